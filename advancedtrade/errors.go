@@ -0,0 +1,61 @@
+package advancedtrade
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/KalebHawkins/crypto-client/coinbase"
+)
+
+// APIError is returned when the Advanced Trade API responds with a non 2xx status. It matches the classification
+// errors of the coinbase package, for example errors.Is(err, coinbase.ErrRateLimited).
+type APIError struct {
+	StatusCode   int    `json:"-"`
+	Status       string `json:"-"`
+	ID           string `json:"error"`
+	Message      string `json:"message"`
+	ErrorDetails string `json:"error_details"`
+	Body         string `json:"-"`
+}
+
+// newAPIError builds an APIError from a failed response and its body. The raw body is kept when it does not
+// contain an error envelope.
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	e := &APIError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+	}
+
+	if err := json.Unmarshal(body, e); err != nil || e.ID == "" {
+		e.Body = string(body)
+	}
+
+	return e
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	if e.ID == "" {
+		return fmt.Sprintf("bad HTTP status return code: %v\n%v", e.Status, e.Body)
+	}
+
+	return fmt.Sprintf("advanced trade API error (%v): %s: %s", e.Status, e.ID, e.Message)
+}
+
+// Is reports whether the error matches one of the coinbase classification errors such as
+// coinbase.ErrUnauthorized.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case coinbase.ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.ID == "UNAUTHENTICATED"
+	case coinbase.ErrForbidden:
+		return e.StatusCode == http.StatusForbidden || e.ID == "PERMISSION_DENIED"
+	case coinbase.ErrNotFound:
+		return e.StatusCode == http.StatusNotFound || e.ID == "NOT_FOUND"
+	case coinbase.ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests || e.ID == "RESOURCE_EXHAUSTED"
+	}
+
+	return false
+}
@@ -0,0 +1,98 @@
+package advancedtrade
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// jwtLifetime is how long a request token is valid for. Coinbase rejects tokens valid for more than two minutes.
+const jwtLifetime = 2 * time.Minute
+
+// parsePrivateKey parses a PEM encoded EC private key in SEC 1 or PKCS #8 form. Escaped newlines, as found when
+// the key is stored in an environment variable, are accepted.
+func parsePrivateKey(pemKey string) (*ecdsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(strings.ReplaceAll(pemKey, `\n`, "\n")))
+	if block == nil {
+		return nil, errors.New("advancedtrade: private key is not PEM encoded")
+	}
+
+	if k, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return k, nil
+	}
+
+	k, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("advancedtrade: invalid private key: %v", err)
+	}
+
+	ec, ok := k.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("advancedtrade: private key is not an EC key")
+	}
+
+	return ec, nil
+}
+
+// createJWT returns the ES256 signed bearer token for a single request. `uri` is the request method followed by
+// the host and path, for example "GET api.coinbase.com/api/v3/brokerage/products".
+func (c Client) createJWT(uri string, now time.Time) (string, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	header, err := json.Marshal(map[string]string{
+		"alg":   "ES256",
+		"typ":   "JWT",
+		"kid":   c.keyName,
+		"nonce": hex.EncodeToString(nonce),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	claims, err := json.Marshal(map[string]interface{}{
+		"sub": c.keyName,
+		"iss": "cdp",
+		"nbf": now.Unix(),
+		"exp": now.Add(jwtLifetime).Unix(),
+		"uri": uri,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+
+	r, s, err := ecdsa.Sign(rand.Reader, c.key, digest[:])
+	if err != nil {
+		return "", err
+	}
+
+	size := (c.key.Curve.Params().BitSize + 7) / 8
+	sig := append(padBytes(r, size), padBytes(s, size)...)
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
+
+// padBytes returns the big-endian bytes of `n` left padded with zeros to `size` bytes.
+func padBytes(n *big.Int, size int) []byte {
+	b := n.Bytes()
+	if len(b) >= size {
+		return b
+	}
+
+	return append(make([]byte, size-len(b)), b...)
+}
@@ -0,0 +1,38 @@
+package advancedtrade
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Option configures a Client when it is created with APIKeyClient() or NewClient().
+type Option func(*Client)
+
+// WithHTTPClient makes the client send its requests using `hc`.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithTimeout sets the time limit for each request made by the client, including reading the response body.
+// The http.Client passed to WithHTTPClient() is copied rather than modified.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		hc := *c.httpClient
+		hc.Timeout = d
+		c.httpClient = &hc
+	}
+}
+
+// WithBaseURL points the client at a different API endpoint, for example a test server. The URL should include
+// the brokerage path such as https://api.coinbase.com/api/v3/brokerage/.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		if !strings.HasSuffix(baseURL, "/") {
+			baseURL += "/"
+		}
+		c.baseURL = baseURL
+	}
+}
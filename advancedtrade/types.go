@@ -0,0 +1,227 @@
+package advancedtrade
+
+import (
+	"crypto/ecdsa"
+	"net/http"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	apiEndpointBase string = "https://api.coinbase.com/api/v3/brokerage/"
+)
+
+// These constants are the sides of an order.
+const (
+	Buy  string = "BUY"
+	Sell string = "SELL"
+)
+
+// Granularity is the time span covered by a single candle.
+type Granularity string
+
+// These constants are the granularities accepted by GetCandles().
+const (
+	OneMinute     Granularity = "ONE_MINUTE"
+	FiveMinute    Granularity = "FIVE_MINUTE"
+	FifteenMinute Granularity = "FIFTEEN_MINUTE"
+	ThirtyMinute  Granularity = "THIRTY_MINUTE"
+	OneHour       Granularity = "ONE_HOUR"
+	TwoHour       Granularity = "TWO_HOUR"
+	SixHour       Granularity = "SIX_HOUR"
+	OneDay        Granularity = "ONE_DAY"
+)
+
+// Client is used to query the Coinbase Advanced Trade API. Create one with APIKeyClient() or NewClient().
+type Client struct {
+	keyName    string
+	key        *ecdsa.PrivateKey
+	baseURL    string
+	httpClient *http.Client
+}
+
+// Number is a decimal value encoded as a JSON string. The API sends empty strings for values that are not known,
+// such as the price of a product that has not traded yet, which decode as zero.
+type Number struct {
+	decimal.Decimal
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *Number) UnmarshalJSON(b []byte) error {
+	if string(b) == `""` || string(b) == "null" {
+		n.Decimal = decimal.Zero
+		return nil
+	}
+
+	return n.Decimal.UnmarshalJSON(b)
+}
+
+// Product is a market that can be traded, for example BTC-USD.
+type Product struct {
+	ProductID                string `json:"product_id"`
+	Price                    Number `json:"price"`
+	PricePercentageChange24h Number `json:"price_percentage_change_24h"`
+	Volume24h                Number `json:"volume_24h"`
+	BaseIncrement            Number `json:"base_increment"`
+	QuoteIncrement           Number `json:"quote_increment"`
+	QuoteMinSize             Number `json:"quote_min_size"`
+	QuoteMaxSize             Number `json:"quote_max_size"`
+	BaseMinSize              Number `json:"base_min_size"`
+	BaseMaxSize              Number `json:"base_max_size"`
+	BaseName                 string `json:"base_name"`
+	QuoteName                string `json:"quote_name"`
+	BaseCurrencyID           string `json:"base_currency_id"`
+	QuoteCurrencyID          string `json:"quote_currency_id"`
+	Status                   string `json:"status"`
+	TradingDisabled          bool   `json:"trading_disabled"`
+	CancelOnly               bool   `json:"cancel_only"`
+	LimitOnly                bool   `json:"limit_only"`
+	PostOnly                 bool   `json:"post_only"`
+	ProductType              string `json:"product_type"`
+}
+
+// Products is used to parse the list of tradable products.
+type Products struct {
+	Products    []Product `json:"products"`
+	NumProducts int       `json:"num_products"`
+}
+
+// Candle is the open, high, low and close price and the traded volume of a product over one period.
+type Candle struct {
+	Start  time.Time
+	Low    decimal.Decimal
+	High   decimal.Decimal
+	Open   decimal.Decimal
+	Close  decimal.Decimal
+	Volume decimal.Decimal
+}
+
+// OrderRequest places an order. Exactly one order configuration must be set, use MarketBuy(), MarketSell() or
+// LimitOrder() to build a valid request.
+type OrderRequest struct {
+	ClientOrderID      string             `json:"client_order_id"`
+	ProductID          string             `json:"product_id"`
+	Side               string             `json:"side"`
+	OrderConfiguration OrderConfiguration `json:"order_configuration"`
+}
+
+// OrderConfiguration describes the type of an order and its sizes.
+type OrderConfiguration struct {
+	MarketIOC *MarketIOC `json:"market_market_ioc,omitempty"`
+	LimitGTC  *LimitGTC  `json:"limit_limit_gtc,omitempty"`
+}
+
+// MarketIOC is a market order filled immediately or canceled. Set QuoteSize to buy for an amount of the quote
+// currency or BaseSize to buy or sell an amount of the base currency.
+type MarketIOC struct {
+	QuoteSize string `json:"quote_size,omitempty"`
+	BaseSize  string `json:"base_size,omitempty"`
+}
+
+// LimitGTC is a limit order that stays on the book until it is filled or canceled.
+type LimitGTC struct {
+	BaseSize   string `json:"base_size"`
+	LimitPrice string `json:"limit_price"`
+	PostOnly   bool   `json:"post_only"`
+}
+
+// CreateOrderResponse is the result of placing an order. Success is false when the order was rejected, the reason
+// is then given in FailureReason and ErrorResponse.
+type CreateOrderResponse struct {
+	Success         bool   `json:"success"`
+	FailureReason   string `json:"failure_reason"`
+	OrderID         string `json:"order_id"`
+	SuccessResponse struct {
+		OrderID       string `json:"order_id"`
+		ProductID     string `json:"product_id"`
+		Side          string `json:"side"`
+		ClientOrderID string `json:"client_order_id"`
+	} `json:"success_response"`
+	ErrorResponse struct {
+		Error                 string `json:"error"`
+		Message               string `json:"message"`
+		ErrorDetails          string `json:"error_details"`
+		PreviewFailureReason  string `json:"preview_failure_reason"`
+		NewOrderFailureReason string `json:"new_order_failure_reason"`
+	} `json:"error_response"`
+}
+
+// Order is an order placed on Advanced Trade.
+type Order struct {
+	OrderID            string             `json:"order_id"`
+	ProductID          string             `json:"product_id"`
+	UserID             string             `json:"user_id"`
+	OrderConfiguration OrderConfiguration `json:"order_configuration"`
+	Side               string             `json:"side"`
+	ClientOrderID      string             `json:"client_order_id"`
+	Status             string             `json:"status"`
+	TimeInForce        string             `json:"time_in_force"`
+	CreatedTime        time.Time          `json:"created_time"`
+	CompletionPercent  Number             `json:"completion_percentage"`
+	FilledSize         Number             `json:"filled_size"`
+	AverageFilledPrice Number             `json:"average_filled_price"`
+	Fee                Number             `json:"fee"`
+	NumberOfFills      Number             `json:"number_of_fills"`
+	FilledValue        Number             `json:"filled_value"`
+	PendingCancel      bool               `json:"pending_cancel"`
+	SizeInQuote        bool               `json:"size_in_quote"`
+	TotalFees          Number             `json:"total_fees"`
+	TotalValueAfterFee Number             `json:"total_value_after_fees"`
+	OrderType          string             `json:"order_type"`
+	RejectReason       string             `json:"reject_reason"`
+	Settled            bool               `json:"settled"`
+	ProductType        string             `json:"product_type"`
+}
+
+// Orders is used to parse a page of historical orders.
+type Orders struct {
+	Orders  []Order `json:"orders"`
+	HasNext bool    `json:"has_next"`
+	Cursor  string  `json:"cursor"`
+}
+
+// OrderFilter narrows down the orders returned by ListOrders(). Zero values are not filtered on.
+type OrderFilter struct {
+	ProductID   string
+	OrderStatus []string
+	Limit       int
+}
+
+// CancelResult is the outcome of canceling a single order.
+type CancelResult struct {
+	Success       bool   `json:"success"`
+	FailureReason string `json:"failure_reason"`
+	OrderID       string `json:"order_id"`
+}
+
+// Fill is a partial or complete execution of an order.
+type Fill struct {
+	EntryID            string    `json:"entry_id"`
+	TradeID            string    `json:"trade_id"`
+	OrderID            string    `json:"order_id"`
+	TradeTime          time.Time `json:"trade_time"`
+	TradeType          string    `json:"trade_type"`
+	Price              Number    `json:"price"`
+	Size               Number    `json:"size"`
+	Commission         Number    `json:"commission"`
+	ProductID          string    `json:"product_id"`
+	SequenceTimestamp  time.Time `json:"sequence_timestamp"`
+	LiquidityIndicator string    `json:"liquidity_indicator"`
+	SizeInQuote        bool      `json:"size_in_quote"`
+	UserID             string    `json:"user_id"`
+	Side               string    `json:"side"`
+}
+
+// Fills is used to parse a page of fills.
+type Fills struct {
+	Fills  []Fill `json:"fills"`
+	Cursor string `json:"cursor"`
+}
+
+// FillFilter narrows down the fills returned by ListFills(). Zero values are not filtered on.
+type FillFilter struct {
+	OrderID   string
+	ProductID string
+	Limit     int
+}
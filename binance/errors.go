@@ -0,0 +1,41 @@
+package binance
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// APIError is returned when the Binance API responds with a non 2xx status. Code and Msg are parsed from the
+// error body, for example -1121 "Invalid symbol.".
+type APIError struct {
+	StatusCode int    `json:"-"`
+	Status     string `json:"-"`
+	Code       int    `json:"code"`
+	Msg        string `json:"msg"`
+	Body       string `json:"-"`
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	if e.Msg == "" {
+		return fmt.Sprintf("bad HTTP status return code: %v\n%v", e.Status, e.Body)
+	}
+
+	return fmt.Sprintf("binance API error (%v): %d: %s", e.Status, e.Code, e.Msg)
+}
+
+// IsAuthError reports whether the error was caused by missing or invalid credentials or an out of sync clock.
+func (e *APIError) IsAuthError() bool {
+	switch e.Code {
+	case -1021, -1022, -2014, -2015:
+		return true
+	}
+
+	return e.StatusCode == http.StatusUnauthorized
+}
+
+// IsRateLimited reports whether the error was caused by exceeding a Binance rate limit. Binance bans the IP
+// address with status 418 when a client keeps sending requests after being rate limited.
+func (e *APIError) IsRateLimited() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode == http.StatusTeapot || e.Code == -1003
+}
@@ -0,0 +1,163 @@
+/*
+Package binance is used to query the Binance API for a user's spot balances and trades and for prices.
+*/
+package binance
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// APIKeyClient sets the API key and API secret for Binance authentication.
+// to use your API Key and API secret set your environment variables.
+//
+//	export BINANCE_KEY="api_key"
+//	export BINANCE_SECRET="api_secret"
+func APIKeyClient() Client {
+	return Client{
+		apiKey:     os.Getenv("BINANCE_KEY"),
+		apiSecret:  os.Getenv("BINANCE_SECRET"),
+		baseURL:    apiEndpointBase,
+		httpClient: &http.Client{},
+	}
+}
+
+// ─── BINANCE METHODS ────────────────────────────────────────────────────────────
+
+// GetBalances upon a successful API request returns every asset held in the spot wallet. Assets with a zero
+// balance are left out. An error is returned if creating or sending the request failed.
+func (c Client) GetBalances() ([]Balance, error) {
+	var account struct {
+		Balances []Balance `json:"balances"`
+	}
+	if err := c.signed("/api/v3/account", url.Values{}, &account); err != nil {
+		return nil, err
+	}
+
+	var balances []Balance
+	for _, b := range account.Balances {
+		if !b.Total().IsZero() {
+			balances = append(balances, b)
+		}
+	}
+
+	return balances, nil
+}
+
+// GetTrades upon a successful API request returns every trade of the user on `symbol`, for example "BTCUSDT",
+// oldest first. All pages are fetched. An error is returned if creating or sending a request failed.
+func (c Client) GetTrades(symbol string) ([]Trade, error) {
+	var trades []Trade
+	fromID := int64(0)
+	for {
+		q := url.Values{
+			"symbol": {symbol},
+			"fromId": {strconv.FormatInt(fromID, 10)},
+			"limit":  {strconv.Itoa(maxTradesLimit)},
+		}
+
+		var page []Trade
+		if err := c.signed("/api/v3/myTrades", q, &page); err != nil {
+			return nil, err
+		}
+		trades = append(trades, page...)
+
+		if len(page) < maxTradesLimit {
+			return trades, nil
+		}
+		fromID = page[len(page)-1].ID + 1
+	}
+}
+
+// GetPrice upon a successful API request returns the last traded price of `symbol`, for example "BTCUSDT".
+// An error is returned if creating or sending the request failed.
+func (c Client) GetPrice(symbol string) (decimal.Decimal, error) {
+	var p struct {
+		Price decimal.Decimal `json:"price"`
+	}
+	err := c.public("/api/v3/ticker/price", url.Values{"symbol": {symbol}}, &p)
+
+	return p.Price, err
+}
+
+// GetBookTicker upon a successful API request returns the best bid and ask of `symbol`. An error is returned if
+// creating or sending the request failed.
+func (c Client) GetBookTicker(symbol string) (BookTicker, error) {
+	var t BookTicker
+	err := c.public("/api/v3/ticker/bookTicker", url.Values{"symbol": {symbol}}, &t)
+
+	return t, err
+}
+
+//
+// ────────────────────────────────────────────────────────── BINANCE METHODS ─────
+//
+
+// ─── HELPER FUNCTIONS ───────────────────────────────────────────────────────────
+
+// public sends an unsigned GET request and parses the response into `v`.
+func (c Client) public(path string, q url.Values, v interface{}) error {
+	req, err := http.NewRequest("GET", c.baseURL+path+"?"+q.Encode(), nil)
+	if err != nil {
+		return err
+	}
+
+	return c.do(req, v)
+}
+
+// signed sends a GET request signed with the API secret and parses the response into `v`.
+func (c Client) signed(path string, q url.Values, v interface{}) error {
+	q.Set("timestamp", strconv.FormatInt(time.Now().UnixNano()/int64(time.Millisecond), 10))
+	query := q.Encode()
+	query += "&signature=" + c.createSignature(query)
+
+	req, err := http.NewRequest("GET", c.baseURL+path+"?"+query, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-MBX-APIKEY", c.apiKey)
+
+	return c.do(req, v)
+}
+
+// createSignature returns the hex encoded HMAC-SHA256 of the query string keyed with the API secret.
+func (c Client) createSignature(query string) string {
+	h := hmac.New(sha256.New, []byte(c.apiSecret))
+	h.Write([]byte(query))
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// do sends a request and parses the response into `v`. A non 2xx response is returned as an *APIError.
+func (c Client) do(req *http.Request, v interface{}) error {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		e := &APIError{StatusCode: resp.StatusCode, Status: resp.Status}
+		if err := json.Unmarshal(body, e); err != nil || e.Msg == "" {
+			e.Body = string(body)
+		}
+		return e
+	}
+
+	return json.Unmarshal(body, v)
+}
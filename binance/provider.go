@@ -0,0 +1,99 @@
+package binance
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/KalebHawkins/crypto-client/exchange"
+	"github.com/KalebHawkins/crypto-client/money"
+)
+
+// transactionQuote is the quote asset of the markets Transactions() reads trades from. Binance only reports
+// trades per market, so every market of every asset cannot be searched.
+const transactionQuote = "USDT"
+
+var _ exchange.Provider = Client{}
+
+// Name implements exchange.Provider.
+func (c Client) Name() string {
+	return "Binance"
+}
+
+// Capabilities implements exchange.Provider. Only reading balances, trades and prices is supported.
+func (c Client) Capabilities() exchange.Capabilities {
+	return nil
+}
+
+// Balances implements exchange.Provider. Free and locked amounts are added up.
+func (c Client) Balances() ([]exchange.Balance, error) {
+	b, err := c.GetBalances()
+	if err != nil {
+		return nil, err
+	}
+
+	var balances []exchange.Balance
+	for _, bal := range b {
+		if bal.Total().IsZero() {
+			continue
+		}
+		balances = append(balances, exchange.Balance{Asset: bal.Asset, Amount: bal.Total()})
+	}
+
+	return balances, nil
+}
+
+// Transactions implements exchange.Provider. The trades of every held asset on its USDT market are returned, the
+// amount is in the asset and the value in USDT.
+func (c Client) Transactions() ([]exchange.Transaction, error) {
+	b, err := c.GetBalances()
+	if err != nil {
+		return nil, err
+	}
+
+	var transactions []exchange.Transaction
+	for _, bal := range b {
+		if bal.Total().IsZero() || bal.Asset == transactionQuote {
+			continue
+		}
+
+		trades, err := c.GetTrades(bal.Asset + transactionQuote)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, t := range trades {
+			amount := t.Qty
+			typ := exchange.Buy
+			if !t.IsBuyer {
+				amount = amount.Neg()
+				typ = exchange.Sell
+			}
+
+			transactions = append(transactions, exchange.Transaction{
+				ID:          t.Symbol + "-" + strconv.FormatInt(t.ID, 10),
+				Type:        typ,
+				Time:        t.ExecutedAt(),
+				Amount:      money.New(amount, bal.Asset),
+				Value:       money.New(t.QuoteQty, transactionQuote),
+				Fee:         money.New(t.Commission, t.CommissionAsset),
+				Description: typ + " " + t.Symbol,
+			})
+		}
+	}
+
+	sort.SliceStable(transactions, func(i, j int) bool {
+		return transactions[i].Time.Before(transactions[j].Time)
+	})
+
+	return transactions, nil
+}
+
+// Price implements exchange.Provider. The price of the last trade is returned.
+func (c Client) Price(pair exchange.Pair) (money.Money, error) {
+	p, err := c.GetPrice(pair.Base + pair.Quote)
+	if err != nil {
+		return money.Money{}, err
+	}
+
+	return money.New(p, pair.Quote), nil
+}
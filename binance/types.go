@@ -0,0 +1,62 @@
+package binance
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	apiEndpointBase string = "https://api.binance.com"
+	maxTradesLimit  int    = 1000
+)
+
+// Client is used to query the Binance API. Create one with APIKeyClient().
+type Client struct {
+	apiKey     string
+	apiSecret  string
+	baseURL    string
+	httpClient *http.Client
+}
+
+// Balance is the amount of an asset held in the spot wallet. Locked funds are reserved by open orders.
+type Balance struct {
+	Asset  string          `json:"asset"`
+	Free   decimal.Decimal `json:"free"`
+	Locked decimal.Decimal `json:"locked"`
+}
+
+// Total returns the free and locked amount.
+func (b Balance) Total() decimal.Decimal {
+	return b.Free.Add(b.Locked)
+}
+
+// BookTicker is the best bid and ask of a symbol.
+type BookTicker struct {
+	Symbol   string          `json:"symbol"`
+	BidPrice decimal.Decimal `json:"bidPrice"`
+	BidQty   decimal.Decimal `json:"bidQty"`
+	AskPrice decimal.Decimal `json:"askPrice"`
+	AskQty   decimal.Decimal `json:"askQty"`
+}
+
+// Trade is a single execution of one of the user's orders.
+type Trade struct {
+	Symbol          string          `json:"symbol"`
+	ID              int64           `json:"id"`
+	OrderID         int64           `json:"orderId"`
+	Price           decimal.Decimal `json:"price"`
+	Qty             decimal.Decimal `json:"qty"`
+	QuoteQty        decimal.Decimal `json:"quoteQty"`
+	Commission      decimal.Decimal `json:"commission"`
+	CommissionAsset string          `json:"commissionAsset"`
+	Time            int64           `json:"time"`
+	IsBuyer         bool            `json:"isBuyer"`
+	IsMaker         bool            `json:"isMaker"`
+}
+
+// ExecutedAt returns the time of the trade.
+func (t Trade) ExecutedAt() time.Time {
+	return time.Unix(0, t.Time*int64(time.Millisecond)).UTC()
+}
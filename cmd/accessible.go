@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/rodaine/table"
+)
+
+var accessible bool
+
+func init() {
+	rootCmd.PersistentFlags().BoolVar(&accessible, "accessible", false, "print labeled lines instead of tables, colors and redraws for screen readers")
+}
+
+// initAccessible turns colors off with --accessible.
+func initAccessible() {
+	if accessible {
+		color.NoColor = true
+	}
+}
+
+// linearTable is a table.Table printing every row as a numbered list of "Header: value" lines. Formatting options
+// only make sense for columns and are ignored.
+type linearTable struct {
+	headers []interface{}
+	rows    [][]interface{}
+	writer  io.Writer
+}
+
+// WithHeaderFormatter implements table.Table.
+func (t *linearTable) WithHeaderFormatter(f table.Formatter) table.Table { return t }
+
+// WithFirstColumnFormatter implements table.Table.
+func (t *linearTable) WithFirstColumnFormatter(f table.Formatter) table.Table { return t }
+
+// WithPadding implements table.Table.
+func (t *linearTable) WithPadding(p int) table.Table { return t }
+
+// WithWidthFunc implements table.Table.
+func (t *linearTable) WithWidthFunc(f table.WidthFunc) table.Table { return t }
+
+// WithWriter implements table.Table.
+func (t *linearTable) WithWriter(w io.Writer) table.Table {
+	if w == nil {
+		w = os.Stdout
+	}
+	t.writer = w
+
+	return t
+}
+
+// AddRow implements table.Table.
+func (t *linearTable) AddRow(vals ...interface{}) table.Table {
+	t.rows = append(t.rows, vals)
+
+	return t
+}
+
+// Print implements table.Table. Empty cells are left out.
+func (t *linearTable) Print() {
+	if len(t.rows) == 0 {
+		fmt.Fprintln(t.writer, "No entries.")
+		return
+	}
+
+	for i, row := range t.rows {
+		fmt.Fprintf(t.writer, "Entry %d of %d.\n", i+1, len(t.rows))
+		for j, v := range row {
+			s := fmt.Sprint(v)
+			if s == "" || j >= len(t.headers) {
+				continue
+			}
+			fmt.Fprintf(t.writer, "%v: %s\n", t.headers[j], s)
+		}
+		fmt.Fprintln(t.writer)
+	}
+}
@@ -0,0 +1,292 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/KalebHawkins/crypto-client/coinbase"
+	"github.com/KalebHawkins/crypto-client/internal/userdata"
+	"github.com/KalebHawkins/crypto-client/money"
+	"github.com/KalebHawkins/crypto-client/notify"
+	"github.com/fatih/color"
+	"github.com/rodaine/table"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+)
+
+// alertsCmd represents the alerts command
+var alertsCmd = &cobra.Command{
+	Use:   "alerts",
+	Short: "get notified when prices cross a threshold.",
+	Long: `Get notified when the spot price of a currency pair rises above or falls below a threshold.
+
+Alerts are stored locally and checked by "alerts run", which polls the prices until interrupted,
+or once with --once for example from cron. An alert fires once when the price crosses its threshold
+and is only re-armed after the price moved back past the threshold by the --hysteresis percentage,
+so a price hovering around the threshold does not fire it over and over.
+
+Fired alerts are printed and also sent to the notification channels in the config file, see
+crypto-client notify -h.
+
+Running this command without a subcommand lists the alerts.
+
+	$ crypto-client alerts add BTC-USD --above 80000
+	$ crypto-client alerts add ETH --below 1500
+	$ crypto-client alerts run --interval 1m
+	$ crypto-client alerts remove 2
+	$ crypto-client alerts
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		d, err := userdata.Load()
+		errHandler(err)
+
+		printAlerts(d.Alerts)
+	},
+}
+
+// alertsAddCmd represents the alerts add command
+var alertsAddCmd = &cobra.Command{
+	Use:   "add <pair>",
+	Short: "add an alert for a price rising above or falling below a threshold.",
+	Args:  cobra.ExactArgs(1),
+
+	Run: func(cmd *cobra.Command, args []string) {
+		a := userdata.Alert{Pair: productIDs(args)[0]}
+		switch {
+		case cmd.Flags().Changed("above") == cmd.Flags().Changed("below"):
+			errHandler(fmt.Errorf("use exactly one of --above or --below"))
+		case cmd.Flags().Changed("above"):
+			a.Condition, a.Price = userdata.Above, alertAbove
+		default:
+			a.Condition, a.Price = userdata.Below, alertBelow
+		}
+
+		if a.Price <= 0 {
+			errHandler(fmt.Errorf("the threshold must be greater than zero"))
+		}
+
+		d, err := userdata.Load()
+		errHandler(err)
+		id := d.AddAlert(a)
+		errHandler(d.Save())
+
+		fmt.Printf("Added alert %d: %s %s %s.\n", id, a.Pair, a.Condition, fmtAlertPrice(a))
+	},
+}
+
+// alertsRemoveCmd represents the alerts remove command
+var alertsRemoveCmd = &cobra.Command{
+	Use:   "remove <id>...",
+	Short: "remove alerts.",
+	Args:  cobra.MinimumNArgs(1),
+
+	Run: func(cmd *cobra.Command, args []string) {
+		d, err := userdata.Load()
+		errHandler(err)
+
+		for _, arg := range args {
+			id, err := strconv.Atoi(arg)
+			errHandler(err)
+			if !d.RemoveAlert(id) {
+				errHandler(fmt.Errorf("there is no alert %d", id))
+			}
+		}
+		errHandler(d.Save())
+	},
+}
+
+// alertsRunCmd represents the alerts run command
+var alertsRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "poll prices and fire alerts until interrupted.",
+	Long: `Poll the spot prices of the alerts every --interval and fire the alerts whose threshold was
+crossed, until interrupted with Ctrl+C. Alerts added or removed while running are picked up on the
+next poll. The interval is stretched if needed to stay well within the Coinbase rate limit, and
+failed price lookups are reported and retried on the next poll.
+
+	$ crypto-client alerts run
+	$ crypto-client alerts run --interval 5m --hysteresis 2
+	$ crypto-client alerts run --once
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		if alertHysteresis < 0 || alertHysteresis >= 100 {
+			errHandler(fmt.Errorf("--hysteresis must be a percentage from 0 up to 100"))
+		}
+
+		n, err := configuredNotifier()
+		errHandler(err)
+
+		runAlerts(newCoinbaseClient(), n, alertInterval, alertHysteresis/100, alertOnce)
+	},
+}
+
+var alertAbove float64
+var alertBelow float64
+var alertInterval time.Duration
+var alertHysteresis float64
+var alertOnce bool
+
+func init() {
+	rootCmd.AddCommand(alertsCmd)
+	alertsCmd.AddCommand(alertsAddCmd)
+	alertsCmd.AddCommand(alertsRemoveCmd)
+	alertsCmd.AddCommand(alertsRunCmd)
+	alertsAddCmd.Flags().Float64Var(&alertAbove, "above", 0, "fire when the price rises to or above this price")
+	alertsAddCmd.Flags().Float64Var(&alertBelow, "below", 0, "fire when the price falls to or below this price")
+	alertsRunCmd.Flags().DurationVar(&alertInterval, "interval", time.Minute, "the time between polls")
+	alertsRunCmd.Flags().Float64Var(&alertHysteresis, "hysteresis", 1, "the percentage the price has to move back past the threshold to re-arm a fired alert")
+	alertsRunCmd.Flags().BoolVar(&alertOnce, "once", false, "poll once and exit")
+}
+
+// runAlerts polls the prices of the stored alerts every `interval` and fires the alerts crossing their threshold,
+// until interrupted or after the first poll with `once`. Fired alerts are also sent to `n` unless it is nil.
+func runAlerts(c coinbase.Client, n notify.Notifier, interval time.Duration, hysteresis float64, once bool) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	for {
+		before := atomic.LoadUint64(&apiRequests)
+		errHandler(pollAlerts(c, n, hysteresis))
+		delay := watchDelay(interval, atomic.LoadUint64(&apiRequests)-before)
+
+		if once {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+	}
+}
+
+// pollAlerts looks up the price of every alert once, fires the alerts that crossed their threshold and stores
+// which alerts are triggered. Prices that cannot be looked up are reported and their alerts left as they are.
+func pollAlerts(c coinbase.Client, n notify.Notifier, hysteresis float64) error {
+	d, err := userdata.Load()
+	if err != nil {
+		return err
+	}
+
+	prices := map[string]money.Money{}
+	changed := map[int]bool{}
+	for _, a := range d.Alerts {
+		price, ok := prices[a.Pair]
+		if !ok {
+			p, err := c.GetPrice(a.Pair, coinbase.Spot)
+			if err != nil {
+				slog.Warn("looking up the alert price", "pair", a.Pair, "error", err)
+				continue
+			}
+			price = p.Data.Money
+			prices[a.Pair] = price
+		}
+
+		triggered := alertTriggered(a, price.Amount, hysteresis)
+		if triggered == a.Triggered {
+			continue
+		}
+
+		changed[a.ID] = triggered
+		if triggered {
+			fireAlert(a, price, n)
+		}
+	}
+
+	if len(changed) == 0 {
+		return nil
+	}
+
+	// Reload so alerts added or removed while polling are kept.
+	d, err = userdata.Load()
+	if err != nil {
+		return err
+	}
+	for id, triggered := range changed {
+		d.SetAlertTriggered(id, triggered)
+	}
+
+	return d.Save()
+}
+
+// alertTriggered reports whether `a` is triggered at `price`. An alert triggers when the price reaches its
+// threshold and, once triggered, stays triggered until the price moved back past the threshold by the fraction
+// `hysteresis` of it.
+func alertTriggered(a userdata.Alert, price decimal.Decimal, hysteresis float64) bool {
+	threshold := decimal.NewFromFloat(a.Price)
+	band := threshold.Mul(decimal.NewFromFloat(hysteresis))
+
+	if a.Condition == userdata.Below {
+		return price.LessThanOrEqual(threshold) || (a.Triggered && price.LessThan(threshold.Add(band)))
+	}
+
+	return price.GreaterThanOrEqual(threshold) || (a.Triggered && price.GreaterThan(threshold.Sub(band)))
+}
+
+// fireAlert reports that `a` was crossed at `price` and sends it to `n` unless it is nil. A failed delivery is
+// reported but does not stop the alerts.
+func fireAlert(a userdata.Alert, price money.Money, n notify.Notifier) {
+	now := time.Now().Format("2006-01-02 15:04:05")
+	title := fmt.Sprintf("%s %s %s", a.Pair, a.Condition, fmtAlertPrice(a))
+	text := fmt.Sprintf("Alert %d: %s is at %s.", a.ID, a.Pair, fmtMoney(price))
+
+	if accessible {
+		fmt.Printf("%s: %s. %s\n", now, title, text)
+	} else {
+		// The bell gets the attention of whoever has the terminal open.
+		fmt.Print("\a")
+		color.Yellow("%s: %s. %s", now, title, text)
+	}
+
+	if n == nil {
+		return
+	}
+
+	if err := n.Notify(notify.Message{Title: title, Text: text}); err != nil {
+		slog.Error("sending alert", "alert", a.ID, "error", err)
+	}
+}
+
+// printAlerts lists `alerts` with whether they are armed or have fired.
+func printAlerts(alerts []userdata.Alert) {
+	if len(alerts) == 0 {
+		fmt.Println("No alerts defined. Add one with `crypto-client alerts add`.")
+		return
+	}
+
+	table.DefaultHeaderFormatter = func(s string, i ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(s, i...))
+	}
+	headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
+	tbl := newTable("ID", "Pair", "Condition", "Price", "State").WithHeaderFormatter(headerFmt)
+
+	for _, a := range alerts {
+		state := "armed"
+		if a.Triggered {
+			state = "fired"
+		}
+		tbl.AddRow(a.ID, a.Pair, a.Condition, fmtAlertPrice(a), state)
+	}
+
+	tbl.Print()
+}
+
+// fmtAlertPrice formats the threshold of `a` in the quote currency of its pair.
+func fmtAlertPrice(a userdata.Alert) string {
+	quote := a.Pair
+	if i := strings.LastIndex(a.Pair, "-"); i >= 0 {
+		quote = a.Pair[i+1:]
+	}
+
+	return fmtMoney(money.New(decimal.NewFromFloat(a.Price), quote))
+}
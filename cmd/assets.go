@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/KalebHawkins/crypto-client/exchange"
+	"github.com/KalebHawkins/crypto-client/internal/userdata"
+	"github.com/fatih/color"
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+)
+
+// assetsCmd represents the assets command
+var assetsCmd = &cobra.Command{
+	Use:   "assets",
+	Short: "manage renamed and delisted assets.",
+	Long: `Manage assets that were renamed or delisted so their history stays linked to a single asset.
+
+Balances, transactions and reports use the current ticker of renamed assets, so for example MATIC
+held before the rename and POL bought afterwards are shown as one POL position. Delisted assets are
+valued at zero instead of failing the price lookup. Common renames are known already, renaming an
+asset to itself overrides a known rename.
+
+Running this command without a subcommand lists every rename and delisted asset.
+
+	$ crypto-client assets rename CGLD CELO
+	$ crypto-client assets delist LUNC
+	$ crypto-client assets forget LUNC
+	$ crypto-client assets
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		d, err := userdata.Load()
+		errHandler(err)
+
+		printAssetMap(d)
+	},
+}
+
+// assetsRenameCmd represents the assets rename command
+var assetsRenameCmd = &cobra.Command{
+	Use:   "rename <old> <current>",
+	Short: "record that an asset is now traded under another ticker.",
+	Args:  cobra.ExactArgs(2),
+
+	Run: func(cmd *cobra.Command, args []string) {
+		d, err := userdata.Load()
+		errHandler(err)
+		d.RenameAsset(args[0], args[1])
+		errHandler(d.Save())
+	},
+}
+
+// assetsDelistCmd represents the assets delist command
+var assetsDelistCmd = &cobra.Command{
+	Use:   "delist <asset>...",
+	Short: "mark assets as no longer traded.",
+	Args:  cobra.MinimumNArgs(1),
+
+	Run: func(cmd *cobra.Command, args []string) {
+		d, err := userdata.Load()
+		errHandler(err)
+		d.Delist(args...)
+		errHandler(d.Save())
+	},
+}
+
+// assetsForgetCmd represents the assets forget command
+var assetsForgetCmd = &cobra.Command{
+	Use:   "forget <asset>...",
+	Short: "remove the renames and delistings you recorded for assets.",
+	Args:  cobra.MinimumNArgs(1),
+
+	Run: func(cmd *cobra.Command, args []string) {
+		d, err := userdata.Load()
+		errHandler(err)
+		for _, a := range args {
+			if !d.ForgetAsset(a) {
+				fmt.Printf("Nothing was recorded for %s.\n", strings.ToUpper(a))
+			}
+		}
+		errHandler(d.Save())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(assetsCmd)
+	assetsCmd.AddCommand(assetsRenameCmd)
+	assetsCmd.AddCommand(assetsDelistCmd)
+	assetsCmd.AddCommand(assetsForgetCmd)
+}
+
+var loadAssetMapOnce sync.Once
+var loadedAssetMap exchange.AssetMap
+
+// assetMap returns the known renames combined with the renames and delistings recorded by the user. It is loaded
+// once per invocation.
+func assetMap() exchange.AssetMap {
+	loadAssetMapOnce.Do(func() {
+		d, err := userdata.Load()
+		errHandler(err)
+		loadedAssetMap = newAssetMap(d)
+	})
+
+	return loadedAssetMap
+}
+
+// newAssetMap combines the known renames with the renames and delistings of `d`. The user's renames win.
+func newAssetMap(d *userdata.Data) exchange.AssetMap {
+	m := exchange.AssetMap{Renames: map[string]string{}, Delisted: map[string]bool{}}
+	for old, current := range exchange.KnownRenames {
+		m.Renames[old] = current
+	}
+	for old, current := range d.AssetRenames {
+		m.Renames[old] = current
+	}
+	for _, s := range d.DelistedAssets {
+		m.Delisted[s] = true
+	}
+
+	return m
+}
+
+// printAssetMap lists every rename, marking the ones recorded by the user, followed by the delisted assets.
+func printAssetMap(d *userdata.Data) {
+	m := newAssetMap(d)
+
+	table.DefaultHeaderFormatter = func(s string, i ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(s, i...))
+	}
+	headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
+	tbl := newTable("Asset", "Current Ticker", "Status", "Source").WithHeaderFormatter(headerFmt)
+
+	var olds []string
+	for old := range m.Renames {
+		olds = append(olds, old)
+	}
+	sort.Strings(olds)
+
+	for _, old := range olds {
+		source := "known"
+		if _, ok := d.AssetRenames[old]; ok {
+			source = "yours"
+		}
+
+		current := m.Canonical(old)
+		status := "renamed"
+		switch {
+		case current == old:
+			status = "not renamed"
+		case m.IsDelisted(old):
+			status = "renamed, delisted"
+		}
+		tbl.AddRow(old, current, status, source)
+	}
+
+	for _, s := range d.DelistedAssets {
+		if _, ok := m.Renames[s]; !ok {
+			tbl.AddRow(s, s, "delisted", "yours")
+		}
+	}
+
+	tbl.Print()
+}
@@ -0,0 +1,293 @@
+package cmd
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/KalebHawkins/crypto-client/coinbase"
+	"github.com/KalebHawkins/crypto-client/internal/credentials"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// authCmd represents the auth command
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "sign in to Coinbase with OAuth.",
+	Long: `Sign in to Coinbase with OAuth instead of an API key.
+
+Register an OAuth application at https://www.coinbase.com/settings/api with the redirect URI
+http://127.0.0.1:8765/callback and export its client ID and secret.
+
+	export COINBASE_CLIENT_ID="client_id"
+	export COINBASE_CLIENT_SECRET="client_secret"
+
+After signing in the token is stored encrypted in the OS keyring and every command uses it
+instead of COINBASE_KEY and COINBASE_SECRET. Expired access tokens are refreshed
+automatically and the refreshed token is stored again, so you only sign in once.
+
+Alternatively "auth login coinbase" prompts for an API key and secret and stores them in the OS
+keyring (Keychain, Secret Service or Credential Manager). The stored key is used whenever
+COINBASE_KEY is not set.
+
+	$ crypto-client auth login
+	$ crypto-client auth login coinbase
+	$ crypto-client auth status
+	$ crypto-client auth revoke
+	$ crypto-client auth revoke coinbase
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+// authLoginCmd represents the auth login command
+var authLoginCmd = &cobra.Command{
+	Use:   "login [coinbase]",
+	Short: "sign in to Coinbase in the browser, or store a Coinbase API key in the OS keyring.",
+	Args:  cobra.MaximumNArgs(1),
+
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 1 {
+			errHandler(storeAPIKey(args[0], profile))
+			fmt.Println("Stored the Coinbase API key in the OS keyring.")
+			return
+		}
+
+		cfg := oauthConfig()
+		if cfg.ClientID == "" || cfg.ClientSecret == "" {
+			errHandler(errors.New("COINBASE_CLIENT_ID and COINBASE_CLIENT_SECRET must be set to sign in"))
+		}
+
+		t, err := authorize(cfg)
+		errHandler(err)
+		errHandler(credentials.SaveToken(profile, t))
+
+		fmt.Println("Signed in to Coinbase with scopes:", t.Scope)
+	},
+}
+
+// authStatusCmd represents the auth status command
+var authStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "show how crypto-client authenticates to Coinbase.",
+
+	Run: func(cmd *cobra.Command, args []string) {
+		t, err := credentials.LoadToken(profile)
+		errHandler(err)
+
+		if t.AccessToken != "" {
+			fmt.Println("Stored OAuth token:", "yes")
+			if !t.Expiry.IsZero() {
+				fmt.Println("Access token expires:", t.Expiry.Local().Format("2006-01-02 15:04"))
+			}
+			fmt.Println("Refreshable:", t.RefreshToken != "" && oauthConfig().ClientID != "")
+		} else {
+			fmt.Println("Stored OAuth token:", "no")
+		}
+
+		_, err = coinbase.KeyringClient(profile)
+		switch {
+		case errors.Is(err, coinbase.ErrNoKeyringCredentials):
+			fmt.Println("Stored API key in keyring:", "no")
+		case err != nil:
+			fmt.Println("Stored API key in keyring:", err)
+		default:
+			fmt.Println("Stored API key in keyring:", "yes")
+		}
+
+		info, err := newCoinbaseClient().GetAuthInfo()
+		errHandler(err)
+
+		fmt.Println("Method:", info.Data.Method)
+		fmt.Println("Scopes:", strings.Join(info.Data.Scopes, ", "))
+	},
+}
+
+// authRevokeCmd represents the auth revoke command
+var authRevokeCmd = &cobra.Command{
+	Use:   "revoke [coinbase]",
+	Short: "revoke the stored OAuth token and forget it, or remove the API key from the OS keyring.",
+	Args:  cobra.MaximumNArgs(1),
+
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 1 {
+			errHandler(checkKeyringProvider(args[0]))
+			errHandler(coinbase.DeleteKeyring(profile))
+			fmt.Println("Removed the Coinbase API key from the OS keyring.")
+			return
+		}
+
+		t, err := credentials.LoadToken(profile)
+		errHandler(err)
+
+		if t.AccessToken == "" {
+			errHandler(errors.New("not signed in to Coinbase with OAuth"))
+		}
+
+		if err := coinbase.OAuthClient(t, coinbaseOptions()...).RevokeToken(); err != nil {
+			fmt.Fprintln(os.Stderr, "warning:", err)
+		}
+
+		errHandler(credentials.DeleteToken(profile))
+		fmt.Println("Signed out of Coinbase.")
+	},
+}
+
+var authListen string
+var authScopes []string
+
+func init() {
+	rootCmd.AddCommand(authCmd)
+	authCmd.AddCommand(authLoginCmd)
+	authCmd.AddCommand(authStatusCmd)
+	authCmd.AddCommand(authRevokeCmd)
+	authLoginCmd.Flags().StringVar(&authListen, "listen", "127.0.0.1:8765", "the address receiving the OAuth redirect")
+	authLoginCmd.Flags().StringSliceVar(&authScopes, "scopes", []string{
+		"wallet:user:read",
+		"wallet:accounts:read",
+		"wallet:transactions:read",
+		"wallet:payment-methods:read",
+		"wallet:addresses:read",
+	}, "the permissions to request")
+}
+
+// oauthConfig returns the OAuth application credentials set in the environment. Refreshed tokens are stored.
+func oauthConfig() coinbase.OAuthConfig {
+	return coinbase.OAuthConfig{
+		ClientID:     os.Getenv("COINBASE_CLIENT_ID"),
+		ClientSecret: os.Getenv("COINBASE_CLIENT_SECRET"),
+		OnRefresh: func(t coinbase.Token) {
+			if err := credentials.SaveToken(profile, t); err != nil {
+				slog.Warn("storing the refreshed OAuth token", "error", err)
+			}
+		},
+	}
+}
+
+// storeAPIKey prompts for an API key and secret of `provider`, checks them against the API and stores them in the
+// OS keyring for `profile`. The secret is not echoed when read from a terminal.
+func storeAPIKey(provider string, profile string) error {
+	if err := checkKeyringProvider(provider); err != nil {
+		return err
+	}
+
+	in := bufio.NewReader(os.Stdin)
+
+	fmt.Print("Coinbase API key: ")
+	key, err := in.ReadString('\n')
+	if err != nil {
+		return err
+	}
+
+	fmt.Print("Coinbase API secret: ")
+	var secret string
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		b, err := term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Println()
+		if err != nil {
+			return err
+		}
+		secret = string(b)
+	} else if secret, err = in.ReadString('\n'); err != nil {
+		return err
+	}
+
+	key, secret = strings.TrimSpace(key), strings.TrimSpace(secret)
+	if key == "" || secret == "" {
+		return errors.New("both the API key and the API secret are required")
+	}
+
+	if err := coinbase.StoreKeyring(profile, key, secret); err != nil {
+		return fmt.Errorf("storing the API key in the OS keyring: %w", err)
+	}
+
+	c, err := coinbase.KeyringClient(profile, coinbaseOptions()...)
+	if err == nil {
+		_, err = c.GetAuthInfo()
+	}
+	if err != nil {
+		coinbase.DeleteKeyring(profile)
+		return fmt.Errorf("the API key was not stored: %w", err)
+	}
+
+	return nil
+}
+
+// checkKeyringProvider returns an error unless API keys of `provider` can be stored in the OS keyring.
+func checkKeyringProvider(provider string) error {
+	if !strings.EqualFold(provider, "coinbase") {
+		return fmt.Errorf("storing API keys in the OS keyring is not supported for %q, only coinbase", provider)
+	}
+
+	return nil
+}
+
+// authorize sends the user to the Coinbase consent page and waits for the redirect carrying the authorization
+// code, which is then exchanged for a token.
+func authorize(cfg coinbase.OAuthConfig) (coinbase.Token, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return coinbase.Token{}, err
+	}
+	state := hex.EncodeToString(b)
+	redirectURI := fmt.Sprintf("http://%s/callback", authListen)
+
+	ln, err := net.Listen("tcp", authListen)
+	if err != nil {
+		return coinbase.Token{}, err
+	}
+
+	codes := make(chan string, 1)
+	errs := make(chan error, 1)
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch {
+		case r.URL.Path != "/callback":
+			http.NotFound(w, r)
+			return
+		case q.Get("state") != state:
+			http.Error(w, "invalid state", http.StatusBadRequest)
+			return
+		case q.Get("error") != "":
+			fmt.Fprintln(w, "Signing in failed, you can close this window.")
+			select {
+			case errs <- fmt.Errorf("signing in failed: %s %s", q.Get("error"), q.Get("error_description")):
+			default:
+			}
+			return
+		}
+
+		fmt.Fprintln(w, "Signed in, you can close this window.")
+		select {
+		case codes <- q.Get("code"):
+		default:
+		}
+	})}
+	go srv.Serve(ln)
+	defer srv.Close()
+
+	fmt.Println("Open the following URL in your browser to sign in to Coinbase:")
+	fmt.Println()
+	fmt.Println(coinbase.AuthorizeURL(cfg, redirectURI, state, authScopes))
+	fmt.Println()
+
+	select {
+	case code := <-codes:
+		return coinbase.ExchangeCode(cfg, code, redirectURI)
+	case err := <-errs:
+		return coinbase.Token{}, err
+	case <-time.After(5 * time.Minute):
+		return coinbase.Token{}, errors.New("timed out waiting for the Coinbase sign in")
+	}
+}
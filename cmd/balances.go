@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/KalebHawkins/crypto-client/exchange"
+	"github.com/KalebHawkins/crypto-client/money"
+	"github.com/fatih/color"
+	"github.com/rodaine/table"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+)
+
+// balancesCmd represents the balances command
+var balancesCmd = &cobra.Command{
+	Use:   "balances",
+	Short: "show your balances across every configured provider.",
+	Long: `Show the balances held with every provider whose credentials are set, valued in USD or the
+currency given with --currency, followed by the total of each asset across providers.
+
+Every balance is priced by the provider holding it. A provider that cannot be reached is reported
+and left out of the totals.
+
+	$ crypto-client balances
+	$ crypto-client balances --currency EUR
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		start := time.Now()
+
+		printBalances(configuredProviders(), fiatCurrency("USD"))
+
+		fmt.Println()
+		fmt.Println("Elapsed Run Time:", time.Since(start))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(balancesCmd)
+}
+
+// printBalances prints the balances of `providers` valued in `quote` and the total of each asset.
+func printBalances(providers []exchange.Provider, quote string) {
+	if len(providers) == 0 {
+		errHandler(fmt.Errorf("no provider credentials are set, see crypto-client -h"))
+	}
+
+	table.DefaultHeaderFormatter = func(s string, i ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(s, i...))
+	}
+	headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
+	tbl := newTable("Provider", "Asset", "Balance", "Spot Price Per Unit", "Total Value").WithHeaderFormatter(headerFmt)
+
+	amounts := map[string]decimal.Decimal{}
+	values := map[string]money.Money{}
+	total := money.Zero(quote)
+
+	for _, p := range providers {
+		balances, err := p.Balances()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", p.Name(), err)
+			continue
+		}
+		sort.Slice(balances, func(i, j int) bool { return balances[i].Asset < balances[j].Asset })
+
+		for _, b := range balances {
+			amounts[b.Asset] = amounts[b.Asset].Add(b.Amount)
+
+			price := money.New(decimal.NewFromInt(1), quote)
+			if b.Asset != quote {
+				price, err = p.Price(exchange.NewPair(b.Asset, quote))
+				if err != nil {
+					tbl.AddRow(p.Name(), b.Asset, fmtAmount(b.Amount, b.Asset), "n/a", "n/a")
+					continue
+				}
+			}
+
+			value := price.Mul(b.Amount)
+			tbl.AddRow(p.Name(), b.Asset, fmtAmount(b.Amount, b.Asset), fmtMoney(price), fmtMoney(value))
+
+			values[b.Asset] = values[b.Asset].Add(value)
+			total = total.Add(value)
+		}
+	}
+
+	tbl.Print()
+	fmt.Println()
+
+	var assets []string
+	for a := range amounts {
+		assets = append(assets, a)
+	}
+	sort.Strings(assets)
+
+	totals := newTable("Asset", "Balance", "Total Value").WithHeaderFormatter(headerFmt)
+	for _, a := range assets {
+		v := "n/a"
+		if m, ok := values[a]; ok {
+			v = fmtMoney(m)
+		}
+		totals.AddRow(a, fmtAmount(amounts[a], a), v)
+	}
+	totals.Print()
+
+	fmt.Printf("Total Value: %s\n", fmtMoney(total))
+}
@@ -0,0 +1,247 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/KalebHawkins/crypto-client/coinbase"
+	"github.com/KalebHawkins/crypto-client/coinbasetest"
+	"github.com/fatih/color"
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+)
+
+// benchCmd represents the bench command
+var benchCmd = &cobra.Command{
+	Use:   "bench",
+	Short: "benchmark crypto-client against a mock Coinbase server.",
+	Long: `Benchmark crypto-client against an in-process mock Coinbase server, no credentials or network
+access are needed.
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+// benchSoakCmd represents the bench soak command
+var benchSoakCmd = &cobra.Command{
+	Use:   "soak",
+	Short: "sync repeatedly against a mock server and report throughput, memory and goroutines.",
+	Long: `Sync the accounts, prices and transactions of the mock Coinbase server at a fixed rate for a
+long time and report the throughput, heap size and number of goroutines every --report interval.
+A heap or goroutine count that keeps growing points at a leak. The run stops early with Ctrl+C.
+
+Syncs that are due while every worker is busy are skipped and counted as dropped. Combine it with
+--inject-faults to soak the retry handling as well.
+
+	$ crypto-client bench soak --duration 1h
+	$ crypto-client bench soak --duration 10m --rate 50 --workers 8 --report 30s
+	$ crypto-client bench soak --inject-faults 429=0.05,5xx=0.05,truncate=0.01
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		if soakRate <= 0 || soakWorkers <= 0 || soakReport <= 0 {
+			errHandler(fmt.Errorf("--rate, --workers and --report must be positive"))
+		}
+
+		srv := coinbasetest.NewServer()
+		defer srv.Close()
+
+		samples := soak(srv, soakDuration, soakRate, soakWorkers, soakReport)
+		printSoakSamples(samples)
+	},
+}
+
+var soakDuration time.Duration
+var soakRate float64
+var soakWorkers int
+var soakReport time.Duration
+
+func init() {
+	rootCmd.AddCommand(benchCmd)
+	benchCmd.AddCommand(benchSoakCmd)
+	benchSoakCmd.Flags().DurationVar(&soakDuration, "duration", time.Hour, "how long to run")
+	benchSoakCmd.Flags().Float64Var(&soakRate, "rate", 10, "the number of syncs started per second")
+	benchSoakCmd.Flags().IntVar(&soakWorkers, "workers", 4, "the number of syncs running at the same time")
+	benchSoakCmd.Flags().DurationVar(&soakReport, "report", time.Minute, "the time between reports")
+}
+
+// soakSample is the state of a soak test at the end of a report interval.
+type soakSample struct {
+	elapsed    time.Duration
+	syncs      uint64
+	failures   uint64
+	dropped    uint64
+	throughput float64
+	heap       uint64
+	goroutines int
+}
+
+// soak syncs against `srv` at `rate` syncs per second using `workers` goroutines until `duration` passed or the
+// run is interrupted, and returns a sample taken every `report`.
+func soak(srv *coinbasetest.Server, duration time.Duration, rate float64, workers int, report time.Duration) []soakSample {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	ctx, cancel := context.WithTimeout(ctx, duration)
+	defer cancel()
+
+	c := srv.Client(coinbase.WithRetries(2), coinbase.WithBackoff(10*time.Millisecond, 100*time.Millisecond))
+
+	var syncs, failures, dropped uint64
+	jobs := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range jobs {
+				if err := syncCoinbase(c); err != nil {
+					atomic.AddUint64(&failures, 1)
+				}
+				atomic.AddUint64(&syncs, 1)
+			}
+		}()
+	}
+
+	var samples []soakSample
+	var last soakSample
+	sample := func(elapsed time.Duration) {
+		// Collect first so the heap reflects live memory rather than garbage awaiting collection.
+		runtime.GC()
+		var m runtime.MemStats
+		runtime.ReadMemStats(&m)
+		srv.ResetRequests()
+
+		s := soakSample{
+			elapsed:    elapsed,
+			syncs:      atomic.LoadUint64(&syncs),
+			failures:   atomic.LoadUint64(&failures),
+			dropped:    atomic.LoadUint64(&dropped),
+			heap:       m.HeapAlloc,
+			goroutines: runtime.NumGoroutine(),
+		}
+		if d := (s.elapsed - last.elapsed).Seconds(); d > 0 {
+			s.throughput = float64(s.syncs-last.syncs) / d
+		}
+		last = s
+		samples = append(samples, s)
+
+		fmt.Fprintf(os.Stderr, "%s: %d syncs (%.1f/s), %d failed, %d dropped, heap %s, %d goroutines\n",
+			s.elapsed.Round(time.Second), s.syncs, s.throughput, s.failures, s.dropped, fmtBytes(s.heap), s.goroutines)
+	}
+
+	start := time.Now()
+	sample(0)
+
+	tick := time.NewTicker(time.Duration(float64(time.Second) / rate))
+	defer tick.Stop()
+	reports := time.NewTicker(report)
+	defer reports.Stop()
+
+loop:
+	for {
+		select {
+		case <-ctx.Done():
+			break loop
+		case <-reports.C:
+			sample(time.Since(start))
+		case <-tick.C:
+			select {
+			case jobs <- struct{}{}:
+			default:
+				atomic.AddUint64(&dropped, 1)
+			}
+		}
+	}
+
+	close(jobs)
+	wg.Wait()
+	sample(time.Since(start))
+
+	return samples
+}
+
+// syncCoinbase loads what the overview needs: the user, every account and the prices and transactions of every
+// account holding a balance.
+func syncCoinbase(c coinbase.Client) error {
+	user, err := c.GetUserProfile()
+	if err != nil {
+		return err
+	}
+
+	accounts, err := c.GetAccount()
+	if err != nil {
+		return err
+	}
+
+	for _, act := range accounts.Data {
+		if !act.Balance.Amount.IsPositive() {
+			continue
+		}
+
+		pair := act.Balance.Currency + "-" + user.Data.NativeCurrency
+		for _, t := range []string{coinbase.Spot, coinbase.Buy, coinbase.Sell} {
+			if _, err := c.GetPrice(pair, t); err != nil {
+				return err
+			}
+		}
+
+		if _, err := c.GetTransactionHistory(act.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// printSoakSamples prints every sample followed by the growth of the heap and goroutines since the first report,
+// which leaves out the warm up of connections and caches.
+func printSoakSamples(samples []soakSample) {
+	table.DefaultHeaderFormatter = func(s string, i ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(s, i...))
+	}
+	headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
+	tbl := newTable("Elapsed", "Syncs", "Syncs/s", "Failed", "Dropped", "Heap", "Goroutines").WithHeaderFormatter(headerFmt)
+
+	for _, s := range samples {
+		tbl.AddRow(s.elapsed.Round(time.Second), s.syncs, fmt.Sprintf("%.1f", s.throughput), s.failures, s.dropped,
+			fmtBytes(s.heap), s.goroutines)
+	}
+
+	tbl.Print()
+
+	first, last := samples[0], samples[len(samples)-1]
+	if len(samples) > 2 {
+		first = samples[1]
+	}
+
+	growth := fmtBytes(last.heap - first.heap)
+	if last.heap < first.heap {
+		growth = "-" + fmtBytes(first.heap-last.heap)
+	}
+
+	fmt.Println()
+	fmt.Printf("Heap growth since %s: %s, goroutine growth: %d\n", first.elapsed.Round(time.Second), growth,
+		last.goroutines-first.goroutines)
+}
+
+// fmtBytes formats a number of bytes with a binary unit.
+func fmtBytes(b uint64) string {
+	units := []string{"B", "KiB", "MiB", "GiB"}
+	f := float64(b)
+	i := 0
+	for f >= 1024 && i < len(units)-1 {
+		f /= 1024
+		i++
+	}
+
+	return fmt.Sprintf("%.1f %s", f, units[i])
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/KalebHawkins/crypto-client/binance"
+	"github.com/KalebHawkins/crypto-client/money"
+	"github.com/fatih/color"
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+)
+
+// binanceCmd represents the binance command
+var binanceCmd = &cobra.Command{
+	Use:   "binance",
+	Short: "interact with the Binance API.",
+	Long: `Interact with the Binance API.
+
+Create a read only API key at https://www.binance.com/en/my/settings/api-management and
+export the BINANCE_KEY and BINANCE_SECRET environment variables.
+
+	[Linux]
+	export BINANCE_KEY="API_KEY"
+	export BINANCE_SECRET="API_SECRET"
+
+	[Windows (Powershell)]
+	$env:BINANCE_KEY = "API_KEY"
+	$env:BINANCE_SECRET = "API_SECRET"
+
+Without flags an overview of your spot wallet is shown, valued in USDT or the asset given with --quote.
+Binance only reports trades per market, the amount invested counts buys on the quote market only.
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		start := time.Now()
+
+		getBinanceOverview()
+
+		fmt.Println()
+		fmt.Println("Elapsed Run Time:", time.Since(start))
+	},
+}
+
+var binanceQuote string
+
+func init() {
+	rootCmd.AddCommand(binanceCmd)
+	binanceCmd.Flags().StringVar(&binanceQuote, "quote", "USDT", "the asset to value holdings in")
+}
+
+// getBinanceOverview will output a wholistic overview of your Binance spot wallet.
+func getBinanceOverview() {
+	c := binance.APIKeyClient()
+	quote := strings.ToUpper(binanceQuote)
+
+	balances, err := c.GetBalances()
+	errHandler(err)
+
+	table.DefaultHeaderFormatter = func(format string, vals ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(format, vals...))
+	}
+	headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
+
+	tbl := newTable("Asset", "Balance", "Spot Price Per Unit", "Buy Price Per Unit", "Sell Price Per Unit",
+		"Total Sell Out Price", "Invested", "Total Return").WithHeaderFormatter(headerFmt)
+
+	totalSellOut := money.Zero(quote)
+	totalReturn := money.Zero(quote)
+	for _, b := range balances {
+		amt := b.Total()
+
+		if b.Asset == quote {
+			tbl.AddRow(b.Asset, fmtAmount(amt, b.Asset), "", "", "", fmtMoney(money.New(amt, quote)), "", "")
+			totalSellOut = totalSellOut.Add(money.New(amt, quote))
+			continue
+		}
+
+		symbol := b.Asset + quote
+		last, err := c.GetPrice(symbol)
+		if err != nil {
+			tbl.AddRow(b.Asset, fmtAmount(amt, b.Asset), "n/a", "n/a", "n/a", "n/a", "n/a", "n/a")
+			continue
+		}
+
+		book, err := c.GetBookTicker(symbol)
+		errHandler(err)
+
+		trades, err := c.GetTrades(symbol)
+		errHandler(err)
+
+		invested := money.Zero(quote)
+		for _, t := range trades {
+			if !t.IsBuyer {
+				continue
+			}
+			invested = invested.Add(money.New(t.QuoteQty, quote))
+			if t.CommissionAsset == quote {
+				invested = invested.Add(money.New(t.Commission, quote))
+			}
+		}
+
+		sellOut := money.New(book.BidPrice.Mul(amt), quote)
+		ret := sellOut.Sub(invested)
+
+		tbl.AddRow(b.Asset, fmtAmount(amt, b.Asset),
+			fmtMoney(money.New(last, quote)),
+			fmtMoney(money.New(book.AskPrice, quote)),
+			fmtMoney(money.New(book.BidPrice, quote)),
+			fmtMoney(sellOut),
+			fmtMoney(invested),
+			fmtMoney(ret))
+
+		totalSellOut = totalSellOut.Add(sellOut)
+		totalReturn = totalReturn.Add(ret)
+	}
+
+	tbl.Print()
+
+	fmt.Printf("Total Sell Out Amount: %s\n", fmtMoney(totalSellOut))
+	fmt.Printf("Total Return Amount: %s\n", fmtMoney(totalReturn))
+}
@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/KalebHawkins/crypto-client/coinbase"
+	"github.com/KalebHawkins/crypto-client/internal/store"
+	"github.com/fatih/color"
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+)
+
+// cacheCmd represents the cache command
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "keep the Coinbase history in a local database.",
+	Long: `Keep the Coinbase accounts, transactions and historical prices in a local database, so repeated
+runs only download the transactions created since the last run and every historical price once.
+
+Pass --cache to any command, or set it in the config file, to read the history through the local
+database. Every profile has its own database in the configuration directory. Transactions that
+were pending are downloaded again until they settle.
+
+	cache: true
+
+With --offline nothing is downloaded. The overview, transactions and portfolio commands render
+from the store as of the last sync, and a note on stderr tells how old the oldest data shown is.
+"cache sync" stores everything the overview needs. Data that was never stored is an error.
+
+Running this command without a subcommand shows what is stored.
+
+	$ crypto-client cache sync
+	$ crypto-client coinbase --list-transactions --cache
+	$ crypto-client coinbase --offline
+	$ crypto-client cache
+	$ crypto-client cache clear
+
+Buys and sells made elsewhere are imported from CSV with "cache import", see its help.
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		printCacheStatus(openStore())
+	},
+}
+
+// cacheSyncCmd represents the cache sync command
+var cacheSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "download the accounts and the transactions created since the last sync.",
+
+	Run: func(cmd *cobra.Command, args []string) {
+		start := time.Now()
+
+		// The sync goes through the store whether or not --cache is set, and stores the amounts in the native currency.
+		c := nativeClient(newCoinbaseClient())
+		sc, ok := c.(store.Client)
+		if !ok {
+			sc = store.NewClient(c, openStore())
+		}
+
+		syncStore(sc)
+
+		fmt.Println()
+		fmt.Println("Elapsed Run Time:", time.Since(start))
+	},
+}
+
+// cacheClearCmd represents the cache clear command
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "remove everything stored but imported trades, the next sync downloads the whole history again.",
+
+	Run: func(cmd *cobra.Command, args []string) {
+		s := openStore()
+		errHandler(s.Clear())
+
+		fmt.Println("Cleared", s.Path())
+	},
+}
+
+var useCache bool
+
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cacheSyncCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+	rootCmd.PersistentFlags().BoolVar(&useCache, "cache", false, "keep the Coinbase history in a local database and only download what changed, see crypto-client cache -h")
+}
+
+var storeOnce sync.Once
+var openedStore *store.Store
+
+// openStore opens the store of the current profile once per run.
+func openStore() *store.Store {
+	storeOnce.Do(func() {
+		path, err := store.Path(profile)
+		errHandler(err)
+
+		openedStore, err = store.Open(path)
+		errHandler(err)
+	})
+
+	return openedStore
+}
+
+// withStore returns `c` reading the history through the local store when --cache is set, otherwise `c` itself.
+func withStore(c coinbase.Client) coinbase.Client {
+	if !useCache {
+		return c
+	}
+
+	return store.NewClient(c, openStore())
+}
+
+// syncStore syncs the user profile, the accounts, the transactions of every account and the current prices of the
+// held assets, so the overview can be rendered --offline, and lists how many transactions were fetched.
+func syncStore(c store.Client) {
+	user, err := c.GetUserProfile()
+	errHandler(err)
+
+	accounts, err := c.GetAccount()
+	errHandler(err)
+
+	table.DefaultHeaderFormatter = func(s string, i ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(s, i...))
+	}
+	headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
+	tbl := newTable("Account", "Fetched").WithHeaderFormatter(headerFmt)
+
+	total := 0
+	for _, a := range accounts.Data {
+		n, err := c.Sync(a.ID)
+		errHandler(err)
+
+		total += n
+		tbl.AddRow(a.Name, n)
+
+		if a.Balance.Amount.IsPositive() {
+			for _, t := range []string{coinbase.Spot, coinbase.Buy, coinbase.Sell} {
+				_, err := c.GetPrice(a.Balance.Currency+"-"+user.Data.NativeCurrency, t)
+				errHandler(err)
+			}
+		}
+	}
+
+	tbl.Print()
+
+	fmt.Println()
+	fmt.Println("Transactions Fetched:", total)
+}
+
+// printCacheStatus prints what `s` holds and when every stored account was last synced.
+func printCacheStatus(s *store.Store) {
+	st, err := s.Stats()
+	errHandler(err)
+
+	fmt.Println("Database:", s.Path())
+	fmt.Println("Size:", fmtBytes(uint64(st.Size)))
+	fmt.Println("Transactions:", st.Transactions)
+	fmt.Println("Historical Prices:", st.Prices)
+	fmt.Println("Imported Trades:", st.Trades)
+
+	accounts, at, ok, err := s.Accounts()
+	errHandler(err)
+	if !ok {
+		fmt.Println("Accounts: 0")
+		return
+	}
+	fmt.Printf("Accounts: %d, as of %s\n", st.Accounts, at.Local().Format("2006-01-02 15:04"))
+
+	table.DefaultHeaderFormatter = func(s string, i ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(s, i...))
+	}
+	headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
+	tbl := newTable("Account", "Last Sync").WithHeaderFormatter(headerFmt)
+
+	for _, a := range accounts.Data {
+		at, err := s.Synced(a.ID)
+		errHandler(err)
+
+		synced := "never"
+		if !at.IsZero() {
+			synced = at.Local().Format("2006-01-02 15:04")
+		}
+		tbl.AddRow(a.Name, synced)
+	}
+
+	fmt.Println()
+	tbl.Print()
+}
@@ -0,0 +1,252 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/KalebHawkins/crypto-client/coinbase"
+	"github.com/KalebHawkins/crypto-client/internal/store"
+	"github.com/KalebHawkins/crypto-client/money"
+	"github.com/KalebHawkins/crypto-client/portfolio"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+)
+
+// cacheImportCmd represents the cache import command
+var cacheImportCmd = &cobra.Command{
+	Use:   "import FILE...",
+	Short: "import buys and sells made elsewhere from CSV into the local database.",
+	Long: `Import buys and sells made outside Coinbase, on another exchange or a DEX, from CSV files into the
+local database, so the cost basis and portfolio commands see the complete history. Name where the
+trades were made with --source. Importing a file again replaces the trades imported from it before,
+including rows since removed or edited, and "cache clear" keeps them. Remove every trade of a source
+with --remove.
+
+	$ crypto-client cache import kraken.csv --source kraken
+	$ crypto-client portfolio --cache
+	$ crypto-client cache import --source kraken --remove
+
+Imported trades are added to the cost basis of the overview, the exporter and the gRPC server
+whenever the local database is used, with --cache or --offline. The CSV has a header row naming the columns below in any order,
+the same names as "coinbase transactions --export" writes. The export also fills the fee columns of
+sends, with the network fee in the sent asset, but sends are not trades and cannot be imported.
+
+	date             when the trade was made, RFC 3339, YYYY-MM-DD HH:MM:SS or YYYY-MM-DD in local time
+	type             buy or sell
+	asset            the asset bought or sold, for example BTC
+	amount           the amount of the asset, a negative amount is taken as its absolute value
+	native_amount    what the buy cost or the sell paid out, fees included, in your native currency
+	native_currency  optional, must be your native currency
+	fee              optional, the fee paid in your native currency
+	fee_currency     optional, must be your native currency
+	txid             optional, the ID of the trade at the source, unique within the file. Without
+	                 one the row and how often the same row came before it are hashed, so
+	                 identical rows stay separate trades
+
+Imported trades only enter the cost basis of their asset. The transaction history, the ledger
+and the balances stay what Coinbase recorded. Trades of assets Coinbase has no wallet for, such
+as many DEX tokens, are imported as well.
+
+Moves between Coinbase and elsewhere stay what Coinbase recorded: a receive into Coinbase is an
+acquisition at its value when received and a send out of Coinbase a transfer out. Import the
+trades of assets that never moved through Coinbase, otherwise the moved amount is counted twice.
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		if importSource == "" {
+			errHandler(fmt.Errorf("name where the trades were made with --source"))
+		}
+
+		s := openStore()
+
+		if importRemove {
+			n, err := s.RemoveTrades(importSource)
+			errHandler(err)
+
+			fmt.Printf("Removed %d trades imported from %s\n", n, importSource)
+			return
+		}
+
+		if len(args) == 0 {
+			errHandler(fmt.Errorf("give the CSV files to import"))
+		}
+
+		c := nativeClient(newCoinbaseClient())
+		user, err := c.GetUserProfile()
+		errHandler(err)
+
+		for _, path := range args {
+			f, err := os.Open(path)
+			errHandler(err)
+
+			trades, err := readTradesCSV(f, importSource, user.Data.NativeCurrency)
+			f.Close()
+			errHandler(err)
+
+			file := filepath.Base(path)
+			for i := range trades {
+				trades[i].File = file
+			}
+			errHandler(s.ReplaceTrades(importSource, file, trades))
+			fmt.Printf("Imported %d trades from %s\n", len(trades), path)
+		}
+	},
+}
+
+var importSource string
+var importRemove bool
+
+func init() {
+	cacheCmd.AddCommand(cacheImportCmd)
+	cacheImportCmd.Flags().StringVar(&importSource, "source", "", "where the trades were made, for example kraken")
+	cacheImportCmd.Flags().BoolVar(&importRemove, "remove", false, "remove the trades imported from --source instead of importing")
+}
+
+// importedEvents returns the cost basis events of the trades imported for `asset`. They are only read when the
+// local database is used, with --cache or --offline.
+func importedEvents(asset string) ([]portfolio.Event, error) {
+	if !useCache && !offline {
+		return nil, nil
+	}
+
+	return openStore().Events(asset)
+}
+
+// tradeDateLayouts are the accepted layouts of the date column, tried in order.
+var tradeDateLayouts = []string{time.RFC3339Nano, "2006-01-02 15:04:05", "2006-01-02T15:04:05", "2006-01-02"}
+
+// readTradesCSV reads the trades of `source` from the CSV in `r`, described in the help of cacheImportCmd. Amounts
+// must be in `native`. An error naming the line is returned if a row is invalid or two rows have the same txid.
+func readTradesCSV(r io.Reader, source, native string) ([]store.Trade, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := map[string]int{}
+	for i, h := range header {
+		columns[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	for _, c := range []string{"date", "type", "asset", "amount", "native_amount"} {
+		if _, ok := columns[c]; !ok {
+			return nil, fmt.Errorf("the CSV has no %s column", c)
+		}
+	}
+
+	var trades []store.Trade
+	ids := map[string]int{}
+	occurrences := map[string]int{}
+	for {
+		row, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := cr.FieldPos(0)
+
+		field := func(name string) string {
+			i, ok := columns[name]
+			if !ok || i >= len(row) {
+				return ""
+			}
+			return strings.TrimSpace(row[i])
+		}
+
+		t, err := parseTrade(field, source, native)
+		if err == nil && t.ID == "" {
+			// Identical rows are separate trades, such as the same buy made twice on a day.
+			key := strings.Join(row, "\x00")
+			occurrences[key]++
+			sum := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%d", key, occurrences[key])))
+			t.ID = hex.EncodeToString(sum[:8])
+		}
+		if first, ok := ids[t.ID]; ok && err == nil {
+			err = fmt.Errorf("txid %s is also on line %d", t.ID, first)
+		}
+		if err == nil {
+			err = t.Validate()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		ids[t.ID] = line
+		trades = append(trades, t)
+	}
+
+	return trades, nil
+}
+
+// parseTrade returns the trade of a CSV row whose columns `field` returns by name, without an ID when the row has
+// no txid. An error is returned if a column is invalid.
+func parseTrade(field func(name string) string, source, native string) (store.Trade, error) {
+	t := store.Trade{ID: field("txid"), Source: source, Side: strings.ToLower(field("type")), Asset: strings.ToUpper(field("asset"))}
+
+	var err error
+	if t.Time, err = parseTradeDate(field("date")); err != nil {
+		return t, err
+	}
+
+	if t.Side != coinbase.Buy && t.Side != coinbase.Sell {
+		return t, fmt.Errorf("type %q is not buy or sell", field("type"))
+	}
+
+	amount, err := decimal.NewFromString(field("amount"))
+	if err != nil {
+		return t, fmt.Errorf("invalid amount %q", field("amount"))
+	}
+	t.Amount = amount.Abs()
+
+	total, err := decimal.NewFromString(field("native_amount"))
+	if err != nil {
+		return t, fmt.Errorf("invalid native_amount %q", field("native_amount"))
+	}
+
+	for _, c := range []string{"native_currency", "fee_currency"} {
+		if cur := field(c); cur != "" && !strings.EqualFold(cur, native) {
+			return t, fmt.Errorf("%s %s is not your native currency %s", c, cur, native)
+		}
+	}
+
+	fee := decimal.Zero
+	if f := field("fee"); f != "" {
+		if fee, err = decimal.NewFromString(f); err != nil {
+			return t, fmt.Errorf("invalid fee %q", f)
+		}
+	}
+	t.Fee = money.New(fee.Abs(), native)
+
+	// The native amount includes the fee, the trade keeps the value traded and the fee apart.
+	t.Value = money.New(total.Abs(), native).Sub(t.Fee)
+	if t.Side == coinbase.Sell {
+		t.Value = money.New(total.Abs(), native).Add(t.Fee)
+	}
+
+	return t, nil
+}
+
+// parseTradeDate parses `s` in the first of tradeDateLayouts it matches, in local time when it has no zone.
+func parseTradeDate(s string) (time.Time, error) {
+	for _, layout := range tradeDateLayouts {
+		if d, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return d, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date %q, use RFC 3339, YYYY-MM-DD HH:MM:SS or YYYY-MM-DD", s)
+}
@@ -3,14 +3,18 @@ package cmd
 import (
 	"fmt"
 	"os"
-	"strconv"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/KalebHawkins/crypto-client/coinbase"
+	"github.com/KalebHawkins/crypto-client/internal/userdata"
+	"github.com/KalebHawkins/crypto-client/money"
+	"github.com/KalebHawkins/crypto-client/portfolio"
 	"github.com/fatih/color"
 	"github.com/rodaine/table"
+	"github.com/shopspring/decimal"
 	"github.com/spf13/cobra"
 )
 
@@ -39,6 +43,28 @@ environment variables. To do this see the examples below.
 	$env:COINBASE_KEY = "API_KEY"
 	$env:COINBASE_SECRET = "API_SECRET"
 
+Narrow --list-transactions down with --since and --until (YYYY-MM-DD, both inclusive), --type
+and --asset, the last two accept several comma separated values.
+
+	$ crypto-client coinbase -t --since 2023-01-01 --type buy,sell --asset BTC
+
+To export the transactions as CSV for spreadsheets and tax tools, see coinbase transactions --help.
+
+The overview shows the average buy price of every asset held along with its realized gain, from
+sells and trades, and its unrealized gain, the spot value of the amount held minus what it cost.
+The total return is their sum. Sold amounts are matched to the oldest lots first, use --cost-basis lifo to
+match the newest or --cost-basis hifo the most expensive lots first instead.
+
+Amounts are shown in your native currency. Give --currency EUR, or set currency in the config file,
+to show them in another fiat currency instead, converted at the current rate of the Coinbase
+exchange-rates endpoint. Past amounts such as what was invested are converted at the rate of the
+day of their transaction.
+
+Use --watch to refresh the output every 30 seconds, or every --interval, until interrupted.
+
+To try out buying, selling and transfers without touching real funds, create sandbox credentials
+and also set COINBASE_SANDBOX=1. Every request is then sent to the Coinbase sandbox instead.
+
 Supported operations for Coinbase is depicted in the table below.
 
 	╔═════════════════════════════════════════╤══════════════════╗
@@ -52,45 +78,73 @@ Supported operations for Coinbase is depicted in the table below.
 	╟─────────────────────────────────────────┼──────────────────╢
 	║ Buy crypto                              │ work in progress ║
 	╟─────────────────────────────────────────┼──────────────────╢
-	║ Sell crypto                             │ work in progress ║
+	║ Sell crypto                             │ yes              ║
 	╟─────────────────────────────────────────┼──────────────────╢
 	║ Set profile information                 │ work in progress ║
 	╚═════════════════════════════════════════╧══════════════════╝
 `,
 
 	Run: func(cmd *cobra.Command, args []string) {
-		start := time.Now()
-
-		if listTransactions {
-			getCoinbaseTransactions()
+		if asOf != "" && !listAccounts {
+			errHandler(fmt.Errorf("--as-of only applies to --list-accounts"))
 		}
 
-		if listAccounts {
-			getCoinbaseAccounts()
-		}
+		date, err := parseAsOf(asOf)
+		errHandler(err)
 
-		if !listAccounts && !listTransactions {
-			getCoinbaseOverview()
-		}
+		watch(func() {
+			start := time.Now()
 
-		fmt.Println()
-		fmt.Println("Elapsed Run Time:", time.Since(start))
+			if listTransactions {
+				getCoinbaseTransactions()
+			}
+
+			if listAccounts {
+				if !date.IsZero() {
+					getCoinbaseAccountsAsOf(date)
+				} else {
+					getCoinbaseAccounts()
+				}
+			}
+
+			if !listAccounts && !listTransactions {
+				getCoinbaseOverview()
+			}
+
+			fmt.Println()
+			fmt.Println("Elapsed Run Time:", time.Since(start))
+		})
 	},
 }
 
 var listTransactions bool
 var listAccounts bool
+var asOf string
+var filterTag string
+var costBasis string
+var filterSince string
+var filterUntil string
+var filterTypes []string
+var filterAssets []string
 
 func init() {
 	rootCmd.AddCommand(coinbaseCmd)
 	coinbaseCmd.Flags().BoolVarP(&listTransactions, "list-transactions", "t", false, "list all your accounts transactions")
 	coinbaseCmd.Flags().BoolVarP(&listAccounts, "list-accounts", "a", false, "list all your accounts")
+	coinbaseCmd.Flags().StringVar(&asOf, "as-of", "", "reconstruct account balances as of a date (YYYY-MM-DD), used with --list-accounts")
+	coinbaseCmd.Flags().StringVar(&filterTag, "tag", "", "only list accounts or transactions carrying this tag")
+	coinbaseCmd.Flags().StringVar(&filterSince, "since", "", "only list transactions made on or after this date (YYYY-MM-DD)")
+	coinbaseCmd.Flags().StringVar(&filterUntil, "until", "", "only list transactions made on or before this date (YYYY-MM-DD)")
+	coinbaseCmd.Flags().StringSliceVar(&filterTypes, "type", nil, "only list transactions of these types, for example buy, sell, send or inflation_reward")
+	coinbaseCmd.Flags().StringSliceVar(&filterAssets, "asset", nil, "only list transactions of these assets, for example BTC")
+	coinbaseCmd.Flags().StringVar(&costBasis, "cost-basis", string(portfolio.FIFO), "how sold lots are matched to buys for the gains in the overview: fifo, lifo or hifo")
+	addWatchFlags(coinbaseCmd)
 }
 
 // getCoinbaseOverview will output a wholistic overview of your Coinbase account and assets.
 // This is the default when running `crypto-client coinbase` without additional flags.
 func getCoinbaseOverview() {
-	c := coinbase.APIKeyClient()
+	c := newCoinbaseClient()
 	user, err := c.GetUserProfile()
 	errHandler(err)
 	fmt.Println(user)
@@ -101,82 +155,126 @@ func getCoinbaseOverview() {
 
 	headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
 
-	tbl := table.New("Wallet", "Balance", "Currency", "Spot Price Per Unit",
+	method, err := portfolio.ParseMethod(costBasis)
+	errHandler(err)
+
+	tbl := newTable("Wallet", "Balance", "Currency", "Spot Price Per Unit",
 		"Buy Price Per Unit", "Sell Price Per Unit", "Total Sell Out Price", "Invested",
-		"Inflation Rewards", "Total Return")
+		"Inflation Rewards", "Average Buy Price", "Realized Gain", "Unrealized Gain", "Total Return")
 	tbl.WithHeaderFormatter(headerFmt)
 
-	account, err := c.GetAccount()
+	account, err := getTrackedAccounts(c)
 	errHandler(err)
 
-	var totalSellOutAmount float64
-	var totalReturnAmount float64
+	totalSellOutAmount := money.Zero(user.Data.NativeCurrency)
+	totalPnL := portfolio.PnL{Realized: money.Zero(user.Data.NativeCurrency), Unrealized: money.Zero(user.Data.NativeCurrency)}
+	totalSpotValue := money.Zero(user.Data.NativeCurrency)
+	balances := map[string]decimal.Decimal{}
+	books := map[string]*portfolio.Book{}
 
+	var pairs []string
 	for _, act := range account.Data {
-		amt, err := strconv.ParseFloat(act.Balance.Amount, 64)
-		errHandler(err)
+		if act.Balance.Amount.IsPositive() {
+			pairs = append(pairs, fmt.Sprintf("%s-%s", act.Balance.Currency, user.Data.NativeCurrency))
+		}
+	}
+	spotPrices, err := c.GetPrices(pairs, coinbase.Spot)
+	errHandler(err)
+	buyPrices, err := c.GetPrices(pairs, coinbase.Buy)
+	errHandler(err)
+	sellPrices, err := c.GetPrices(pairs, coinbase.Sell)
+	errHandler(err)
 
-		if amt > 0 {
+	for _, act := range account.Data {
+		amt := act.Balance.Amount
 
-			currencyPair := fmt.Sprintf("%s-%s", act.Balance.Currency, user.Data.NativeCurrency)
+		if amt.IsPositive() {
+			balances[act.Balance.Currency] = balances[act.Balance.Currency].Add(amt)
 
-			spotPrice, err := c.GetPrice(currencyPair, coinbase.Spot)
-			errHandler(err)
-			spotAmt, err := strconv.ParseFloat(spotPrice.Data.Amount, 64)
-			errHandler(err)
-			buyPrice, err := c.GetPrice(currencyPair, coinbase.Buy)
-			errHandler(err)
-			bpAmt, err := strconv.ParseFloat(buyPrice.Data.Amount, 64)
-			errHandler(err)
-
-			sellPrice, err := c.GetPrice(currencyPair, coinbase.Sell)
-			errHandler(err)
-			sellAmt, err := strconv.ParseFloat(sellPrice.Data.Amount, 64)
-			errHandler(err)
+			currencyPair := fmt.Sprintf("%s-%s", act.Balance.Currency, user.Data.NativeCurrency)
+			spotPrice, buyPrice, sellPrice := spotPrices[currencyPair], buyPrices[currencyPair], sellPrices[currencyPair]
 
-			var invested float64
-			var inflationRewards float64
+			invested := money.Zero(user.Data.NativeCurrency)
+			inflationRewards := money.Zero(act.Balance.Currency)
 
 			transactions, err := c.GetTransactionHistory(act.ID)
 			errHandler(err)
 
 			for _, tr := range transactions.Data {
-				trNcAmt, err := strconv.ParseFloat(tr.NativeAmount.Amount, 64)
-				errHandler(err)
-				trAmt, err := strconv.ParseFloat(tr.Amount.Amount, 64)
-				errHandler(err)
-
 				switch tr.Type {
 				case coinbase.Buy:
-					invested += trNcAmt
+					v, err := nativeValue(c, tr, user.Data.NativeCurrency)
+					errHandler(err)
+					invested = invested.Add(v)
 				case coinbase.InflationReward:
-					inflationRewards += trAmt
+					inflationRewards = inflationRewards.Add(tr.Amount)
 				}
 
 			}
 
-			sellOutAmount := amt * sellAmt
-			returnAmount := sellOutAmount - invested
+			asset := assetMap().Canonical(act.Balance.Currency)
+			book, ok := books[asset]
+			if !ok {
+				book, err = costBasisBook(c, method, user.Data.NativeCurrency, asset, account)
+				errHandler(err)
+				books[asset] = book
+				if book.Skipped > 0 {
+					fmt.Fprintf(os.Stderr, "warning: %d %s transactions valued in another currency are left out of the gains\n",
+						book.Skipped, asset)
+				}
+			}
+			position := book.Position(asset)
+			pnl := position.PnL(spotPrice.Data.Money)
+
+			sellOutAmount := sellPrice.Data.Mul(amt)
+
+			tbl.AddRow(act.Name, fmtAmount(amt, act.Balance.Currency), act.Balance.Currency,
+				fmtMoney(spotPrice.Data.Money),
+				fmtMoney(buyPrice.Data.Money),
+				fmtMoney(sellPrice.Data.Money),
+				fmtMoney(sellOutAmount),
+				fmtMoney(invested),
+				fmtMoney(inflationRewards),
+				fmtMoney(position.AverageCost()),
+				fmtMoney(pnl.Realized),
+				fmtMoney(pnl.Unrealized),
+				fmtMoney(pnl.Total()))
+
+			totalSellOutAmount = totalSellOutAmount.Add(sellOutAmount)
+			totalSpotValue = totalSpotValue.Add(spotPrice.Data.Mul(amt))
+			if !ok {
+				totalPnL = totalPnL.Add(pnl)
+			}
 
-			tbl.AddRow(act.Name, fmt.Sprintf("%f", amt), act.Balance.Currency,
-				fmt.Sprintf("%.2f %s", spotAmt, spotPrice.Data.Currency),
-				fmt.Sprintf("%.2f %s", bpAmt, buyPrice.Data.Currency),
-				fmt.Sprintf("%.2f %s", sellAmt, sellPrice.Data.Currency),
-				fmt.Sprintf("%.2f %s", sellOutAmount, sellPrice.Data.Currency),
-				fmt.Sprintf("%.2f %s", invested, user.Data.NativeCurrency),
-				fmt.Sprintf("%f %s", inflationRewards, act.Balance.Currency),
-				fmt.Sprintf("%.2f %s", returnAmount, user.Data.NativeCurrency))
+		}
+	}
+
+	tbl.Print()
 
-			totalSellOutAmount += amt * sellAmt
-			totalReturnAmount += returnAmount
+	fmt.Printf("Total Sell Out Amount: %s\n", fmtMoney(totalSellOutAmount))
+	fmt.Printf("Total Realized Gain: %s\n", fmtMoney(totalPnL.Realized))
+	fmt.Printf("Total Unrealized Gain: %s\n", fmtMoney(totalPnL.Unrealized))
+	fmt.Printf("Total Return Amount: %s\n", fmtMoney(totalPnL.Total()))
 
+	d, err := userdata.Load()
+	errHandler(err)
+
+	var watching []string
+	for _, w := range d.Watchlist {
+		if balances[w].IsZero() {
+			watching = append(watching, w)
 		}
 	}
 
-	tbl.Print()
+	if len(watching) > 0 {
+		fmt.Println()
+		printWatchlist(c, watching, user.Data.NativeCurrency)
+	}
 
-	fmt.Printf("Total Sell Out Amount: %.2f %s\n", totalSellOutAmount, user.Data.NativeCurrency)
-	fmt.Printf("Total Return Amount: %.2f %s\n", totalReturnAmount, user.Data.NativeCurrency)
+	if len(d.Goals) > 0 {
+		fmt.Println()
+		printGoalProgress(d.Goals, balances, totalSpotValue)
+	}
 }
 
 // getCoinbaseTransactions will list all past transactions the currency and a summary.
@@ -185,32 +283,112 @@ func getCoinbaseTransactions() {
 		return strings.ToUpper(fmt.Sprintf(s, i...))
 	}
 	headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
-	tbl := table.New("Transaction Type", "Crypto", "Amount", "Date", "Payment Method", "Summary").WithHeaderFormatter(headerFmt)
+	tbl := newTable("Transaction Type", "Crypto", "Amount", "Date", "Payment Method", "Summary", "Tags").WithHeaderFormatter(headerFmt)
 
-	c := coinbase.APIKeyClient()
+	notes, err := userdata.Load()
+	errHandler(err)
 
-	accounts, err := c.GetAccount()
+	transactions, err := selectTransactions(newCoinbaseClient(), notes)
 	errHandler(err)
 
+	for _, t := range transactions {
+		tbl.AddRow(t.Type, t.Amount.Currency, fmtAmount(t.Amount.Amount, t.Amount.Currency), t.CreatedAt, t.Details.PaymentMethodName, t.Details.Header, strings.Join(transactionTags(notes, t), ","))
+	}
+
+	tbl.Print()
+}
+
+// selectTransactions fetches the transactions of every tracked wallet concurrently and returns those selected by
+// --since, --until, --type, --asset and --tag, oldest first. An error is returned if fetching any of them failed.
+func selectTransactions(c coinbase.Client, notes *userdata.Data) ([]coinbase.TransactionData, error) {
+	filter, err := transactionFilter()
+	if err != nil {
+		return nil, err
+	}
+
+	accounts, err := getTrackedAccounts(c)
+	if err != nil {
+		return nil, err
+	}
+
+	histories := make([][]coinbase.TransactionData, len(accounts.Data))
+	errs := make([]error, len(accounts.Data))
 	var wg sync.WaitGroup
-	for _, a := range accounts.Data {
+	for i, a := range accounts.Data {
 		wg.Add(1)
-		go func(accountID string) {
+		go func(i int, accountID string) {
 			defer wg.Done()
 			tr, err := c.GetTransactionHistory(accountID)
-			errHandler(err)
+			histories[i], errs[i] = tr.Data, err
+		}(i, a.ID)
+	}
+	wg.Wait()
 
-			for _, t := range tr.Data {
-				tAmt, err := strconv.ParseFloat(t.Amount.Amount, 64)
-				errHandler(err)
+	var selected []coinbase.TransactionData
+	for i, history := range histories {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
 
-				tbl.AddRow(t.Type, t.Amount.Currency, tAmt, t.CreatedAt, t.Details.PaymentMethodName, t.Details.Header)
+		for _, t := range filter.Apply(history) {
+			if filterTag != "" && !(userdata.Annotation{Tags: transactionTags(notes, t)}).HasTag(filterTag) {
+				continue
 			}
-		}(a.ID)
+			selected = append(selected, t)
+		}
 	}
-	wg.Wait()
 
-	tbl.Print()
+	sort.SliceStable(selected, func(i, j int) bool {
+		if !selected[i].CreatedAt.Equal(selected[j].CreatedAt) {
+			return selected[i].CreatedAt.Before(selected[j].CreatedAt)
+		}
+		return selected[i].ID < selected[j].ID
+	})
+
+	return selected, nil
+}
+
+// transactionTags returns the tags of the asset of `t` followed by those of `t` itself.
+func transactionTags(notes *userdata.Data, t coinbase.TransactionData) []string {
+	var tags []string
+	tags = append(tags, notes.Asset(t.Amount.Currency).Tags...)
+	tags = append(tags, notes.Transaction(t.ID).Tags...)
+
+	return tags
+}
+
+// transactionFilter builds the filter selected by --since, --until, --type and --asset. Old tickers of the assets
+// are included so transactions made before a rename are listed too.
+func transactionFilter() (coinbase.TransactionFilter, error) {
+	var f coinbase.TransactionFilter
+
+	if filterSince != "" {
+		d, err := time.ParseInLocation("2006-01-02", filterSince, time.Local)
+		if err != nil {
+			return f, fmt.Errorf("invalid --since: %w", err)
+		}
+		f.Since = d
+	}
+
+	if filterUntil != "" {
+		d, err := time.ParseInLocation("2006-01-02", filterUntil, time.Local)
+		if err != nil {
+			return f, fmt.Errorf("invalid --until: %w", err)
+		}
+		f.Until = d.AddDate(0, 0, 1)
+	}
+
+	if !f.Since.IsZero() && !f.Until.IsZero() && !f.Since.Before(f.Until) {
+		return f, fmt.Errorf("--since %s is after --until %s", filterSince, filterUntil)
+	}
+
+	f.Types = filterTypes
+	for _, a := range filterAssets {
+		f.Assets = append(f.Assets, assetMap().Canonical(a))
+		f.Assets = append(f.Assets, assetMap().Aliases(a)...)
+	}
+
+	return f, nil
 }
 
 // getCoinbaseAccounts will list all your coinbase accounts that contain assets.
@@ -220,37 +398,70 @@ func getCoinbaseAccounts() {
 		return strings.ToUpper(fmt.Sprintf(s, i...))
 	}
 	headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
-	tbl := table.New("Wallet", "Balance", "Native").WithHeaderFormatter(headerFmt)
+	tbl := newTable("Wallet", "Balance", "Native", "Tags").WithHeaderFormatter(headerFmt)
 
-	c := coinbase.APIKeyClient()
-	user, err := c.GetUserProfile()
+	notes, err := userdata.Load()
 	errHandler(err)
 
-	acts, err := c.GetAccount()
+	c := newCoinbaseClient()
+	user, err := c.GetUserProfile()
 	errHandler(err)
 
-	var wg sync.WaitGroup
-	wg.Add(len(acts.Data))
+	acts, err := getTrackedAccounts(c)
+	errHandler(err)
 
 	for _, a := range acts.Data {
-		amt, err := strconv.ParseFloat(a.Balance.Amount, 64)
-		errHandler(err)
-		if amt > 0 {
+		tags := notes.Asset(a.Balance.Currency)
+		if filterTag != "" && !tags.HasTag(filterTag) {
+			continue
+		}
+
+		if a.Balance.IsPositive() {
 			currencyPair := fmt.Sprintf("%s-%s", a.Balance.Currency, user.Data.NativeCurrency)
 			spotPrice, err := c.GetPrice(currencyPair, coinbase.Spot)
 			errHandler(err)
-			sAmt, err := strconv.ParseFloat(spotPrice.Data.Amount, 64)
-			errHandler(err)
 
-			tbl.AddRow(a.Name, a.Balance.Amount, fmt.Sprintf("%.2f %s", sAmt*amt, user.Data.NativeCurrency))
+			tbl.AddRow(a.Name, fmtAmount(a.Balance.Amount, a.Balance.Currency), fmtMoney(spotPrice.Data.Mul(a.Balance.Amount)), strings.Join(tags.Tags, ","))
 		}
 	}
 
 	tbl.Print()
 }
 
-// errHandler is a short hand error handler.
+// getCoinbaseAccountsAsOf will list your coinbase accounts as they were at the end of the given date.
+// Balances are rebuilt from the transaction history by loadCoinbaseHoldingsAsOf().
+func getCoinbaseAccountsAsOf(date time.Time) {
+	table.DefaultHeaderFormatter = func(s string, i ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(s, i...))
+	}
+	headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
+	tbl := newTable("Wallet", "Balance", "Native", "Tags").WithHeaderFormatter(headerFmt)
+
+	notes, err := userdata.Load()
+	errHandler(err)
+
+	holdings, _, err := loadCoinbaseHoldingsAsOf(newCoinbaseClient(), date)
+	errHandler(err)
+
+	for _, h := range holdings {
+		tags := notes.Asset(h.Currency)
+		if filterTag != "" && !tags.HasTag(filterTag) {
+			continue
+		}
+
+		tbl.AddRow(h.Wallet, fmtAmount(h.Amount, h.Currency), fmtMoney(h.Value()), strings.Join(tags.Tags, ","))
+	}
+
+	fmt.Println("Balances as of", date.Format("2006-01-02"))
+	tbl.Print()
+}
+
+// errHandler is a short hand error handler. While watching it only aborts the current render, see watch().
 func errHandler(e error) {
+	if e != nil && watching {
+		panic(watchAbort{e})
+	}
+
 	if e != nil {
 		fmt.Fprintf(os.Stderr, "%v\n", e)
 		os.Exit(1)
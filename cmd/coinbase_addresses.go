@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// coinbaseAddressesCmd represents the coinbase addresses command
+var coinbaseAddressesCmd = &cobra.Command{
+	Use:   "addresses",
+	Short: "list or create receive addresses for a wallet.",
+	Long: `List or create receive addresses for one of your Coinbase wallets.
+
+	$ crypto-client coinbase addresses --asset BTC
+	$ crypto-client coinbase addresses --asset BTC --create --name "hardware wallet withdrawals"
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newCoinbaseClient()
+
+		accountID, err := findCoinbaseAccountID(c, addressAsset)
+		errHandler(err)
+
+		if createAddress {
+			addr, err := c.CreateAddress(accountID, addressName)
+			errHandler(err)
+			fmt.Println(addr)
+			return
+		}
+
+		addrs, err := c.GetAddresses(accountID)
+		errHandler(err)
+		fmt.Println(addrs)
+	},
+}
+
+var addressAsset string
+var addressName string
+var createAddress bool
+
+func init() {
+	coinbaseCmd.AddCommand(coinbaseAddressesCmd)
+	coinbaseAddressesCmd.Flags().StringVar(&addressAsset, "asset", "", "the crypto currency of the wallet, for example BTC")
+	coinbaseAddressesCmd.Flags().BoolVar(&createAddress, "create", false, "create a new receive address")
+	coinbaseAddressesCmd.Flags().StringVar(&addressName, "name", "", "label for a newly created address")
+	coinbaseAddressesCmd.MarkFlagRequired("asset")
+}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/KalebHawkins/crypto-client/coinbase/exchange"
+	"github.com/KalebHawkins/crypto-client/money"
+	"github.com/fatih/color"
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+)
+
+// coinbaseExchangeCmd represents the coinbase-exchange command
+var coinbaseExchangeCmd = &cobra.Command{
+	Use:   "coinbase-exchange",
+	Short: "interact with the Coinbase Exchange (institutional) API.",
+	Long: `Interact with the Coinbase Exchange API used by institutional accounts.
+
+Exchange API keys are separate from retail Coinbase API keys. Create a view only key for your
+profile at https://exchange.coinbase.com/profile/api and export it with its passphrase.
+
+	[Linux]
+	export COINBASE_EXCHANGE_KEY="API_KEY"
+	export COINBASE_EXCHANGE_SECRET="API_SECRET"
+	export COINBASE_EXCHANGE_PASSPHRASE="PASSPHRASE"
+
+	[Windows (Powershell)]
+	$env:COINBASE_EXCHANGE_KEY = "API_KEY"
+	$env:COINBASE_EXCHANGE_SECRET = "API_SECRET"
+	$env:COINBASE_EXCHANGE_PASSPHRASE = "PASSPHRASE"
+
+Without a subcommand an overview of the profile accounts is shown, valued in USD or the currency
+given with --currency.
+
+	$ crypto-client coinbase-exchange
+	$ crypto-client coinbase-exchange fills BTC-USD
+	$ crypto-client coinbase-exchange transfers
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		start := time.Now()
+
+		getCoinbaseExchangeOverview()
+
+		fmt.Println()
+		fmt.Println("Elapsed Run Time:", time.Since(start))
+	},
+}
+
+// coinbaseExchangeFillsCmd represents the coinbase-exchange fills command
+var coinbaseExchangeFillsCmd = &cobra.Command{
+	Use:   "fills <product>",
+	Short: "list the fills of a product such as BTC-USD.",
+	Args:  cobra.ExactArgs(1),
+
+	Run: func(cmd *cobra.Command, args []string) {
+		fills, err := newCoinbaseExchangeClient().GetFills(strings.ToUpper(args[0]))
+		errHandler(err)
+
+		table.DefaultHeaderFormatter = func(s string, i ...interface{}) string {
+			return strings.ToUpper(fmt.Sprintf(s, i...))
+		}
+		headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
+		tbl := newTable("Date", "Product", "Side", "Size", "Price", "Fee", "Liquidity", "Settled").WithHeaderFormatter(headerFmt)
+
+		for _, f := range fills {
+			tbl.AddRow(f.CreatedAt.Local().Format("2006-01-02 15:04"), f.ProductID, f.Side, f.Size.String(),
+				f.Price.String(), f.Fee.String(), f.Liquidity, f.Settled)
+		}
+
+		tbl.Print()
+	},
+}
+
+// coinbaseExchangeTransfersCmd represents the coinbase-exchange transfers command
+var coinbaseExchangeTransfersCmd = &cobra.Command{
+	Use:   "transfers",
+	Short: "list the deposits and withdrawals of the profile.",
+
+	Run: func(cmd *cobra.Command, args []string) {
+		transfers, err := newCoinbaseExchangeClient().GetTransfers()
+		errHandler(err)
+
+		table.DefaultHeaderFormatter = func(s string, i ...interface{}) string {
+			return strings.ToUpper(fmt.Sprintf(s, i...))
+		}
+		headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
+		tbl := newTable("Date", "Type", "Status", "Currency", "Amount", "Tx Hash").WithHeaderFormatter(headerFmt)
+
+		for _, t := range transfers {
+			tbl.AddRow(t.CreatedAt.Local().Format("2006-01-02 15:04"), t.Type, t.Status(), t.Currency,
+				t.Amount.String(), t.Details.CryptoTransactionHash)
+		}
+
+		tbl.Print()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(coinbaseExchangeCmd)
+	coinbaseExchangeCmd.AddCommand(coinbaseExchangeFillsCmd)
+	coinbaseExchangeCmd.AddCommand(coinbaseExchangeTransfersCmd)
+}
+
+// newCoinbaseExchangeClient creates the Coinbase Exchange client from the API key set in the environment.
+func newCoinbaseExchangeClient() exchange.Client {
+	c, err := exchange.APIKeyClient()
+	errHandler(err)
+
+	return c
+}
+
+// getCoinbaseExchangeOverview will output a wholistic overview of your Coinbase Exchange profile accounts.
+func getCoinbaseExchangeOverview() {
+	c := newCoinbaseExchangeClient()
+	quote := fiatCurrency("USD")
+
+	accounts, err := c.GetAccounts()
+	errHandler(err)
+
+	table.DefaultHeaderFormatter = func(format string, vals ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(format, vals...))
+	}
+	headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
+
+	tbl := newTable("Asset", "Balance", "Hold", "Spot Price Per Unit", "Buy Price Per Unit", "Sell Price Per Unit",
+		"Total Sell Out Price").WithHeaderFormatter(headerFmt)
+
+	totalSellOut := money.Zero(quote)
+	for _, a := range accounts {
+		if a.Balance.IsZero() {
+			continue
+		}
+
+		if a.Currency == quote {
+			tbl.AddRow(a.Currency, fmtAmount(a.Balance, a.Currency), fmtAmount(a.Hold, a.Currency), "", "", "",
+				fmtMoney(money.New(a.Balance, quote)))
+			totalSellOut = totalSellOut.Add(money.New(a.Balance, quote))
+			continue
+		}
+
+		t, err := c.GetTicker(a.Currency + "-" + quote)
+		if err != nil {
+			tbl.AddRow(a.Currency, fmtAmount(a.Balance, a.Currency), fmtAmount(a.Hold, a.Currency), "n/a", "n/a", "n/a", "n/a")
+			continue
+		}
+
+		sellOut := money.New(t.Bid.Mul(a.Balance), quote)
+		tbl.AddRow(a.Currency, fmtAmount(a.Balance, a.Currency), fmtAmount(a.Hold, a.Currency),
+			fmtMoney(money.New(t.Price, quote)),
+			fmtMoney(money.New(t.Ask, quote)),
+			fmtMoney(money.New(t.Bid, quote)),
+			fmtMoney(sellOut))
+
+		totalSellOut = totalSellOut.Add(sellOut)
+	}
+
+	tbl.Print()
+
+	fmt.Printf("Total Sell Out Amount: %s\n", fmtMoney(totalSellOut))
+}
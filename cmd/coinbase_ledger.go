@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/KalebHawkins/crypto-client/coinbase"
+	"github.com/KalebHawkins/crypto-client/money"
+	"github.com/fatih/color"
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+)
+
+// coinbaseLedgerCmd represents the coinbase ledger command
+var coinbaseLedgerCmd = &cobra.Command{
+	Use:   "ledger <asset>",
+	Short: "list the transactions of one asset with a running balance.",
+	Long: `List the transactions of one of your Coinbase wallets oldest first with a running balance.
+
+Failed, canceled and expired transactions are listed but do not move the balance. Once every
+transaction is applied the running balance is compared against the balance Coinbase reports
+for the wallet and any difference is printed.
+
+	$ crypto-client coinbase ledger BTC
+`,
+	Args: cobra.ExactArgs(1),
+
+	Run: func(cmd *cobra.Command, args []string) {
+		printCoinbaseLedger(args[0])
+	},
+}
+
+func init() {
+	coinbaseCmd.AddCommand(coinbaseLedgerCmd)
+}
+
+// printCoinbaseLedger prints the transactions of the wallet holding `asset` with a running balance.
+func printCoinbaseLedger(asset string) {
+	c := newCoinbaseClient()
+
+	acts, err := c.GetAccount()
+	errHandler(err)
+
+	for _, a := range acts.Data {
+		if strings.EqualFold(a.Balance.Currency, asset) {
+			tr, err := c.GetTransactionHistory(a.ID)
+			errHandler(err)
+
+			printLedger(tr.Data, a.Balance)
+			return
+		}
+	}
+
+	errHandler(fmt.Errorf("no Coinbase wallet found for currency %q", asset))
+}
+
+// printLedger prints `transactions` oldest first with a running balance and reconciles it against `balance`.
+func printLedger(transactions []coinbase.TransactionData, balance money.Money) {
+	table.DefaultHeaderFormatter = func(s string, i ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(s, i...))
+	}
+	headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
+	tbl := newTable("Date", "Transaction Type", "Status", "Amount", "Balance", "Summary").WithHeaderFormatter(headerFmt)
+
+	sort.SliceStable(transactions, func(i, j int) bool {
+		return transactions[i].CreatedAt.Before(transactions[j].CreatedAt)
+	})
+
+	running := money.Zero(balance.Currency)
+	for _, t := range transactions {
+		if affectsBalance(t.Status) {
+			running = running.Add(t.Amount)
+		}
+
+		tbl.AddRow(t.CreatedAt.Local().Format("2006-01-02 15:04"), t.Type, t.Status, fmtAmount(t.Amount.Amount, t.Amount.Currency), fmtAmount(running.Amount, running.Currency), t.Details.Header)
+	}
+
+	tbl.Print()
+
+	fmt.Println()
+	fmt.Println("Running Balance:", running)
+	fmt.Println("Coinbase Balance:", balance)
+
+	if diff := balance.Sub(running); !diff.IsZero() {
+		color.Red("Difference: %s", diff)
+		return
+	}
+	color.Green("The ledger matches the Coinbase balance.")
+}
+
+// affectsBalance reports whether a transaction with `status` moves the balance of its wallet.
+func affectsBalance(status string) bool {
+	switch status {
+	case "failed", "canceled", "expired":
+		return false
+	}
+
+	return true
+}
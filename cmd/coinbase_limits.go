@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/KalebHawkins/crypto-client/coinbase"
+	"github.com/KalebHawkins/crypto-client/money"
+	"github.com/fatih/color"
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+)
+
+// coinbaseLimitsCmd represents the coinbase limits command
+var coinbaseLimitsCmd = &cobra.Command{
+	Use:   "limits",
+	Short: "show your remaining buy, sell, deposit and send allowances.",
+	Long: `Show your remaining buy, sell, deposit and send allowances.
+
+Coinbase limits how much can be moved through each payment method over a rolling period,
+usually a week. The send limit is only reported by Coinbase when using OAuth authentication.
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		printCoinbaseLimits()
+	},
+}
+
+func init() {
+	coinbaseCmd.AddCommand(coinbaseLimitsCmd)
+}
+
+// printCoinbaseLimits prints the limits of every payment method followed by the send limit if there is one.
+func printCoinbaseLimits() {
+	c := newCoinbaseClient()
+
+	pms, err := c.GetPaymentMethods()
+	errHandler(err)
+
+	table.DefaultHeaderFormatter = func(s string, i ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(s, i...))
+	}
+	headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
+	tbl := newTable("Payment Method", "Limit", "Period", "Total", "Remaining").WithHeaderFormatter(headerFmt)
+
+	for _, pm := range pms.Data {
+		kinds := []struct {
+			name   string
+			limits []coinbase.Limit
+		}{
+			{"buy", pm.Limits.Buy},
+			{"instant buy", pm.Limits.InstantBuy},
+			{"sell", pm.Limits.Sell},
+			{"deposit", pm.Limits.Deposit},
+		}
+
+		for _, k := range kinds {
+			for _, l := range k.limits {
+				tbl.AddRow(pm.Name, k.name, fmt.Sprintf("%d days", l.PeriodInDays),
+					fmtMoney(l.Total), fmtMoney(l.Remaining))
+			}
+		}
+	}
+
+	auth, err := c.GetAuthInfo()
+	errHandler(err)
+
+	if meta := auth.Data.OAuthMeta; meta.SendLimitAmount != "" {
+		tbl.AddRow("send", "send", meta.SendLimitPeriod, fmt.Sprintf("%s %s", meta.SendLimitAmount, meta.SendLimitCurrency), "")
+	}
+
+	tbl.Print()
+}
+
+// checkSellLimit returns an error if selling for `total` would exceed the remaining sell allowance of the payment
+// method matching `paymentMethodID`, or of the primary sell payment method when the ID is empty.
+func checkSellLimit(c coinbase.Client, paymentMethodID string, total money.Money) error {
+	pms, err := c.GetPaymentMethods()
+	if err != nil {
+		return err
+	}
+
+	for _, pm := range pms.Data {
+		if pm.ID != paymentMethodID && !(paymentMethodID == "" && pm.PrimarySell) {
+			continue
+		}
+
+		for _, l := range pm.Limits.Sell {
+			if l.Remaining.Currency != total.Currency {
+				continue
+			}
+
+			if total.Cmp(l.Remaining) > 0 {
+				return fmt.Errorf("selling %s exceeds the remaining %d day sell limit of %s on %q",
+					fmtMoney(total), l.PeriodInDays, fmtMoney(l.Remaining), pm.Name)
+			}
+		}
+	}
+
+	return nil
+}
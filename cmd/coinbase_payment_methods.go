@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/KalebHawkins/crypto-client/coinbase"
+	"github.com/spf13/cobra"
+)
+
+// coinbasePaymentMethodsCmd represents the coinbase payment-methods command
+var coinbasePaymentMethodsCmd = &cobra.Command{
+	Use:   "payment-methods",
+	Short: "list the payment methods linked to your Coinbase account.",
+	Long: `List the payment methods linked to your Coinbase account.
+
+The name shown here can be passed to --payment-method on commands that move funds
+instead of the payment method ID.
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newCoinbaseClient()
+		pm, err := c.GetPaymentMethods()
+		errHandler(err)
+		fmt.Println(pm)
+	},
+}
+
+func init() {
+	coinbaseCmd.AddCommand(coinbasePaymentMethodsCmd)
+}
+
+// findCoinbasePaymentMethodID returns the ID of the payment method matching `nameOrID`, either by its ID or by
+// its case insensitive name. An error is returned when nothing or more than one payment method matches.
+func findCoinbasePaymentMethodID(c coinbase.Client, nameOrID string) (string, error) {
+	pms, err := c.GetPaymentMethods()
+	if err != nil {
+		return "", err
+	}
+
+	var matches []string
+	for _, pm := range pms.Data {
+		if pm.ID == nameOrID {
+			return pm.ID, nil
+		}
+		if strings.EqualFold(pm.Name, nameOrID) {
+			matches = append(matches, pm.ID)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no payment method named %q, see `crypto-client coinbase payment-methods`", nameOrID)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("payment method name %q is ambiguous, use its ID instead", nameOrID)
+	}
+}
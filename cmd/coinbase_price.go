@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/KalebHawkins/crypto-client/coinbase"
+	"github.com/fatih/color"
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+)
+
+// coinbasePriceCmd represents the coinbase price command
+var coinbasePriceCmd = &cobra.Command{
+	Use:   "price <product>...",
+	Short: "show the buy, sell or spot price of currency pairs.",
+	Long: `Show the buy, sell or spot price of currency pairs without loading your accounts.
+
+Products are currency pairs such as BTC-USD, a bare asset such as ETH is quoted in USD. Use --date
+for the spot price on a past day, Coinbase only reports historical spot prices.
+
+	$ crypto-client coinbase price BTC-USD
+	$ crypto-client coinbase price ETH-EUR SOL --type buy
+	$ crypto-client coinbase price BTC-USD --date 2023-01-01
+	$ crypto-client coinbase price BTC-USD --watch --interval 1m
+`,
+	Args: cobra.MinimumNArgs(1),
+
+	Run: func(cmd *cobra.Command, args []string) {
+		kind := strings.ToLower(priceType)
+		if kind != coinbase.Buy && kind != coinbase.Sell && kind != coinbase.Spot {
+			errHandler(fmt.Errorf("unknown price type %q, use buy, sell or spot", kind))
+		}
+
+		var date time.Time
+		if priceDate != "" {
+			d, err := time.Parse("2006-01-02", priceDate)
+			errHandler(err)
+			if kind != coinbase.Spot {
+				errHandler(fmt.Errorf("only spot prices are available for past dates"))
+			}
+			date = d
+		}
+
+		products := productIDs(args)
+
+		watch(func() {
+			printPrices(newCoinbaseClient(), products, kind, date)
+		})
+	},
+}
+
+var priceType string
+var priceDate string
+
+func init() {
+	coinbaseCmd.AddCommand(coinbasePriceCmd)
+	coinbasePriceCmd.Flags().StringVar(&priceType, "type", coinbase.Spot, "the price to show, one of buy, sell or spot")
+	coinbasePriceCmd.Flags().StringVar(&priceDate, "date", "", "show the spot price on this day instead of the current price (YYYY-MM-DD)")
+	addWatchFlags(coinbasePriceCmd)
+}
+
+// printPrices prints the `priceType` price of every product, or the spot price on `date` when it is not zero.
+func printPrices(c coinbase.Client, products []string, priceType string, date time.Time) {
+	table.DefaultHeaderFormatter = func(s string, i ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(s, i...))
+	}
+	headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
+	tbl := newTable("Product", "Type", "Date", "Price").WithHeaderFormatter(headerFmt)
+
+	for _, p := range products {
+		var price coinbase.Price
+		var err error
+		day := "now"
+		if date.IsZero() {
+			price, err = c.GetPrice(p, priceType)
+		} else {
+			price, err = c.GetPriceByDate(p, date)
+			day = date.Format("2006-01-02")
+		}
+		errHandler(err)
+
+		tbl.AddRow(p, priceType, day, fmtMoney(price.Data.Money))
+	}
+
+	tbl.Print()
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/KalebHawkins/crypto-client/coinbase"
+	"github.com/fatih/color"
+	"github.com/rodaine/table"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+)
+
+// coinbaseRatesCmd represents the coinbase rates command
+var coinbaseRatesCmd = &cobra.Command{
+	Use:   "rates [symbol]...",
+	Short: "show the exchange rates from a base currency.",
+	Long: `Show how much of every currency one unit of the base currency buys, and the price of one unit of
+every currency in the base currency, sorted by symbol. The base currency is USD unless --base is
+given. List symbols to only show their rates.
+
+	$ crypto-client coinbase rates
+	$ crypto-client coinbase rates --base EUR BTC ETH GBP
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		rates, err := newCoinbaseClient().GetExchangeRate(ratesBase)
+		errHandler(err)
+
+		printExchangeRates(rates, args)
+	},
+}
+
+var ratesBase string
+
+func init() {
+	coinbaseCmd.AddCommand(coinbaseRatesCmd)
+	coinbaseRatesCmd.Flags().StringVar(&ratesBase, "base", "", "the currency to show the rates from (default USD)")
+}
+
+// printExchangeRates prints the rates of `symbols`, or every rate when there are none. Symbols without a rate are
+// reported as an error.
+func printExchangeRates(rates coinbase.ExchangeRate, symbols []string) {
+	currencies := rates.Currencies()
+	if len(symbols) > 0 {
+		currencies = nil
+		for _, s := range symbols {
+			s = strings.ToUpper(s)
+			if _, ok := rates.Data.Rates[s]; !ok {
+				errHandler(fmt.Errorf("there is no %s rate for %s", rates.Data.Currency, s))
+			}
+			currencies = append(currencies, s)
+		}
+	}
+
+	table.DefaultHeaderFormatter = func(s string, i ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(s, i...))
+	}
+	headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
+	tbl := newTable("Currency", fmt.Sprintf("Per %s", rates.Data.Currency), fmt.Sprintf("Price in %s", rates.Data.Currency)).WithHeaderFormatter(headerFmt)
+
+	for _, c := range currencies {
+		rate := rates.Data.Rates[c]
+
+		price := ""
+		if !rate.IsZero() {
+			price = fmtAmount(decimal.NewFromInt(1).DivRound(rate, 16), rates.Data.Currency)
+		}
+
+		tbl.AddRow(c, fmtAmount(rate, c), price)
+	}
+
+	tbl.Print()
+}
@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/KalebHawkins/crypto-client/coinbase"
+	"github.com/KalebHawkins/crypto-client/money"
+	"github.com/fatih/color"
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+)
+
+// coinbaseRewardsCmd represents the coinbase rewards command
+var coinbaseRewardsCmd = &cobra.Command{
+	Use:   "rewards [asset]...",
+	Short: "summarize staking and inflation rewards per asset and month.",
+	Long: `Summarize the staking, inflation and interest rewards paid into your Coinbase wallets per month and
+asset, with their value in your native currency when they were received, for reporting them as
+income. List assets to only summarize their rewards, give --year to only summarize one tax year.
+
+The value is the native amount Coinbase recorded with every reward. For rewards recorded without
+one, the spot price of the day the reward was received is looked up instead. Months are those of
+your local time zone.
+
+	$ crypto-client coinbase rewards --year 2021
+	$ crypto-client coinbase rewards ETH ALGO
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		printCoinbaseRewards(args)
+	},
+}
+
+var rewardsYear int
+
+func init() {
+	coinbaseCmd.AddCommand(coinbaseRewardsCmd)
+	coinbaseRewardsCmd.Flags().IntVar(&rewardsYear, "year", 0, "only summarize the rewards received in this year")
+}
+
+// rewardSummary is the rewards of one asset received in one month, or in every month when Month is zero.
+type rewardSummary struct {
+	Month  time.Time
+	Asset  string
+	Count  int
+	Amount money.Money
+	Value  money.Money
+}
+
+// printCoinbaseRewards prints the rewards of `assets`, or every asset when there are none, per month and asset and
+// their totals per asset.
+func printCoinbaseRewards(assets []string) {
+	c := newCoinbaseClient()
+
+	user, err := c.GetUserProfile()
+	errHandler(err)
+	native := user.Data.NativeCurrency
+
+	accounts, err := getTrackedAccounts(c)
+	errHandler(err)
+
+	selected := map[string]bool{}
+	for _, a := range assets {
+		selected[strings.ToUpper(a)] = true
+	}
+
+	var transactions []coinbase.TransactionData
+	for _, a := range accounts.Data {
+		if len(selected) > 0 && !selected[a.Balance.Currency] {
+			continue
+		}
+
+		tr, err := c.GetTransactionHistory(a.ID)
+		errHandler(err)
+		transactions = append(transactions, tr.Data...)
+	}
+
+	months, err := summarizeRewards(c, transactions, native, rewardsYear)
+	errHandler(err)
+
+	if len(months) == 0 {
+		fmt.Println("No rewards found.")
+		return
+	}
+
+	table.DefaultHeaderFormatter = func(s string, i ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(s, i...))
+	}
+	headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
+
+	tbl := newTable("Month", "Asset", "Rewards", "Amount", "Value").WithHeaderFormatter(headerFmt)
+	totals := map[string]*rewardSummary{}
+	var order []string
+	total := money.Zero(native)
+	for _, m := range months {
+		tbl.AddRow(m.Month.Format("2006-01"), m.Asset, m.Count, fmtAmount(m.Amount.Amount, m.Asset), fmtMoney(m.Value))
+
+		t, ok := totals[m.Asset]
+		if !ok {
+			t = &rewardSummary{Asset: m.Asset, Amount: money.Zero(m.Asset), Value: money.Zero(native)}
+			totals[m.Asset] = t
+			order = append(order, m.Asset)
+		}
+		t.Count += m.Count
+		t.Amount = t.Amount.Add(m.Amount)
+		t.Value = t.Value.Add(m.Value)
+		total = total.Add(m.Value)
+	}
+	tbl.Print()
+
+	fmt.Println()
+	sort.Strings(order)
+	tbl = newTable("Asset", "Rewards", "Amount", "Value").WithHeaderFormatter(headerFmt)
+	for _, a := range order {
+		t := totals[a]
+		tbl.AddRow(t.Asset, t.Count, fmtAmount(t.Amount.Amount, t.Asset), fmtMoney(t.Value))
+	}
+	tbl.Print()
+
+	fmt.Printf("Total Reward Income: %s\n", fmtMoney(total))
+}
+
+// summarizeRewards sums the completed reward transactions among `transactions` per month and asset, sorted by month
+// and then asset. Only rewards received in `year` are included unless it is zero. Every reward is valued in `native`
+// at its native amount, or at the spot price of the day it was received when Coinbase recorded it without one. An
+// error is returned if looking up a price failed.
+func summarizeRewards(c coinbase.Client, transactions []coinbase.TransactionData, native string, year int) ([]rewardSummary, error) {
+	type key struct {
+		month time.Time
+		asset string
+	}
+	summaries := map[key]*rewardSummary{}
+
+	for _, t := range transactions {
+		if !rewardTypes[t.Type] || !affectsBalance(t.Status) {
+			continue
+		}
+
+		received := t.CreatedAt.Local()
+		if year != 0 && received.Year() != year {
+			continue
+		}
+
+		value, err := rewardValue(c, t, native)
+		if err != nil {
+			return nil, err
+		}
+
+		asset := t.Amount.Currency
+		k := key{time.Date(received.Year(), received.Month(), 1, 0, 0, 0, 0, time.Local), asset}
+		s, ok := summaries[k]
+		if !ok {
+			s = &rewardSummary{Month: k.month, Asset: asset, Amount: money.Zero(asset), Value: money.Zero(native)}
+			summaries[k] = s
+		}
+		s.Count++
+		s.Amount = s.Amount.Add(t.Amount)
+		s.Value = s.Value.Add(value)
+	}
+
+	months := make([]rewardSummary, 0, len(summaries))
+	for _, s := range summaries {
+		months = append(months, *s)
+	}
+	sort.Slice(months, func(i, j int) bool {
+		if !months[i].Month.Equal(months[j].Month) {
+			return months[i].Month.Before(months[j].Month)
+		}
+		return months[i].Asset < months[j].Asset
+	})
+
+	return months, nil
+}
+
+// rewardValue returns the value of the reward `t` in `native` when it was received. An error is returned if the
+// reward has no native amount and looking up the price of its day failed.
+func rewardValue(c coinbase.Client, t coinbase.TransactionData, native string) (money.Money, error) {
+	if t.NativeAmount.Currency == native && !t.NativeAmount.IsZero() {
+		return t.NativeAmount, nil
+	}
+
+	p, err := c.GetPriceByDate(t.Amount.Currency+"-"+native, t.CreatedAt)
+	if err != nil {
+		return money.Money{}, fmt.Errorf("looking up the price of the %s reward of %s: %w", t.Amount.Currency, t.CreatedAt.Format("2006-01-02"), err)
+	}
+
+	return p.Data.Mul(t.Amount.Amount), nil
+}
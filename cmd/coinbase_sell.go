@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/KalebHawkins/crypto-client/coinbase"
+	"github.com/spf13/cobra"
+)
+
+// coinbaseSellCmd represents the coinbase sell command
+var coinbaseSellCmd = &cobra.Command{
+	Use:   "sell",
+	Short: "sell crypto currency from one of your Coinbase wallets.",
+	Long: `Sell crypto currency from one of your Coinbase wallets.
+
+A quote for the sell is always requested first so the fees and totals can be reviewed.
+You will then be asked to confirm before the sell is committed. Use --preview to only
+show the quote, or --yes to commit without being prompted.
+
+	$ crypto-client coinbase sell --asset BTC --amount 0.01
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		sellCoinbaseAsset()
+	},
+}
+
+var sellAsset string
+var sellAmount string
+var sellPaymentMethod string
+var sellPreview bool
+var sellYes bool
+
+func init() {
+	coinbaseCmd.AddCommand(coinbaseSellCmd)
+	coinbaseSellCmd.Flags().StringVar(&sellAsset, "asset", "", "the crypto currency to sell, for example BTC")
+	coinbaseSellCmd.Flags().StringVar(&sellAmount, "amount", "", "the amount of the asset to sell")
+	coinbaseSellCmd.Flags().StringVar(&sellPaymentMethod, "payment-method", "", "the name or ID of the payment method to deposit the funds to")
+	coinbaseSellCmd.Flags().BoolVar(&sellPreview, "preview", false, "only show the sell quote without committing it")
+	coinbaseSellCmd.Flags().BoolVarP(&sellYes, "yes", "y", false, "commit the sell without asking for confirmation")
+	coinbaseSellCmd.MarkFlagRequired("asset")
+	coinbaseSellCmd.MarkFlagRequired("amount")
+}
+
+// sellCoinbaseAsset quotes a sell order, shows it to the user and commits it once confirmed.
+func sellCoinbaseAsset() {
+	c := newCoinbaseClient()
+
+	accountID, err := findCoinbaseAccountID(c, sellAsset)
+	errHandler(err)
+
+	if sellPaymentMethod != "" {
+		sellPaymentMethod, err = findCoinbasePaymentMethodID(c, sellPaymentMethod)
+		errHandler(err)
+	}
+
+	quote, err := c.PlaceSellOrder(accountID, coinbase.SellRequest{
+		Amount:        sellAmount,
+		Currency:      strings.ToUpper(sellAsset),
+		PaymentMethod: sellPaymentMethod,
+		Commit:        false,
+	})
+	errHandler(err)
+	fmt.Println(quote)
+
+	errHandler(checkSellLimit(c, sellPaymentMethod, quote.Data.Total))
+
+	if sellPreview {
+		return
+	}
+
+	if !sellYes && !confirm("Commit this sell order?") {
+		fmt.Println("Sell order was not committed.")
+		return
+	}
+
+	sell, err := c.CommitSellOrder(accountID, quote.Data.ID)
+	errHandler(err)
+	fmt.Println(sell)
+}
+
+// findCoinbaseAccountID returns the ID of the wallet holding the given currency.
+func findCoinbaseAccountID(c coinbase.Client, currency string) (string, error) {
+	acts, err := c.GetAccount()
+	if err != nil {
+		return "", err
+	}
+
+	for _, a := range acts.Data {
+		if strings.EqualFold(a.Balance.Currency, currency) {
+			return a.ID, nil
+		}
+	}
+
+	return "", fmt.Errorf("no Coinbase wallet found for currency %q", currency)
+}
+
+// confirm prompts the user with a yes/no question and reports whether they answered yes.
+func confirm(question string) bool {
+	fmt.Printf("%s [y/N]: ", question)
+
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false
+	}
+
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
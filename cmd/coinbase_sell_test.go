@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/KalebHawkins/crypto-client/coinbasetest"
+)
+
+func TestSellCoinbaseAsset(t *testing.T) {
+	tests := []struct {
+		name    string
+		preview bool
+		yes     bool
+		answer  string
+		prompt  bool
+		commit  bool
+	}{
+		{name: "preview", preview: true},
+		{name: "yes flag", yes: true, commit: true},
+		{name: "answered yes", answer: "y\n", prompt: true, commit: true},
+		{name: "answered no", answer: "n\n", prompt: true},
+		{name: "no answer", prompt: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := useServer(t)
+			sellAsset, sellAmount, sellPreview, sellYes = "btc", "0.01", tt.preview, tt.yes
+			t.Cleanup(func() {
+				sellAsset, sellAmount, sellPaymentMethod, sellPreview, sellYes = "", "", "", false, false
+			})
+
+			out := run(t, tt.answer, sellCoinbaseAsset)
+
+			var quoted, committed bool
+			for _, r := range srv.Requests() {
+				if r.Method != http.MethodPost {
+					continue
+				}
+				switch {
+				case r.Path == "/v2/accounts/"+coinbasetest.BTCAccountID+"/sells":
+					quoted = true
+				case strings.HasSuffix(r.Path, "/commit"):
+					committed = true
+				}
+			}
+
+			if !quoted {
+				t.Error("no sell quote was requested")
+			}
+			if committed != tt.commit {
+				t.Errorf("committed = %v, want %v", committed, tt.commit)
+			}
+			if prompted := strings.Contains(out, "Commit this sell order? [y/N]"); prompted != tt.prompt {
+				t.Errorf("prompted = %v, want %v:\n%s", prompted, tt.prompt, out)
+			}
+			if declined := strings.Contains(out, "Sell order was not committed."); declined != (tt.prompt && !tt.commit) {
+				t.Errorf("declined = %v, want %v:\n%s", declined, tt.prompt && !tt.commit, out)
+			}
+		})
+	}
+}
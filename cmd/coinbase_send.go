@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"crypto/rand"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/KalebHawkins/crypto-client/coinbase"
+	"github.com/spf13/cobra"
+)
+
+// coinbaseSendCmd represents the coinbase send command
+var coinbaseSendCmd = &cobra.Command{
+	Use:   "send",
+	Short: "send crypto currency to an external address.",
+	Long: `Send crypto currency from one of your Coinbase wallets to an external address or email.
+
+Sending crypto currency cannot be undone, so this command refuses to run unless --confirm is given.
+Every send carries an idempotency key. One is generated when --idem is not set and it is printed
+so a failed send can be retried with the same key without risking a double send.
+
+If your account uses two factor authentication the first attempt is rejected and Coinbase sends
+you a token. Repeat the command with the same --idem and --two-factor-token set.
+
+	$ crypto-client coinbase send --asset BTC --amount 0.001 --to bc1q... --confirm
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		if !sendConfirm {
+			errHandler(fmt.Errorf("refusing to send %s %s to %s without --confirm", sendAmount, strings.ToUpper(sendAsset), sendTo))
+		}
+		sendCoinbaseAsset()
+	},
+}
+
+var sendAsset string
+var sendAmount string
+var sendTo string
+var sendDescription string
+var sendIdem string
+var sendTwoFactorToken string
+var sendConfirm bool
+
+func init() {
+	coinbaseCmd.AddCommand(coinbaseSendCmd)
+	coinbaseSendCmd.Flags().StringVar(&sendAsset, "asset", "", "the crypto currency to send, for example BTC")
+	coinbaseSendCmd.Flags().StringVar(&sendAmount, "amount", "", "the amount of the asset to send")
+	coinbaseSendCmd.Flags().StringVar(&sendTo, "to", "", "the destination address or email")
+	coinbaseSendCmd.Flags().StringVar(&sendDescription, "description", "", "an optional note included with the send")
+	coinbaseSendCmd.Flags().StringVar(&sendIdem, "idem", "", "idempotency key, generated when not set")
+	coinbaseSendCmd.Flags().StringVar(&sendTwoFactorToken, "two-factor-token", "", "two factor authentication token")
+	coinbaseSendCmd.Flags().BoolVar(&sendConfirm, "confirm", false, "confirm that the funds should be sent")
+	coinbaseSendCmd.MarkFlagRequired("asset")
+	coinbaseSendCmd.MarkFlagRequired("amount")
+	coinbaseSendCmd.MarkFlagRequired("to")
+}
+
+// sendCoinbaseAsset sends the requested amount of an asset to an external address.
+func sendCoinbaseAsset() {
+	c := newCoinbaseClient()
+
+	accountID, err := findCoinbaseAccountID(c, sendAsset)
+	errHandler(err)
+
+	if sendIdem == "" {
+		sendIdem, err = newIdempotencyKey()
+		errHandler(err)
+	}
+	fmt.Fprintln(os.Stderr, "Idempotency Key:", sendIdem)
+
+	tr, err := c.SendMoney(accountID, coinbase.SendRequest{
+		To:             sendTo,
+		Amount:         sendAmount,
+		Currency:       strings.ToUpper(sendAsset),
+		Description:    sendDescription,
+		Idem:           sendIdem,
+		TwoFactorToken: sendTwoFactorToken,
+	})
+	errHandler(err)
+
+	fmt.Println(tr)
+}
+
+// newIdempotencyKey returns a random version 4 UUID.
+func newIdempotencyKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/KalebHawkins/crypto-client/coinbase"
+	"github.com/KalebHawkins/crypto-client/coinbasetest"
+	"github.com/KalebHawkins/crypto-client/internal/userdata"
+	"github.com/fatih/color"
+	"github.com/shopspring/decimal"
+)
+
+// useServer points every command at a new coinbasetest server and keeps the user data of the test in a temporary
+// directory. The server is closed when the test finishes.
+func useServer(t *testing.T) *coinbasetest.Server {
+	t.Helper()
+
+	srv := coinbasetest.NewServer()
+	t.Cleanup(srv.Close)
+
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(dir, ".config"))
+
+	newClient := newCoinbaseClient
+	newCoinbaseClient = func() coinbase.Client { return srv.Client() }
+	t.Cleanup(func() { newCoinbaseClient = newClient })
+
+	noColor := color.NoColor
+	color.NoColor = true
+	t.Cleanup(func() { color.NoColor = noColor })
+
+	return srv
+}
+
+// run calls `f` with standard input reading `input` and returns everything it wrote to standard output, tables
+// included.
+func run(t *testing.T, input string, f func()) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	in, err := os.Create(filepath.Join(dir, "stdin"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer in.Close()
+	if _, err := in.WriteString(input); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := in.Seek(0, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := os.Create(filepath.Join(dir, "stdout"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer out.Close()
+
+	stdin, stdout, w := os.Stdin, os.Stdout, results
+	os.Stdin, os.Stdout, results = in, out, out
+	defer func() { os.Stdin, os.Stdout, results = stdin, stdout, w }()
+
+	f()
+
+	b, err := os.ReadFile(out.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(b)
+}
+
+func TestGetCoinbaseOverview(t *testing.T) {
+	tests := []struct {
+		name   string
+		prices map[string]int64
+		want   []string
+	}{
+		{
+			name: "fixture prices",
+			want: []string{
+				"BTC Wallet  0.50000000  BTC       50000.00 USD",
+				"Total Sell Out Amount: 32030.00 USD",
+				"Total Unrealized Gain: 12005.00 USD",
+				"Total Return Amount: 12005.00 USD",
+			},
+		},
+		{
+			name:   "bitcoin below cost",
+			prices: map[string]int64{"BTC-USD": 20000},
+			want: []string{
+				"BTC Wallet  0.50000000  BTC       20000.00 USD",
+				"Total Sell Out Amount: 17030.00 USD",
+				"Total Unrealized Gain: -2995.00 USD",
+			},
+		},
+		{
+			name:   "ether doubled",
+			prices: map[string]int64{"ETH-USD": 6000},
+			want: []string{
+				"Total Sell Out Amount: 38060.00 USD",
+				"Total Unrealized Gain: 18035.00 USD",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := useServer(t)
+			for pair, price := range tt.prices {
+				srv.SetPrice(pair, decimal.NewFromInt(price))
+			}
+
+			out := run(t, "", getCoinbaseOverview)
+			for _, want := range tt.want {
+				if !strings.Contains(out, want) {
+					t.Errorf("output is missing %q:\n%s", want, out)
+				}
+			}
+		})
+	}
+}
+
+func TestSelectTransactions(t *testing.T) {
+	const (
+		btcBuy2020 = "4117f7d6-5694-5b36-bc8f-847509850ea4"
+		btcBuy2021 = "57ffb4ae-0c59-5430-bcd3-3f98f797a66c"
+		ethBuy     = "1f2e6a3b-7c4d-5e8f-9a0b-1c2d3e4f5a6b"
+		ethReward  = "8250fe29-f5ef-5fc5-8302-0fbacf6be51e"
+		usdDeposit = "a3b1c9d2-6e7f-5a8b-9c0d-2e3f4a5b6c7d"
+	)
+
+	tests := []struct {
+		name   string
+		since  string
+		until  string
+		types  []string
+		assets []string
+		tag    string
+		want   []string
+	}{
+		{name: "everything", want: []string{btcBuy2020, ethBuy, btcBuy2021, ethReward, usdDeposit}},
+		{name: "since", since: "2021-02-01", want: []string{btcBuy2021, ethReward, usdDeposit}},
+		{name: "until", until: "2021-01-31", want: []string{btcBuy2020, ethBuy}},
+		{name: "since and until", since: "2021-01-01", until: "2021-03-31", want: []string{ethBuy, btcBuy2021}},
+		{name: "type", types: []string{"buy"}, want: []string{btcBuy2020, ethBuy, btcBuy2021}},
+		{name: "several types", types: []string{"inflation_reward", "fiat_deposit"}, want: []string{ethReward, usdDeposit}},
+		{name: "asset", assets: []string{"btc"}, want: []string{btcBuy2020, btcBuy2021}},
+		{name: "asset and type", assets: []string{"ETH"}, types: []string{"buy"}, want: []string{ethBuy}},
+		{name: "transaction tag", tag: "taxes", want: []string{btcBuy2021}},
+		{name: "asset tag", tag: "long-term", want: []string{ethBuy, ethReward}},
+		{name: "no match", types: []string{"sell"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useServer(t)
+			filterSince, filterUntil, filterTypes, filterAssets, filterTag = tt.since, tt.until, tt.types, tt.assets, tt.tag
+			t.Cleanup(func() {
+				filterSince, filterUntil, filterTypes, filterAssets, filterTag = "", "", nil, nil, ""
+			})
+
+			notes := &userdata.Data{}
+			notes.TagTransaction(btcBuy2021, "taxes")
+			notes.TagAsset("ETH", "long-term")
+
+			transactions, err := selectTransactions(newCoinbaseClient(), notes)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+			for _, tr := range transactions {
+				got = append(got, tr.ID)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("got transactions %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectTransactionsInvalidDate(t *testing.T) {
+	useServer(t)
+	filterSince = "01/02/2021"
+	t.Cleanup(func() { filterSince = "" })
+
+	if _, err := selectTransactions(newCoinbaseClient(), &userdata.Data{}); err == nil {
+		t.Error("expected an error")
+	}
+}
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/KalebHawkins/crypto-client/coinbase/stream"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// coinbaseTickerCmd represents the coinbase ticker command
+var coinbaseTickerCmd = &cobra.Command{
+	Use:   "ticker <product>...",
+	Short: "show live prices streamed from Coinbase.",
+	Long: `Show live prices streamed from the Coinbase WebSocket feed until interrupted with Ctrl+C.
+
+Products are currency pairs such as BTC-USD, a bare asset such as ETH is quoted in USD.
+The view is redrawn on every trade. Lost connections are reconnected automatically. With --accessible
+every trade is printed on its own line instead.
+
+	$ crypto-client coinbase ticker BTC-USD ETH-EUR SOL
+`,
+	Args: cobra.MinimumNArgs(1),
+
+	Run: func(cmd *cobra.Command, args []string) {
+		products := productIDs(args)
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		s := stream.New()
+		s.OnError = func(err error) {
+			slog.Warn("connection lost, reconnecting", "error", err)
+		}
+
+		printTicker(products, s.Ticker(ctx, products...))
+	},
+}
+
+func init() {
+	coinbaseCmd.AddCommand(coinbaseTickerCmd)
+}
+
+// productIDs upper cases currency pairs such as btc-eur and quotes bare assets such as ETH in USD.
+func productIDs(args []string) []string {
+	var products []string
+	for _, a := range args {
+		p := strings.ToUpper(a)
+		if !strings.Contains(p, "-") {
+			p += "-USD"
+		}
+		products = append(products, p)
+	}
+
+	return products
+}
+
+// printTicker redraws one line per product every time an update arrives until `updates` is closed.
+func printTicker(products []string, updates <-chan stream.Ticker) {
+	last := map[string]stream.Ticker{}
+	drawn := false
+
+	for t := range updates {
+		prev, seen := last[t.ProductID]
+		last[t.ProductID] = t
+
+		if accessible {
+			printTickerLine(t, prev, seen)
+			continue
+		}
+
+		if drawn {
+			fmt.Printf("\033[%dA", len(products))
+		}
+		drawn = true
+
+		for _, p := range products {
+			cur, ok := last[p]
+			if !ok {
+				fmt.Printf("\033[2K%-10s waiting for a trade...\n", p)
+				continue
+			}
+
+			line := fmt.Sprintf("%-10s %14s  24h %s  bid %s  ask %s", p,
+				cur.Price.String(), change24h(cur), cur.BestBid.String(), cur.BestAsk.String())
+
+			switch {
+			case p != t.ProductID || !seen:
+				fmt.Printf("\033[2K%s\n", line)
+			case t.Price.GreaterThan(prev.Price):
+				fmt.Printf("\033[2K%s\n", color.GreenString(line))
+			case t.Price.LessThan(prev.Price):
+				fmt.Printf("\033[2K%s\n", color.RedString(line))
+			default:
+				fmt.Printf("\033[2K%s\n", line)
+			}
+		}
+	}
+}
+
+// printTickerLine prints an update on its own line, spelling out the direction of the price change instead of
+// coloring it.
+func printTickerLine(t, prev stream.Ticker, seen bool) {
+	direction := "unchanged"
+	switch {
+	case !seen:
+		direction = "first trade"
+	case t.Price.GreaterThan(prev.Price):
+		direction = "up"
+	case t.Price.LessThan(prev.Price):
+		direction = "down"
+	}
+
+	fmt.Printf("%s: price %s, %s, 24 hour change %s, bid %s, ask %s\n", t.ProductID, t.Price.String(), direction,
+		strings.TrimSpace(change24h(t)), t.BestBid.String(), t.BestAsk.String())
+}
+
+// change24h formats the relative price change of `t` over the last 24 hours.
+func change24h(t stream.Ticker) string {
+	if t.Open24h.IsZero() {
+		return "     n/a"
+	}
+
+	return fmt.Sprintf("%+7.2f%%", t.Price.Sub(t.Open24h).Div(t.Open24h).Shift(2).InexactFloat64())
+}
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/KalebHawkins/crypto-client/coinbase"
+	"github.com/KalebHawkins/crypto-client/internal/userdata"
+	"github.com/KalebHawkins/crypto-client/money"
+	"github.com/spf13/cobra"
+)
+
+// coinbaseTransactionsCmd represents the coinbase transactions command
+var coinbaseTransactionsCmd = &cobra.Command{
+	Use:   "transactions",
+	Short: "list or export the transactions of your Coinbase wallets.",
+	Long: `List the transactions of every Coinbase wallet oldest first, the same as coinbase --list-transactions,
+or export them as CSV for spreadsheets and tax tools with --export. Give - to write the CSV to
+standard output. --since, --until, --type, --asset and --tag select the transactions in both cases.
+
+	$ crypto-client coinbase transactions --since 2021-01-01 --until 2021-12-31 --export transactions.csv
+
+The CSV has a header row and the columns below, which are kept stable so imports keep working.
+Amounts are plain decimals with every digit Coinbase reports, negative for amounts leaving the wallet.
+
+	date             when the transaction was created, RFC 3339 in UTC
+	type             the Coinbase transaction type, for example buy, sell, send or staking_reward
+	status           completed, pending, failed, canceled or expired
+	asset            the currency of the wallet, for example BTC
+	amount           the amount of the asset
+	native_amount    the value of the amount in native_currency when the transaction was made
+	native_currency  the native currency of the user, for example USD
+	fee              the fee Coinbase charged on buys and sells in your native currency, the same as
+	                 "cache import" reads, and the network fee of sends in the sent asset. Empty when
+	                 there is none, and for buys and sells with --offline
+	fee_currency     the currency of the fee
+	txid             the Coinbase transaction ID
+	hash             the blockchain transaction hash of sends and receives, empty otherwise
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		if transactionsExport == "" {
+			getCoinbaseTransactions()
+			return
+		}
+
+		notes, err := userdata.Load()
+		errHandler(err)
+
+		c := newCoinbaseClient()
+		transactions, err := selectTransactions(c, notes)
+		errHandler(err)
+
+		if transactionsExport == "-" {
+			errHandler(writeTransactionsCSV(results, c, transactions))
+			return
+		}
+
+		f, err := os.Create(transactionsExport)
+		errHandler(err)
+		errHandler(writeTransactionsCSV(f, c, transactions))
+		errHandler(f.Close())
+	},
+}
+
+var transactionsExport string
+
+func init() {
+	coinbaseCmd.AddCommand(coinbaseTransactionsCmd)
+	f := coinbaseTransactionsCmd.Flags()
+	f.StringVar(&transactionsExport, "export", "", "write the transactions to this CSV file instead of listing them, - for standard output")
+	f.StringVar(&filterTag, "tag", "", "only include transactions carrying this tag")
+	f.StringVar(&filterSince, "since", "", "only include transactions made on or after this date (YYYY-MM-DD)")
+	f.StringVar(&filterUntil, "until", "", "only include transactions made on or before this date (YYYY-MM-DD)")
+	f.StringSliceVar(&filterTypes, "type", nil, "only include transactions of these types, for example buy, sell, send or inflation_reward")
+	f.StringSliceVar(&filterAssets, "asset", nil, "only include transactions of these assets, for example BTC")
+}
+
+// transactionColumns is the header of the transaction CSV export. Columns are only ever added at the end.
+var transactionColumns = []string{"date", "type", "status", "asset", "amount", "native_amount", "native_currency", "fee", "fee_currency", "txid", "hash"}
+
+// writeTransactionsCSV writes `transactions` to `w` as CSV with the transactionColumns header. The fees of buys and
+// sells are looked up with `c`. An error is returned if looking up a fee or writing failed.
+func writeTransactionsCSV(w io.Writer, c coinbase.Client, transactions []coinbase.TransactionData) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(transactionColumns); err != nil {
+		return err
+	}
+
+	for _, t := range transactions {
+		tf, err := transactionFee(c, t)
+		if err != nil {
+			return err
+		}
+
+		var fee, feeCurrency string
+		if tf.Currency != "" {
+			fee, feeCurrency = tf.Amount.String(), tf.Currency
+		}
+
+		err = cw.Write([]string{
+			t.CreatedAt.UTC().Format(time.RFC3339),
+			t.Type,
+			t.Status,
+			t.Amount.Currency,
+			t.Amount.Amount.String(),
+			t.NativeAmount.Amount.String(),
+			t.NativeAmount.Currency,
+			fee,
+			feeCurrency,
+			t.ID,
+			t.Network.Hash,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// transactionFee returns the fee paid on `t`: the trading fee of a buy or sell, looked up from the buy or sell with
+// `c`, and the network fee of a send. Coinbase reports the fee of a buy or sell, or else it is the difference between
+// the total and the subtotal. The fee has no currency when there is none or, --offline, when it is not stored. An
+// error is returned if looking up the buy or sell failed.
+func transactionFee(c coinbase.Client, t coinbase.TransactionData) (money.Money, error) {
+	var order coinbase.SellOrder
+	var err error
+	switch {
+	case t.Buy.ResourcePath == "" && t.Sell.ResourcePath == "":
+		return t.Network.TransactionFee, nil
+	case offline:
+		return money.Money{}, nil
+	case t.Buy.ResourcePath != "":
+		var b coinbase.BuyOrder
+		b, err = c.GetBuy(resourceAccountID(t.Buy.ResourcePath), t.Buy.ID)
+		order = coinbase.SellOrder(b)
+	default:
+		order, err = c.GetSell(resourceAccountID(t.Sell.ResourcePath), t.Sell.ID)
+	}
+	if err != nil {
+		return money.Money{}, fmt.Errorf("looking up the fee of %s %s: %w", t.Type, t.ID, err)
+	}
+
+	o := order.Data
+	switch {
+	case o.Fee.Currency != "":
+		return o.Fee, nil
+	case o.Total.Currency != "" && o.Total.Currency == o.Subtotal.Currency:
+		fee := o.Total.Sub(o.Subtotal)
+		return money.New(fee.Amount.Abs(), fee.Currency), nil
+	}
+
+	return money.Money{}, nil
+}
+
+// resourceAccountID returns the account ID in a resource path such as /v2/accounts/:account_id/buys/:buy_id.
+func resourceAccountID(resourcePath string) string {
+	parts := strings.Split(strings.Trim(resourcePath, "/"), "/")
+	for i, p := range parts {
+		if p == "accounts" && i+1 < len(parts) {
+			return parts[i+1]
+		}
+	}
+
+	return ""
+}
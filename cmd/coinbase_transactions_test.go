@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"net/http"
+	"testing"
+
+	"github.com/KalebHawkins/crypto-client/coinbasetest"
+	"github.com/KalebHawkins/crypto-client/internal/userdata"
+)
+
+func TestWriteTransactionsCSVFees(t *testing.T) {
+	srv := useServer(t)
+	srv.Handle(http.MethodGet, "/v2/accounts/"+coinbasetest.LTCAccountID+"/transactions", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"pagination": {}, "data": [
+			{"id": "sell-1", "type": "sell", "status": "completed", "amount": {"amount": "-1", "currency": "LTC"},
+			 "native_amount": {"amount": "-98.00", "currency": "USD"}, "created_at": "2021-07-01T12:00:00Z",
+			 "sell": {"id": "s1", "resource": "sell", "resource_path": "/v2/accounts/` + coinbasetest.LTCAccountID + `/sells/s1"}},
+			{"id": "send-1", "type": "send", "status": "completed", "amount": {"amount": "-0.5", "currency": "LTC"},
+			 "native_amount": {"amount": "-50.00", "currency": "USD"}, "created_at": "2021-07-02T12:00:00Z",
+			 "network": {"status": "confirmed", "hash": "abc", "transaction_fee": {"amount": "0.001", "currency": "LTC"}}}
+		]}`))
+	})
+	srv.Handle(http.MethodGet, "/v2/accounts/"+coinbasetest.LTCAccountID+"/sells/s1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data": {"id": "s1", "status": "completed",
+			"subtotal": {"amount": "100.00", "currency": "USD"}, "total": {"amount": "98.00", "currency": "USD"}}}`))
+	})
+
+	c := newCoinbaseClient()
+	transactions, err := selectTransactions(c, &userdata.Data{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var b bytes.Buffer
+	if err := writeTransactionsCSV(&b, c, transactions); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := csv.NewReader(&b).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fees := map[string][2]string{}
+	for _, row := range rows[1:] {
+		fees[row[9]] = [2]string{row[7], row[8]}
+	}
+
+	tests := []struct {
+		txid     string
+		fee      string
+		currency string
+	}{
+		{txid: "57ffb4ae-0c59-5430-bcd3-3f98f797a66c", fee: "90", currency: "USD"},
+		{txid: "1f2e6a3b-7c4d-5e8f-9a0b-1c2d3e4f5a6b", fee: "59.6", currency: "USD"},
+		{txid: "sell-1", fee: "2", currency: "USD"},
+		{txid: "send-1", fee: "0.001", currency: "LTC"},
+		{txid: "a3b1c9d2-6e7f-5a8b-9c0d-2e3f4a5b6c7d"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.txid, func(t *testing.T) {
+			got, ok := fees[tt.txid]
+			if !ok {
+				t.Fatal("transaction not exported")
+			}
+			if got != [2]string{tt.fee, tt.currency} {
+				t.Errorf("fee = %q %q, want %q %q", got[0], got[1], tt.fee, tt.currency)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/KalebHawkins/crypto-client/coinbase"
+	"github.com/spf13/cobra"
+)
+
+// coinbaseDepositCmd represents the coinbase deposit command
+var coinbaseDepositCmd = &cobra.Command{
+	Use:   "deposit",
+	Short: "deposit fiat from a payment method into Coinbase.",
+	Long: `Deposit fiat from one of your payment methods into your Coinbase fiat wallet.
+
+A quote is requested first so the fees can be reviewed, then you are asked to confirm.
+Use --preview to only show the quote, or --yes to commit without being prompted.
+
+	$ crypto-client coinbase deposit --amount 100 --fiat USD --payment-method "My Bank"
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		transferFiat(true)
+	},
+}
+
+// coinbaseWithdrawCmd represents the coinbase withdraw command
+var coinbaseWithdrawCmd = &cobra.Command{
+	Use:   "withdraw",
+	Short: "withdraw fiat from Coinbase to a payment method.",
+	Long: `Withdraw fiat from your Coinbase fiat wallet to one of your payment methods.
+
+A quote is requested first so the fees can be reviewed, then you are asked to confirm.
+Use --preview to only show the quote, or --yes to commit without being prompted.
+
+	$ crypto-client coinbase withdraw --amount 100 --fiat USD --payment-method "My Bank"
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		transferFiat(false)
+	},
+}
+
+var transferAmount string
+var transferFiatCurrency string
+var transferPaymentMethod string
+var transferPreview bool
+var transferYes bool
+
+func init() {
+	for _, c := range []*cobra.Command{coinbaseDepositCmd, coinbaseWithdrawCmd} {
+		coinbaseCmd.AddCommand(c)
+		c.Flags().StringVar(&transferAmount, "amount", "", "the amount of fiat to move")
+		c.Flags().StringVar(&transferFiatCurrency, "fiat", "", "the fiat currency to move, for example USD")
+		c.Flags().StringVar(&transferPaymentMethod, "payment-method", "", "the name or ID of the payment method")
+		c.Flags().BoolVar(&transferPreview, "preview", false, "only show the quote without committing it")
+		c.Flags().BoolVarP(&transferYes, "yes", "y", false, "commit without asking for confirmation")
+		c.MarkFlagRequired("amount")
+		c.MarkFlagRequired("fiat")
+		c.MarkFlagRequired("payment-method")
+	}
+}
+
+// transferFiat quotes a deposit (or a withdrawal when `deposit` is false), shows it to the user and commits it
+// once confirmed.
+func transferFiat(deposit bool) {
+	c := nativeClient(newCoinbaseClient())
+
+	accountID, err := findCoinbaseAccountID(c, transferFiatCurrency)
+	errHandler(err)
+
+	paymentMethodID, err := findCoinbasePaymentMethodID(c, transferPaymentMethod)
+	errHandler(err)
+
+	req := coinbase.TransferRequest{
+		Amount:        transferAmount,
+		Currency:      strings.ToUpper(transferFiatCurrency),
+		PaymentMethod: paymentMethodID,
+		Commit:        false,
+	}
+
+	place, commit, kind := c.Deposit, c.CommitDeposit, "deposit"
+	if !deposit {
+		place, commit, kind = c.Withdraw, c.CommitWithdrawal, "withdrawal"
+	}
+
+	quote, err := place(accountID, req)
+	errHandler(err)
+	fmt.Println(quote)
+
+	if transferPreview {
+		return
+	}
+
+	if !transferYes && !confirm(fmt.Sprintf("Commit this %s?", kind)) {
+		fmt.Printf("The %s was not committed.\n", kind)
+		return
+	}
+
+	t, err := commit(accountID, quote.Data.ID)
+	errHandler(err)
+	fmt.Println(t)
+}
@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/KalebHawkins/crypto-client/coinbase"
+	"github.com/KalebHawkins/crypto-client/internal/credentials"
+	"github.com/KalebHawkins/crypto-client/internal/userdata"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// configCmd represents the config command
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "inspect the crypto-client configuration.",
+	Long: `Inspect the crypto-client configuration.
+
+The configuration is the user data document holding notes, tags, the watchlist, goals and ignored
+wallets, together with the provider credentials set in the environment.
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+// configValidateCmd represents the config validate command
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "check the configuration for mistakes.",
+	Long: `Check the configuration for mistakes and print every problem with the line and column it is on.
+
+The user data document is checked for JSON syntax, unknown keys, malformed asset symbols and
+goals without a name or a positive target. Every wallet and asset it refers to is then looked up
+on Coinbase, and the provider credentials in the environment are checked for missing pieces such
+as a key without its secret. Use --offline to skip the lookups.
+
+	$ crypto-client config validate
+	$ crypto-client config validate --file backup/userdata.json --offline
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		if n := validateConfig(); n > 0 {
+			color.Red("%d problem(s) found.", n)
+			os.Exit(1)
+		}
+
+		color.Green("The configuration is valid.")
+	},
+}
+
+var configFile string
+var configOffline bool
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configValidateCmd)
+	configValidateCmd.Flags().StringVar(&configFile, "file", "", "the user data document to check instead of the stored one")
+	configValidateCmd.Flags().BoolVar(&configOffline, "offline", false, "skip looking up wallets, assets and credentials on Coinbase")
+}
+
+// validateConfig prints every problem found in the configuration and returns how many there are.
+func validateConfig() int {
+	path := configFile
+	if path == "" {
+		var err error
+		path, err = userdata.Path()
+		errHandler(err)
+	}
+
+	problems := 0
+	report := func(where string, format string, args ...interface{}) {
+		problems++
+		fmt.Fprintf(os.Stderr, "%s: %s\n", where, fmt.Sprintf(format, args...))
+	}
+
+	var refs []userdata.Reference
+	b, err := ioutil.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist) && configFile == "":
+		fmt.Println("No user data stored yet at", path)
+	case err != nil:
+		report(path, "%v", err)
+	default:
+		var issues []userdata.Issue
+		issues, refs = userdata.Validate(b)
+		for _, i := range issues {
+			report(fmt.Sprintf("%s:%d:%d", path, i.Line, i.Column), "%s", i.Message)
+		}
+	}
+
+	hasToken := false
+	if t, err := credentials.LoadToken(profile); err != nil {
+		report("credentials", "stored OAuth token is unreadable: %v, sign in again with `crypto-client auth login`", err)
+	} else {
+		hasToken = t.AccessToken != ""
+	}
+
+	for _, p := range providerCredentials {
+		var set, missing []string
+		for _, e := range p.env {
+			if os.Getenv(e) != "" {
+				set = append(set, e)
+			} else {
+				missing = append(missing, e)
+			}
+		}
+		if len(set) > 0 && len(missing) > 0 {
+			report("environment", "%s credentials are incomplete, export %s as well", p.provider, strings.Join(missing, " and "))
+		}
+	}
+
+	_, keyringErr := coinbase.KeyringClient(profile)
+	if os.Getenv("COINBASE_KEY") == "" && !hasToken && keyringErr != nil {
+		report("environment", "no Coinbase credentials, export COINBASE_KEY and COINBASE_SECRET or run `crypto-client auth login`")
+		return problems
+	}
+
+	if configOffline {
+		return problems
+	}
+
+	c := newCoinbaseClient()
+	if _, err := c.GetAuthInfo(); err != nil {
+		report("credentials", "Coinbase rejected the credentials: %v", err)
+		return problems
+	}
+
+	for _, r := range unresolvedReferences(c, refs) {
+		report(fmt.Sprintf("%s:%d:%d", path, r.Line, r.Column), "%s %q does not exist on Coinbase", r.Kind, r.Value)
+	}
+
+	return problems
+}
+
+// unresolvedReferences returns the references to wallets that are not among the Coinbase wallets and to assets
+// that Coinbase has no price for.
+func unresolvedReferences(c coinbase.Client, refs []userdata.Reference) []userdata.Reference {
+	if len(refs) == 0 {
+		return nil
+	}
+
+	user, err := c.GetUserProfile()
+	errHandler(err)
+
+	acts, err := c.GetAccount()
+	errHandler(err)
+
+	wallets := map[string]bool{}
+	known := map[string]bool{}
+	for _, a := range acts.Data {
+		wallets[strings.ToLower(a.ID)] = true
+		wallets[strings.ToLower(a.Name)] = true
+		known[strings.ToUpper(a.Balance.Currency)] = true
+	}
+
+	var unresolved []userdata.Reference
+	for _, r := range refs {
+		switch r.Kind {
+		case userdata.WalletReference:
+			if !wallets[strings.ToLower(r.Value)] {
+				unresolved = append(unresolved, r)
+			}
+		case userdata.AssetReference:
+			sym := strings.ToUpper(r.Value)
+			if _, checked := known[sym]; !checked {
+				_, err := c.GetPrice(sym+"-"+user.Data.NativeCurrency, coinbase.Spot)
+				known[sym] = err == nil
+			}
+			if !known[sym] {
+				unresolved = append(unresolved, r)
+			}
+		}
+	}
+
+	return unresolved
+}
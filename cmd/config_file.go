@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/KalebHawkins/crypto-client/internal/userdata"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// configCredentials maps the provider credentials that can be kept in the config file to the environment variables
+// the clients read them from.
+var configCredentials = map[string]string{
+	"coinbase.key":                  "COINBASE_KEY",
+	"coinbase.secret":               "COINBASE_SECRET",
+	"coinbase.client_id":            "COINBASE_CLIENT_ID",
+	"coinbase.client_secret":        "COINBASE_CLIENT_SECRET",
+	"coinbase.advanced_key_name":    "COINBASE_ADVANCED_KEY_NAME",
+	"coinbase.advanced_private_key": "COINBASE_ADVANCED_PRIVATE_KEY",
+	"coinbase.sandbox":              "COINBASE_SANDBOX",
+	"coinbase-exchange.key":         "COINBASE_EXCHANGE_KEY",
+	"coinbase-exchange.secret":      "COINBASE_EXCHANGE_SECRET",
+	"coinbase-exchange.passphrase":  "COINBASE_EXCHANGE_PASSPHRASE",
+	"kraken.key":                    "KRAKEN_KEY",
+	"kraken.secret":                 "KRAKEN_SECRET",
+	"binance.key":                   "BINANCE_KEY",
+	"binance.secret":                "BINANCE_SECRET",
+	"gemini.key":                    "GEMINI_KEY",
+	"gemini.secret":                 "GEMINI_SECRET",
+	"kucoin.key":                    "KUCOIN_KEY",
+	"kucoin.secret":                 "KUCOIN_SECRET",
+	"kucoin.passphrase":             "KUCOIN_PASSPHRASE",
+	"coinmarketcap.key":             "COINMARKETCAP_KEY",
+}
+
+// configGlobals maps flag names to the top level config key setting them for every command. The native currency
+// is used wherever a command asks for the currency to value holdings in.
+var configGlobals = map[string]string{
+	"output":              "output",
+	"accessible":          "accessible",
+	"dump-raw":            "dump-raw",
+	"currency":            "currency",
+	"convert":             "currency",
+	"fiat-places":         "fiat-places",
+	"significant-figures": "significant-figures",
+	"places":              "places",
+	"rounding":            "rounding",
+	"profile":             "profile",
+	"cost-basis":          "cost-basis",
+	"cache":               "cache",
+	"offline":             "offline",
+	"rate-limit":          "rate-limit",
+	"price-ttl":           "price-ttl",
+	"verbose":             "verbose",
+	"log-level":           "log-level",
+	"debug-http":          "debug-http",
+	"proxy":               "proxy",
+	"ca-cert":             "ca-cert",
+	"timeout":             "timeout",
+	"connect-timeout":     "connect-timeout",
+}
+
+var configPath string
+
+// loadedConfig is the config file read by loadConfig(), for the sections that do not map to flags.
+var loadedConfig = viper.New()
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&configPath, "config", "", "the config file to use (default is config.yaml in the crypto-client configuration directory)")
+}
+
+// loadConfig reads the config file and applies it. Credentials are only taken from the file when their environment
+// variable is not set, and flags only when they are not given on the command line. A missing default config file is
+// not an error.
+//
+// With --profile the credentials are taken from the profiles.<name> section of the file instead and the credential
+// environment variables are ignored, so a profile never picks up the keys of another account.
+func loadConfig() {
+	v := viper.New()
+	v.SetConfigType("yaml")
+
+	path := configPath
+	if path == "" {
+		dir, err := userdata.Dir()
+		errHandler(err)
+		path = filepath.Join(dir, "config.yaml")
+	}
+	v.SetConfigFile(path)
+
+	if err := v.ReadInConfig(); err != nil && (configPath != "" || !errors.Is(err, os.ErrNotExist)) {
+		errHandler(fmt.Errorf("reading config file: %w", err))
+	}
+
+	loadedConfig = v
+
+	errHandler(applyConfigFlags(v, rootCmd))
+	errHandler(checkProfile(v))
+
+	for key, env := range configCredentials {
+		switch {
+		case profile != "":
+			os.Setenv(env, v.GetString("profiles."+profile+"."+key))
+		case os.Getenv(env) == "" && v.IsSet(key):
+			os.Setenv(env, v.GetString(key))
+		}
+	}
+}
+
+// applyConfigFlags sets the flags of `cmd` and its subcommands that were not given on the command line from `v`.
+// A flag is looked up under the path of its command, for example kraken.currency, then under every parent section
+// and finally in configGlobals.
+func applyConfigFlags(v *viper.Viper, cmd *cobra.Command) error {
+	var sections []string
+	for c := cmd; c.HasParent(); c = c.Parent() {
+		sections = append([]string{c.Name()}, sections...)
+	}
+
+	var err error
+	apply := func(f *pflag.Flag) {
+		if f.Changed || err != nil {
+			return
+		}
+
+		var keys []string
+		for i := len(sections); i > 0; i-- {
+			keys = append(keys, strings.Join(append(sections[:i:i], f.Name), "."))
+		}
+		if global, ok := configGlobals[f.Name]; ok {
+			keys = append(keys, global)
+		}
+
+		for _, key := range keys {
+			if !v.IsSet(key) {
+				continue
+			}
+
+			if err = f.Value.Set(flagValue(v.Get(key))); err != nil {
+				err = fmt.Errorf("config file: %s: %w", key, err)
+			}
+			return
+		}
+	}
+	cmd.LocalNonPersistentFlags().VisitAll(apply)
+	cmd.PersistentFlags().VisitAll(apply)
+
+	for _, sub := range cmd.Commands() {
+		if err != nil {
+			break
+		}
+		err = applyConfigFlags(v, sub)
+	}
+
+	return err
+}
+
+// flagValue formats a config value the way it would be given on the command line. Lists become comma separated
+// values and maps comma separated key=value pairs.
+func flagValue(value interface{}) string {
+	var items []string
+	switch value := value.(type) {
+	case []interface{}:
+		for _, i := range value {
+			items = append(items, fmt.Sprint(i))
+		}
+	case map[string]interface{}:
+		for k, i := range value {
+			items = append(items, fmt.Sprintf("%s=%v", k, i))
+		}
+	default:
+		return fmt.Sprint(value)
+	}
+
+	return strings.Join(items, ",")
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/KalebHawkins/crypto-client/coinbase"
+	"github.com/KalebHawkins/crypto-client/internal/fx"
+)
+
+var displayCurrency string
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&displayCurrency, "currency", "", "the fiat currency amounts are shown in, Coinbase amounts are converted from your native currency at the current exchange rate and transactions at the rate of their day (default is your native currency, USD elsewhere)")
+}
+
+// fiatCurrency returns the --currency currency upper cased, or `def` when it is not set.
+func fiatCurrency(def string) string {
+	if displayCurrency == "" {
+		return def
+	}
+
+	return strings.ToUpper(displayCurrency)
+}
+
+// withCurrency returns `c` showing its amounts in the --currency currency, or `c` itself when it is not set.
+// Amounts are converted at the current rate of the Coinbase exchange-rates endpoint and transactions at the rate of
+// their day, see fx.Client.
+func withCurrency(c coinbase.Client) coinbase.Client {
+	if displayCurrency == "" {
+		return c
+	}
+
+	return fx.NewClient(c, displayCurrency)
+}
+
+// nativeClient returns the client wrapped by withCurrency(), for what has to see the amounts in the native currency
+// of the user, such as the local store.
+func nativeClient(c coinbase.Client) coinbase.Client {
+	if fc, ok := c.(fx.Client); ok {
+		return fc.Client
+	}
+
+	return c
+}
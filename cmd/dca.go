@@ -0,0 +1,356 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/KalebHawkins/crypto-client/advancedtrade"
+	"github.com/KalebHawkins/crypto-client/dca"
+	"github.com/KalebHawkins/crypto-client/internal/userdata"
+	"github.com/fatih/color"
+	"github.com/rodaine/table"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+)
+
+// dcaCmd represents the dca command
+var dcaCmd = &cobra.Command{
+	Use:   "dca",
+	Short: "buy a fixed amount of an asset on a schedule.",
+	Long: `Dollar-cost-average into assets by buying a fixed amount on a schedule.
+
+The recurring buys are defined in the dca section of the config file. The asset may be a bare
+symbol, bought with USD, or a product such as BTC-EUR. The cadence is daily, weekly, biweekly,
+monthly or a duration such as 12h. The amount is in the quote currency of the product.
+
+	dca:
+	  monthly_cap: 300
+	  rules:
+	    - name: btc-weekly
+	      asset: BTC
+	      amount: 50
+	      cadence: weekly
+	    - name: eth-monthly
+	      asset: ETH-USD
+	      amount: 100
+	      cadence: monthly
+
+"dca run" places the buys that are due as market orders on Coinbase Advanced Trade, using the
+COINBASE_ADVANCED_KEY_NAME and COINBASE_ADVANCED_PRIVATE_KEY credentials, and polls until
+interrupted or once with --once for example from cron. A rule that never bought buys at once.
+
+Safeguards:
+  - --dry-run prints the buys that are due without placing or recording them.
+  - The monthly_cap, or --cap, limits the total bought per calendar month. A buy exceeding it is
+    skipped. Buys are only placed for real when a cap is set, and the cap needs every rule to use
+    the same quote currency.
+  - Every attempt at the same buy sends the same client order ID, derived from the rule and when
+    the buy is due. A buy that failed after Coinbase accepted it, for example on a timeout, is
+    returned by Coinbase when it is retried instead of being placed a second time.
+
+Every placed buy is recorded locally. Running this command without a subcommand lists the rules
+with their last and next buy.
+
+	$ crypto-client dca
+	$ crypto-client dca run --dry-run --once
+	$ crypto-client dca run --interval 5m
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		rules, spendCap, err := configuredDCA()
+		errHandler(err)
+
+		d, err := userdata.Load()
+		errHandler(err)
+
+		printDCARules(rules, d, dcaCapFlag(spendCap), time.Now())
+	},
+}
+
+// dcaRunCmd represents the dca run command
+var dcaRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "place the recurring buys as they become due.",
+
+	Run: func(cmd *cobra.Command, args []string) {
+		rules, spendCap, err := configuredDCA()
+		errHandler(err)
+		spendCap = dcaCapFlag(spendCap)
+
+		if len(rules) == 0 {
+			errHandler(fmt.Errorf("no recurring buys are configured, see crypto-client dca -h"))
+		}
+
+		var buy dcaBuyer
+		if !dcaDryRun {
+			if !spendCap.IsPositive() {
+				errHandler(fmt.Errorf("refusing to place buys without a spending cap, set dca.monthly_cap or --cap, or use --dry-run"))
+			}
+
+			c, err := advancedtrade.APIKeyClient()
+			errHandler(err)
+			buy = advancedTradeBuy(c)
+		}
+
+		runDCA(rules, spendCap, buy, dcaInterval, dcaOnce)
+	},
+}
+
+var dcaDryRun bool
+var dcaOnce bool
+var dcaInterval time.Duration
+var dcaCap string
+
+func init() {
+	rootCmd.AddCommand(dcaCmd)
+	dcaCmd.AddCommand(dcaRunCmd)
+	dcaCmd.PersistentFlags().StringVar(&dcaCap, "cap", "", "the most to buy per calendar month, overriding dca.monthly_cap")
+	dcaRunCmd.Flags().BoolVar(&dcaDryRun, "dry-run", false, "print the buys that are due without placing them")
+	dcaRunCmd.Flags().BoolVar(&dcaOnce, "once", false, "place the buys that are due and exit")
+	dcaRunCmd.Flags().DurationVar(&dcaInterval, "interval", time.Minute, "the time between checks for due buys")
+}
+
+// dcaConfig is the dca section of the config file. The amounts are read as text, so they are exact decimals.
+type dcaConfig struct {
+	MonthlyCap string `mapstructure:"monthly_cap"`
+	Rules      []struct {
+		Name    string `mapstructure:"name"`
+		Asset   string `mapstructure:"asset"`
+		Amount  string `mapstructure:"amount"`
+		Cadence string `mapstructure:"cadence"`
+	} `mapstructure:"rules"`
+}
+
+// configuredDCA returns the recurring buys and the monthly spending cap in the dca section of the config file.
+// An error is returned if the section cannot be read or a rule is invalid.
+func configuredDCA() ([]dca.Rule, decimal.Decimal, error) {
+	var cfg dcaConfig
+	if err := loadedConfig.UnmarshalKey("dca", &cfg); err != nil {
+		return nil, decimal.Zero, fmt.Errorf("reading the dca section of the config file: %w", err)
+	}
+
+	var rules []dca.Rule
+	for _, r := range cfg.Rules {
+		cadence, err := dca.ParseCadence(r.Cadence)
+		if err != nil {
+			return nil, decimal.Zero, fmt.Errorf("dca rule %q: %w", r.Name, err)
+		}
+
+		amount, err := parseDCAAmount(r.Amount)
+		if err != nil {
+			return nil, decimal.Zero, fmt.Errorf("dca rule %q: invalid amount %q", r.Name, r.Amount)
+		}
+
+		rule := dca.Rule{Name: r.Name, Amount: amount, Cadence: cadence}
+		if r.Asset != "" {
+			rule.ProductID = productIDs([]string{r.Asset})[0]
+		}
+		rules = append(rules, rule)
+	}
+
+	if err := dca.Validate(rules); err != nil {
+		return nil, decimal.Zero, err
+	}
+
+	spendCap, err := parseDCAAmount(cfg.MonthlyCap)
+	if err != nil || spendCap.IsNegative() {
+		return nil, decimal.Zero, fmt.Errorf("dca.monthly_cap must be an amount that is not negative, got %q", cfg.MonthlyCap)
+	}
+
+	return rules, spendCap, nil
+}
+
+// parseDCAAmount parses an amount of the dca section of the config file, an empty amount is zero.
+func parseDCAAmount(s string) (decimal.Decimal, error) {
+	if strings.TrimSpace(s) == "" {
+		return decimal.Zero, nil
+	}
+
+	return decimal.NewFromString(strings.TrimSpace(s))
+}
+
+// dcaCapFlag returns the --cap flag when it is set and `configured` otherwise.
+func dcaCapFlag(configured decimal.Decimal) decimal.Decimal {
+	if dcaCap == "" {
+		return configured
+	}
+
+	c, err := decimal.NewFromString(dcaCap)
+	if err != nil || !c.IsPositive() {
+		errHandler(fmt.Errorf("--cap must be a positive amount, got %q", dcaCap))
+	}
+
+	return c
+}
+
+// dcaQuote returns the quote currency of the rules, or an error if they do not share one.
+func dcaQuote(rules []dca.Rule) (string, error) {
+	quote := ""
+	for _, r := range rules {
+		q := r.ProductID[strings.LastIndex(r.ProductID, "-")+1:]
+		if quote != "" && q != quote {
+			return "", fmt.Errorf("the spending cap needs every dca rule to buy with the same currency, found %s and %s", quote, q)
+		}
+		quote = q
+	}
+
+	return quote, nil
+}
+
+// dcaBuyer places a buy of `amount` of the quote currency worth of `productID` identified by `clientOrderID` and
+// returns the order ID.
+type dcaBuyer func(productID string, amount decimal.Decimal, clientOrderID string) (string, error)
+
+// advancedTradeBuy returns a buyer placing market orders on Advanced Trade. Rejected orders are an error.
+func advancedTradeBuy(c advancedtrade.Client) dcaBuyer {
+	return func(productID string, amount decimal.Decimal, clientOrderID string) (string, error) {
+		req := advancedtrade.MarketBuy(productID, amount.String())
+		req.ClientOrderID = clientOrderID
+
+		r, err := c.CreateOrder(req)
+		if err != nil {
+			return "", err
+		}
+
+		if !r.Success {
+			reason := r.FailureReason
+			if r.ErrorResponse.Message != "" {
+				reason = r.ErrorResponse.Message
+			}
+			return "", fmt.Errorf("the order was rejected: %s", reason)
+		}
+
+		return r.SuccessResponse.OrderID, nil
+	}
+}
+
+// runDCA places the buys of `rules` as they become due, checking every `interval` until interrupted or after
+// the first check with `once`. A nil `buy` only prints the buys that are due.
+func runDCA(rules []dca.Rule, spendCap decimal.Decimal, buy dcaBuyer, interval time.Duration, once bool) {
+	if spendCap.IsPositive() {
+		_, err := dcaQuote(rules)
+		errHandler(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	// A rule skipped for the cap stays due, so it is only reported once per month.
+	reported := map[string]time.Time{}
+	for {
+		errHandler(placeDueBuys(rules, spendCap, buy, reported))
+
+		if once {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+// placeDueBuys places the buys of `rules` that are due now and records them. A failed buy is reported and retried
+// on the next check with the same client order ID. Skips for the spending cap are reported unless `reported` holds
+// them for the current month.
+func placeDueBuys(rules []dca.Rule, spendCap decimal.Decimal, buy dcaBuyer, reported map[string]time.Time) error {
+	d, err := userdata.Load()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	month := dca.MonthStart(now)
+	last := d.LastDCAPurchases()
+	due, skipped := dca.Plan(rules, last, d.DCASpent(month), spendCap, now)
+
+	stamp := now.Format("2006-01-02 15:04:05")
+	for _, s := range skipped {
+		if reported[s.Rule.Name].Equal(month) {
+			continue
+		}
+		reported[s.Rule.Name] = month
+		slog.Warn("skipping DCA rule", "rule", s.Rule.Name, "reason", s.Reason)
+	}
+
+	for _, r := range due {
+		amount := fmtDCAAmount(r)
+		if buy == nil {
+			fmt.Printf("%s: %s: would buy %s of %s\n", stamp, r.Name, amount, r.ProductID)
+			continue
+		}
+
+		clientOrderID := r.ClientOrderID(last[r.Name])
+		orderID, err := buy(r.ProductID, r.Amount, clientOrderID)
+		if err != nil {
+			slog.Error("placing DCA buy", "rule", r.Name, "amount", amount, "product", r.ProductID, "client_order_id", clientOrderID, "error", err)
+			continue
+		}
+		fmt.Printf("%s: %s: bought %s of %s, order %s\n", stamp, r.Name, amount, r.ProductID, orderID)
+
+		// Record every buy at once and reload first, so a crash cannot buy twice and concurrent edits are kept.
+		d, err = userdata.Load()
+		if err != nil {
+			return err
+		}
+		d.AddDCAPurchase(userdata.DCAPurchase{Rule: r.Name, Time: now, ProductID: r.ProductID, Amount: r.Amount, OrderID: orderID})
+		if err := d.Save(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// printDCARules prints `rules` with their last and next buy, followed by the spending of the current month.
+func printDCARules(rules []dca.Rule, d *userdata.Data, spendCap decimal.Decimal, now time.Time) {
+	if len(rules) == 0 {
+		fmt.Println("No recurring buys are configured, see crypto-client dca -h.")
+		return
+	}
+
+	table.DefaultHeaderFormatter = func(s string, i ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(s, i...))
+	}
+	headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
+	tbl := newTable("Name", "Product", "Amount", "Cadence", "Last Buy", "Next Buy").WithHeaderFormatter(headerFmt)
+
+	last := d.LastDCAPurchases()
+	for _, r := range rules {
+		lastBuy, nextBuy := "never", "now"
+		if t, ok := last[r.Name]; ok {
+			lastBuy = t.Local().Format("2006-01-02 15:04")
+			if !r.Due(t, now) {
+				nextBuy = r.Cadence.Next(t).Local().Format("2006-01-02 15:04")
+			}
+		}
+		tbl.AddRow(r.Name, r.ProductID, fmtDCAAmount(r), r.Cadence, lastBuy, nextBuy)
+	}
+
+	tbl.Print()
+
+	fmt.Println()
+	spent := d.DCASpent(dca.MonthStart(now))
+	quote, err := dcaQuote(rules)
+	if err != nil {
+		fmt.Println("Spent This Month:", spent)
+		return
+	}
+
+	fmt.Println("Spent This Month:", fmtAmount(spent, quote))
+	if spendCap.IsPositive() {
+		fmt.Println("Monthly Cap:", fmtAmount(spendCap, quote))
+	}
+}
+
+// fmtDCAAmount formats the amount `r` buys in the quote currency of its product.
+func fmtDCAAmount(r dca.Rule) string {
+	return fmtAmount(r.Amount, r.ProductID[strings.LastIndex(r.ProductID, "-")+1:])
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/KalebHawkins/crypto-client/internal/httpdebug"
+)
+
+var debugHTTP string
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&debugHTTP, "debug-http", "", "write every API request and response with credentials redacted to this file, or stderr when given without a file")
+	rootCmd.PersistentFlags().Lookup("debug-http").NoOptDefVal = "-"
+}
+
+// initDebugHTTP routes the requests of every provider through an httpdebug.Transport with --debug-http. It wraps
+// the fault injection of --inject-faults, so injected failures show up in the dump.
+func initDebugHTTP() {
+	if debugHTTP == "" {
+		return
+	}
+
+	var out io.Writer = os.Stderr
+	if debugHTTP != "-" {
+		f, err := os.OpenFile(debugHTTP, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+		errHandler(err)
+		out = f
+	}
+
+	http.DefaultTransport = httpdebug.NewTransport(http.DefaultTransport, out)
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// secretKeys are the JSON keys whose values are replaced before a response is dumped.
+var secretKeys = map[string]bool{
+	"access_token":  true,
+	"refresh_token": true,
+	"id_token":      true,
+	"secret":        true,
+	"api_secret":    true,
+	"client_secret": true,
+	"password":      true,
+	"private_key":   true,
+}
+
+var dumpSeq uint64
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9.-]+`)
+
+// rawResponse is the file format written by --dump-raw.
+type rawResponse struct {
+	Time     time.Time       `json:"time"`
+	Method   string          `json:"method"`
+	Path     string          `json:"path"`
+	Status   int             `json:"status"`
+	Response json.RawMessage `json:"response,omitempty"`
+	Text     string          `json:"text,omitempty"`
+}
+
+// dumpRawResponse writes a response received by the Coinbase client to the --dump-raw directory. Failing to dump a
+// response is reported but does not fail the command.
+func dumpRawResponse(method string, path string, status int, body []byte) {
+	now := time.Now().UTC()
+	raw := rawResponse{Time: now, Method: method, Path: path, Status: status}
+
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err == nil {
+		raw.Response, _ = json.Marshal(redactSecrets(v))
+	} else {
+		raw.Text = string(body)
+	}
+
+	b, err := json.MarshalIndent(raw, "", "  ")
+	if err == nil {
+		err = os.MkdirAll(dumpRawDir, 0700)
+	}
+	if err == nil {
+		name := fmt.Sprintf("%s-%06d-%s-%s.json", now.Format("20060102T150405.000Z"), atomic.AddUint64(&dumpSeq, 1),
+			method, strings.Trim(unsafeFileChars.ReplaceAllString(path, "_"), "_"))
+		err = ioutil.WriteFile(filepath.Join(dumpRawDir, name), b, 0600)
+	}
+
+	if err != nil {
+		slog.Warn("writing the raw response", "path", path, "error", err)
+	}
+}
+
+// redactSecrets returns `v` with the values of every key in secretKeys replaced, at any depth.
+func redactSecrets(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, val := range t {
+			if secretKeys[strings.ToLower(k)] {
+				t[k] = "REDACTED"
+				continue
+			}
+			t[k] = redactSecrets(val)
+		}
+	case []interface{}:
+		for i, val := range t {
+			t[i] = redactSecrets(val)
+		}
+	}
+
+	return v
+}
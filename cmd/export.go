@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/KalebHawkins/crypto-client/coinbase"
+	"github.com/KalebHawkins/crypto-client/internal/promtext"
+	"github.com/KalebHawkins/crypto-client/money"
+	"github.com/KalebHawkins/crypto-client/portfolio"
+	"github.com/spf13/cobra"
+)
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "make your holdings available to other tools.",
+
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+// exportPrometheusCmd represents the export prometheus command
+var exportPrometheusCmd = &cobra.Command{
+	Use:   "prometheus",
+	Short: "serve your Coinbase holdings as Prometheus metrics.",
+	Long: `Run an HTTP listener serving the Coinbase holdings as Prometheus gauges, to graph them in Grafana.
+
+The gauges are the balance, value and total return of every wallet, the spot price of every held
+asset and the value and total return of the whole portfolio, in the native currency of the user.
+Ignored wallets are left out. The holdings are looked up when scraped, at most once per --refresh,
+so frequent scrapes do not run into the rate limits. crypto_client_up is 0 while the last lookup
+failed, the gauges then keep the values of the last successful one.
+
+	$ crypto-client export prometheus --listen :9109
+
+	scrape_configs:
+	  - job_name: crypto-client
+	    static_configs:
+	      - targets: ["localhost:9109"]
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		method, err := portfolio.ParseMethod(exportCostBasis)
+		errHandler(err)
+
+		e := &exporter{c: newCoinbaseClient(), method: method, refresh: exportRefresh}
+
+		mux := http.NewServeMux()
+		mux.Handle(exportPath, e)
+
+		fmt.Printf("Serving Prometheus metrics on %s%s\n", exportListen, exportPath)
+		errHandler(http.ListenAndServe(exportListen, mux))
+	},
+}
+
+var exportListen string
+var exportPath string
+var exportRefresh time.Duration
+var exportCostBasis string
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.AddCommand(exportPrometheusCmd)
+	exportPrometheusCmd.Flags().StringVar(&exportListen, "listen", ":9109", "the address to listen on")
+	exportPrometheusCmd.Flags().StringVar(&exportPath, "path", "/metrics", "the URL path the metrics are served on")
+	exportPrometheusCmd.Flags().DurationVar(&exportRefresh, "refresh", time.Minute, "the least time between lookups of the holdings")
+	exportPrometheusCmd.Flags().StringVar(&exportCostBasis, "cost-basis", string(portfolio.FIFO), "how sold lots are matched to buys for the total return: fifo, lifo or hifo")
+}
+
+// exporter serves the holdings as Prometheus metrics, looking them up at most once per refresh.
+type exporter struct {
+	c       coinbase.Client
+	method  portfolio.Method
+	refresh time.Duration
+
+	mu          sync.Mutex
+	gauges      []promtext.Gauge
+	lookedUp    time.Time
+	lastSuccess time.Time
+	duration    time.Duration
+	err         error
+}
+
+// ServeHTTP writes the metrics, looking up the holdings first when the last lookup is older than the refresh.
+func (e *exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if time.Since(e.lookedUp) >= e.refresh {
+		start := time.Now()
+		gauges, err := holdingGauges(e.c, e.method)
+		e.lookedUp, e.duration, e.err = start, time.Since(start), err
+		if err != nil {
+			slog.Error("looking up the holdings", "error", err)
+		} else {
+			e.gauges, e.lastSuccess = gauges, start
+		}
+	}
+
+	up := promtext.Gauge{Name: "crypto_client_up", Help: "Whether the last lookup of the holdings succeeded."}
+	up.Add(1)
+	if e.err != nil {
+		up.Samples[0].Value = 0
+	}
+
+	last := promtext.Gauge{Name: "crypto_client_last_success_timestamp_seconds", Help: "When the holdings were last looked up successfully."}
+	if !e.lastSuccess.IsZero() {
+		last.Add(float64(e.lastSuccess.Unix()))
+	}
+
+	duration := promtext.Gauge{Name: "crypto_client_lookup_duration_seconds", Help: "How long the last lookup of the holdings took."}
+	duration.Add(e.duration.Seconds())
+
+	w.Header().Set("Content-Type", promtext.ContentType)
+	promtext.Write(w, append([]promtext.Gauge{up, last, duration}, e.gauges...))
+}
+
+// holdingGauges looks up the tracked wallets with a balance and returns their balance, value and total return, the
+// spot price of their assets and the value and total return of the portfolio. An error is returned if any lookup
+// failed.
+func holdingGauges(c coinbase.Client, method portfolio.Method) ([]promtext.Gauge, error) {
+	user, err := c.GetUserProfile()
+	if err != nil {
+		return nil, err
+	}
+	native := user.Data.NativeCurrency
+
+	accounts, err := getTrackedAccounts(c)
+	if err != nil {
+		return nil, err
+	}
+
+	balance := promtext.Gauge{Name: "crypto_client_balance", Help: "The balance of a wallet in units of its asset."}
+	price := promtext.Gauge{Name: "crypto_client_spot_price", Help: "The spot price of an asset."}
+	value := promtext.Gauge{Name: "crypto_client_value", Help: "The value of a wallet at the spot price."}
+	ret := promtext.Gauge{Name: "crypto_client_total_return", Help: "The realized and unrealized gain of a wallet."}
+	portfolioValue := promtext.Gauge{Name: "crypto_client_portfolio_value", Help: "The value of all tracked wallets at the spot price."}
+	portfolioReturn := promtext.Gauge{Name: "crypto_client_portfolio_total_return", Help: "The realized and unrealized gain of all tracked wallets."}
+
+	totalValue := money.Zero(native)
+	totalPnL := portfolio.PnL{Realized: money.Zero(native), Unrealized: money.Zero(native)}
+	spots := map[string]money.Money{}
+	books := map[string]*portfolio.Book{}
+	for _, a := range accounts.Data {
+		amt := a.Balance.Amount
+		if !amt.IsPositive() {
+			continue
+		}
+		asset := a.Balance.Currency
+
+		spot, ok := spots[asset]
+		if !ok {
+			p, err := c.GetPrice(asset+"-"+native, coinbase.Spot)
+			if err != nil {
+				return nil, err
+			}
+			spot = p.Data.Money
+			spots[asset] = spot
+			price.Add(spot.Amount.InexactFloat64(), "asset", asset, "currency", native)
+		}
+
+		canonical := assetMap().Canonical(asset)
+		book, counted := books[canonical]
+		if !counted {
+			book, err = costBasisBook(c, method, native, canonical, accounts)
+			if err != nil {
+				return nil, err
+			}
+			books[canonical] = book
+			if book.Skipped > 0 {
+				slog.Warn("leaving events valued in another currency out of the total return", "asset", canonical, "events", book.Skipped)
+			}
+		}
+		pnl := book.Position(canonical).PnL(spot)
+		v := spot.Mul(amt)
+
+		balance.Add(amt.InexactFloat64(), "wallet", a.Name, "asset", asset)
+		value.Add(v.Amount.InexactFloat64(), "wallet", a.Name, "asset", asset, "currency", native)
+		ret.Add(pnl.Total().Amount.InexactFloat64(), "wallet", a.Name, "asset", asset, "currency", native)
+
+		totalValue = totalValue.Add(v)
+		if !counted {
+			totalPnL = totalPnL.Add(pnl)
+		}
+	}
+
+	portfolioValue.Add(totalValue.Amount.InexactFloat64(), "currency", native)
+	portfolioReturn.Add(totalPnL.Total().Amount.InexactFloat64(), "currency", native)
+
+	return []promtext.Gauge{balance, price, value, ret, portfolioValue, portfolioReturn}, nil
+}
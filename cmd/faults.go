@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/KalebHawkins/crypto-client/internal/faults"
+)
+
+// faultsEnv enables fault injection for every invocation, for example in CI, when --inject-faults is not given.
+const faultsEnv = "CRYPTO_CLIENT_FAULTS"
+
+var injectFaults string
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&injectFaults, "inject-faults", "", "randomly inject API failures to test retries, for example latency=0.2,delay=2s,429=0.1,5xx=0.05,truncate=0.05,seed=42 (also "+faultsEnv+")")
+}
+
+// initFaults routes the requests of every provider through a faults.Transport with --inject-faults or
+// CRYPTO_CLIENT_FAULTS. The providers create their HTTP clients without a transport, which makes them use
+// http.DefaultTransport.
+func initFaults() {
+	s := injectFaults
+	if s == "" {
+		s = os.Getenv(faultsEnv)
+	}
+	if s == "" {
+		return
+	}
+
+	spec, err := faults.Parse(s)
+	errHandler(err)
+
+	http.DefaultTransport = faults.NewTransport(http.DefaultTransport, spec)
+	fmt.Fprintln(os.Stderr, "warning: injecting faults into API requests:", spec)
+}
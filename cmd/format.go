@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/KalebHawkins/crypto-client/money"
+	"github.com/shopspring/decimal"
+)
+
+// formatPolicy decides the decimal places and rounding of every amount printed, see money.Policy.
+var formatPolicy = money.DefaultPolicy
+
+var formatPlaces map[string]int
+var formatRounding string
+
+func init() {
+	pf := rootCmd.PersistentFlags()
+	pf.Int32Var(&formatPolicy.FiatPlaces, "fiat-places", money.DefaultPolicy.FiatPlaces, "the decimal places of fiat amounts")
+	pf.Int32Var(&formatPolicy.SignificantFigures, "significant-figures", money.DefaultPolicy.SignificantFigures, "the significant figures of crypto amounts without fixed places")
+	pf.StringToIntVar(&formatPlaces, "places", nil, "fixed decimal places per asset, for example BTC=8,ETH=6 (BTC defaults to 8)")
+	pf.StringVar(&formatRounding, "rounding", string(money.DefaultPolicy.Rounding), "how amounts are rounded, one of half-up, half-even, down or up")
+}
+
+// initFormat builds the formatting policy from the flags.
+func initFormat() {
+	places := map[string]int32{}
+	for asset, p := range money.DefaultPolicy.Places {
+		places[asset] = p
+	}
+	for asset, p := range formatPlaces {
+		places[strings.ToUpper(asset)] = int32(p)
+	}
+
+	formatPolicy.Places = places
+	formatPolicy.Rounding = money.Rounding(strings.ToLower(formatRounding))
+	errHandler(formatPolicy.Validate())
+}
+
+// fmtMoney formats an amount followed by its currency according to the formatting policy.
+func fmtMoney(m money.Money) string {
+	return formatPolicy.Format(m)
+}
+
+// fmtAmount formats an amount of `currency` without the currency according to the formatting policy.
+func fmtAmount(amount decimal.Decimal, currency string) string {
+	return formatPolicy.FormatAmount(amount, currency)
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/KalebHawkins/crypto-client/gemini"
+	"github.com/KalebHawkins/crypto-client/money"
+	"github.com/fatih/color"
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+)
+
+// geminiCmd represents the gemini command
+var geminiCmd = &cobra.Command{
+	Use:   "gemini",
+	Short: "interact with the Gemini API.",
+	Long: `Interact with the Gemini API.
+
+Create an API key with the Auditor role at https://exchange.gemini.com/settings/api and
+export the GEMINI_KEY and GEMINI_SECRET environment variables.
+
+	[Linux]
+	export GEMINI_KEY="API_KEY"
+	export GEMINI_SECRET="API_SECRET"
+
+	[Windows (Powershell)]
+	$env:GEMINI_KEY = "API_KEY"
+	$env:GEMINI_SECRET = "API_SECRET"
+
+Without flags an overview of your exchange balances is shown, valued in USD or the currency given with
+--currency. Use --transfers to list your latest deposits and withdrawals instead.
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		start := time.Now()
+
+		if geminiTransfers {
+			printGeminiTransfers()
+		} else {
+			getGeminiOverview()
+		}
+
+		fmt.Println()
+		fmt.Println("Elapsed Run Time:", time.Since(start))
+	},
+}
+
+var geminiTransfers bool
+
+func init() {
+	rootCmd.AddCommand(geminiCmd)
+	geminiCmd.Flags().BoolVar(&geminiTransfers, "transfers", false, "list the latest deposits and withdrawals")
+}
+
+// getGeminiOverview will output a wholistic overview of your Gemini exchange balances.
+func getGeminiOverview() {
+	c := gemini.APIKeyClient()
+	quote := fiatCurrency("USD")
+
+	balances, err := c.GetBalances()
+	errHandler(err)
+
+	table.DefaultHeaderFormatter = func(format string, vals ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(format, vals...))
+	}
+	headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
+
+	tbl := newTable("Asset", "Balance", "Available", "Spot Price Per Unit", "Buy Price Per Unit",
+		"Sell Price Per Unit", "Total Sell Out Price").WithHeaderFormatter(headerFmt)
+
+	totalSellOut := money.Zero(quote)
+	for _, b := range balances {
+		if b.Amount.IsZero() {
+			continue
+		}
+
+		if b.Currency == quote {
+			tbl.AddRow(b.Currency, fmtAmount(b.Amount, b.Currency), fmtAmount(b.Available, b.Currency), "", "", "",
+				fmtMoney(money.New(b.Amount, quote)))
+			totalSellOut = totalSellOut.Add(money.New(b.Amount, quote))
+			continue
+		}
+
+		t, err := c.GetTicker(b.Currency + quote)
+		if err != nil {
+			tbl.AddRow(b.Currency, fmtAmount(b.Amount, b.Currency), fmtAmount(b.Available, b.Currency), "n/a", "n/a", "n/a", "n/a")
+			continue
+		}
+
+		sellOut := money.New(t.Bid.Mul(b.Amount), quote)
+		tbl.AddRow(b.Currency, fmtAmount(b.Amount, b.Currency), fmtAmount(b.Available, b.Currency),
+			fmtMoney(money.New(t.Last, quote)),
+			fmtMoney(money.New(t.Ask, quote)),
+			fmtMoney(money.New(t.Bid, quote)),
+			fmtMoney(sellOut))
+
+		totalSellOut = totalSellOut.Add(sellOut)
+	}
+
+	tbl.Print()
+
+	fmt.Printf("Total Sell Out Amount: %s\n", fmtMoney(totalSellOut))
+}
+
+// printGeminiTransfers lists the latest Gemini deposits and withdrawals, newest first.
+func printGeminiTransfers() {
+	transfers, err := gemini.APIKeyClient().GetTransfers(time.Time{})
+	errHandler(err)
+
+	table.DefaultHeaderFormatter = func(format string, vals ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(format, vals...))
+	}
+	headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
+
+	tbl := newTable("Date", "Type", "Status", "Currency", "Amount", "Method", "Tx Hash").WithHeaderFormatter(headerFmt)
+	for _, t := range transfers {
+		tbl.AddRow(t.Time().Local().Format("2006-01-02 15:04"), t.Type, t.Status, t.Currency,
+			t.Amount.String(), t.Method, t.TxHash)
+	}
+
+	tbl.Print()
+}
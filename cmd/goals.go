@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/KalebHawkins/crypto-client/coinbase"
+	"github.com/KalebHawkins/crypto-client/internal/userdata"
+	"github.com/KalebHawkins/crypto-client/money"
+	"github.com/fatih/color"
+	"github.com/rodaine/table"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+)
+
+// goalsCmd represents the goals command
+var goalsCmd = &cobra.Command{
+	Use:   "goals",
+	Short: "track progress towards portfolio goals.",
+	Long: `Track progress towards portfolio goals.
+
+A goal is either an amount of a single asset to accumulate or a total portfolio value in your
+native currency. Progress is shown below the Coinbase overview and by running this command
+without a subcommand.
+
+	$ crypto-client goals add "one whole coin" --asset BTC --target 1
+	$ crypto-client goals add "six figures" --target 100000
+	$ crypto-client goals remove "six figures"
+	$ crypto-client goals
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		d, err := userdata.Load()
+		errHandler(err)
+
+		if len(d.Goals) == 0 {
+			fmt.Println("No goals defined. Add one with `crypto-client goals add`.")
+			return
+		}
+
+		c := newCoinbaseClient()
+		user, err := c.GetUserProfile()
+		errHandler(err)
+
+		acts, err := getTrackedAccounts(c)
+		errHandler(err)
+
+		balances := map[string]decimal.Decimal{}
+		portfolioValue := money.Zero(user.Data.NativeCurrency)
+
+		for _, a := range acts.Data {
+			if a.Balance.IsPositive() {
+				spotPrice, err := c.GetPrice(fmt.Sprintf("%s-%s", a.Balance.Currency, user.Data.NativeCurrency), coinbase.Spot)
+				errHandler(err)
+
+				balances[a.Balance.Currency] = balances[a.Balance.Currency].Add(a.Balance.Amount)
+				portfolioValue = portfolioValue.Add(spotPrice.Data.Mul(a.Balance.Amount))
+			}
+		}
+
+		printGoalProgress(d.Goals, balances, portfolioValue)
+	},
+}
+
+// goalsAddCmd represents the goals add command
+var goalsAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "add or replace a goal.",
+	Args:  cobra.ExactArgs(1),
+
+	Run: func(cmd *cobra.Command, args []string) {
+		if goalTarget <= 0 {
+			errHandler(fmt.Errorf("--target must be greater than zero"))
+		}
+
+		d, err := userdata.Load()
+		errHandler(err)
+		d.SetGoal(userdata.Goal{Name: args[0], Asset: goalAsset, Target: goalTarget})
+		errHandler(d.Save())
+	},
+}
+
+// goalsRemoveCmd represents the goals remove command
+var goalsRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "remove a goal.",
+	Args:  cobra.ExactArgs(1),
+
+	Run: func(cmd *cobra.Command, args []string) {
+		d, err := userdata.Load()
+		errHandler(err)
+
+		if !d.RemoveGoal(args[0]) {
+			errHandler(fmt.Errorf("no goal named %q", args[0]))
+		}
+		errHandler(d.Save())
+	},
+}
+
+var goalAsset string
+var goalTarget float64
+
+func init() {
+	rootCmd.AddCommand(goalsCmd)
+	goalsCmd.AddCommand(goalsAddCmd)
+	goalsCmd.AddCommand(goalsRemoveCmd)
+	goalsAddCmd.Flags().StringVar(&goalAsset, "asset", "", "accumulate this asset, leave empty for a portfolio value goal")
+	goalsAddCmd.Flags().Float64Var(&goalTarget, "target", 0, "the amount of the asset or the portfolio value to reach")
+	goalsAddCmd.MarkFlagRequired("target")
+}
+
+// printGoalProgress prints a progress bar for every goal. Asset goals are measured against `balances`, keyed by
+// currency, and portfolio goals against `portfolioValue` in the native currency.
+func printGoalProgress(goals []userdata.Goal, balances map[string]decimal.Decimal, portfolioValue money.Money) {
+	if len(goals) == 0 {
+		return
+	}
+
+	table.DefaultHeaderFormatter = func(s string, i ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(s, i...))
+	}
+	headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
+	tbl := newTable("Goal", "Current", "Target", "Progress").WithHeaderFormatter(headerFmt)
+
+	for _, g := range goals {
+		var have, target money.Money
+
+		if g.Asset != "" {
+			have = money.New(balances[g.Asset], g.Asset)
+			target = money.New(decimal.NewFromFloat(g.Target), g.Asset)
+			tbl.AddRow(g.Name, fmtMoney(have), fmtMoney(target), progressBar(have, target, 20))
+		} else {
+			have = portfolioValue
+			target = money.New(decimal.NewFromFloat(g.Target), portfolioValue.Currency)
+			tbl.AddRow(g.Name, fmtMoney(have), fmtMoney(target), progressBar(have, target, 20))
+		}
+	}
+
+	tbl.Print()
+}
+
+// progressBar renders how far `have` is towards `target` as a fixed width bar followed by the percentage, for
+// example "[##########----------]  50.0%". The bar is capped at full, the percentage is not.
+func progressBar(have money.Money, target money.Money, width int) string {
+	fraction := have.Div(target.Amount).Float64()
+	filled := int(fraction * float64(width))
+	if filled > width {
+		filled = width
+	}
+	if filled < 0 {
+		filled = 0
+	}
+
+	return fmt.Sprintf("[%s%s] %5.1f%%", strings.Repeat("#", filled), strings.Repeat("-", width-filled), fraction*100)
+}
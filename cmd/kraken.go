@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/KalebHawkins/crypto-client/kraken"
+	"github.com/KalebHawkins/crypto-client/money"
+	"github.com/fatih/color"
+	"github.com/rodaine/table"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+)
+
+// krakenCmd represents the kraken command
+var krakenCmd = &cobra.Command{
+	Use:   "kraken",
+	Short: "interact with the Kraken API.",
+	Long: `Interact with the Kraken API.
+
+Create an API key at https://www.kraken.com/u/security/api with the "Query Funds" and
+"Query Closed Orders & Trades" permissions. Then export the KRAKEN_KEY and KRAKEN_SECRET
+environment variables.
+
+	[Linux]
+	export KRAKEN_KEY="API_KEY"
+	export KRAKEN_SECRET="API_SECRET"
+
+	[Windows (Powershell)]
+	$env:KRAKEN_KEY = "API_KEY"
+	$env:KRAKEN_SECRET = "API_SECRET"
+
+Without flags an overview of your assets is shown, valued in USD or the currency given with --currency.
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		start := time.Now()
+
+		getKrakenOverview()
+
+		fmt.Println()
+		fmt.Println("Elapsed Run Time:", time.Since(start))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(krakenCmd)
+}
+
+// getKrakenOverview will output a wholistic overview of your Kraken account and assets.
+func getKrakenOverview() {
+	c := kraken.APIKeyClient()
+	quote := fiatCurrency("USD")
+
+	balances, err := c.GetBalances()
+	errHandler(err)
+
+	trades, err := c.GetTradesHistory()
+	errHandler(err)
+
+	pairs, err := c.GetAssetPairs()
+	errHandler(err)
+
+	invested := map[string]decimal.Decimal{}
+	for _, t := range trades {
+		p, ok := pairs[t.Pair]
+		if !ok || p.Quote != quote || t.Type != kraken.Buy {
+			continue
+		}
+		invested[p.Base] = invested[p.Base].Add(t.Cost).Add(t.Fee)
+	}
+
+	table.DefaultHeaderFormatter = func(format string, vals ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(format, vals...))
+	}
+	headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
+
+	tbl := newTable("Asset", "Balance", "Spot Price Per Unit", "Buy Price Per Unit", "Sell Price Per Unit",
+		"Total Sell Out Price", "Invested", "Total Return").WithHeaderFormatter(headerFmt)
+
+	assets := make([]string, 0, len(balances))
+	for a := range balances {
+		assets = append(assets, a)
+	}
+	sort.Strings(assets)
+
+	var totalSellOut, totalReturn decimal.Decimal
+	for _, a := range assets {
+		amt := balances[a]
+
+		spot, ask, bid := decimal.NewFromInt(1), decimal.NewFromInt(1), decimal.NewFromInt(1)
+		if a != quote {
+			t, err := c.GetTicker(kraken.Pair(a, quote))
+			if err != nil {
+				tbl.AddRow(a, fmtAmount(amt, a), "n/a", "n/a", "n/a", "n/a", fmtMoney(money.New(invested[a], quote)), "n/a")
+				continue
+			}
+			spot, ask, bid = t.Last, t.Ask, t.Bid
+		}
+
+		sellOut := bid.Mul(amt)
+		ret := sellOut.Sub(invested[a])
+		if a == quote {
+			ret = decimal.Zero
+		}
+
+		tbl.AddRow(a, fmtAmount(amt, a),
+			fmtMoney(money.New(spot, quote)),
+			fmtMoney(money.New(ask, quote)),
+			fmtMoney(money.New(bid, quote)),
+			fmtMoney(money.New(sellOut, quote)),
+			fmtMoney(money.New(invested[a], quote)),
+			fmtMoney(money.New(ret, quote)))
+
+		totalSellOut = totalSellOut.Add(sellOut)
+		totalReturn = totalReturn.Add(ret)
+	}
+
+	tbl.Print()
+
+	fmt.Printf("Total Sell Out Amount: %s\n", fmtMoney(money.New(totalSellOut, quote)))
+	fmt.Printf("Total Return Amount: %s\n", fmtMoney(money.New(totalReturn, quote)))
+}
@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/KalebHawkins/crypto-client/kucoin"
+	"github.com/KalebHawkins/crypto-client/money"
+	"github.com/fatih/color"
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+)
+
+// kucoinCmd represents the kucoin command
+var kucoinCmd = &cobra.Command{
+	Use:   "kucoin",
+	Short: "interact with the KuCoin API.",
+	Long: `Interact with the KuCoin API.
+
+Create a general (read only) API key at https://www.kucoin.com/account/api and export it with
+the passphrase chosen when creating it.
+
+	[Linux]
+	export KUCOIN_KEY="API_KEY"
+	export KUCOIN_SECRET="API_SECRET"
+	export KUCOIN_PASSPHRASE="PASSPHRASE"
+
+	[Windows (Powershell)]
+	$env:KUCOIN_KEY = "API_KEY"
+	$env:KUCOIN_SECRET = "API_SECRET"
+	$env:KUCOIN_PASSPHRASE = "PASSPHRASE"
+
+Without a subcommand an overview of your balances across the main, trade and margin accounts is
+shown, valued in USD or the fiat currency given with --currency.
+
+	$ crypto-client kucoin --currency EUR
+	$ crypto-client kucoin ledger BTC --since 2022-01-01
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		start := time.Now()
+
+		getKucoinOverview()
+
+		fmt.Println()
+		fmt.Println("Elapsed Run Time:", time.Since(start))
+	},
+}
+
+// kucoinLedgerCmd represents the kucoin ledger command
+var kucoinLedgerCmd = &cobra.Command{
+	Use:   "ledger [currency]",
+	Short: "list the ledger entries of your KuCoin accounts.",
+	Args:  cobra.MaximumNArgs(1),
+
+	Run: func(cmd *cobra.Command, args []string) {
+		filter := kucoin.LedgerFilter{}
+		if len(args) == 1 {
+			filter.Currency = args[0]
+		}
+		if kucoinSince != "" {
+			since, err := time.Parse("2006-01-02", kucoinSince)
+			errHandler(err)
+			filter.Start = since
+		}
+
+		entries, err := kucoin.APIKeyClient().GetLedgers(filter)
+		errHandler(err)
+
+		table.DefaultHeaderFormatter = func(s string, i ...interface{}) string {
+			return strings.ToUpper(fmt.Sprintf(s, i...))
+		}
+		headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
+		tbl := newTable("Date", "Currency", "Account", "Type", "Amount", "Fee", "Balance").WithHeaderFormatter(headerFmt)
+
+		for _, e := range entries {
+			tbl.AddRow(e.Time().Local().Format("2006-01-02 15:04"), e.Currency, e.AccountType, e.BizType,
+				e.SignedAmount().String(), e.Fee.String(), e.Balance.String())
+		}
+
+		tbl.Print()
+	},
+}
+
+var kucoinSince string
+
+func init() {
+	rootCmd.AddCommand(kucoinCmd)
+	kucoinCmd.AddCommand(kucoinLedgerCmd)
+	kucoinLedgerCmd.Flags().StringVar(&kucoinSince, "since", "", "only list entries made on or after a date (YYYY-MM-DD)")
+}
+
+// getKucoinOverview will output a wholistic overview of your KuCoin balances.
+func getKucoinOverview() {
+	c := kucoin.APIKeyClient()
+	quote := fiatCurrency("USD")
+
+	balances, err := c.GetBalances()
+	errHandler(err)
+
+	var assets []string
+	for a := range balances {
+		assets = append(assets, a)
+	}
+	sort.Strings(assets)
+
+	prices, err := c.GetPrices(quote, assets...)
+	errHandler(err)
+
+	table.DefaultHeaderFormatter = func(format string, vals ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(format, vals...))
+	}
+	headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
+
+	tbl := newTable("Asset", "Balance", "Spot Price Per Unit", "Total Value").WithHeaderFormatter(headerFmt)
+
+	total := money.Zero(quote)
+	for _, a := range assets {
+		amt := balances[a]
+
+		price, ok := prices[a]
+		if !ok {
+			tbl.AddRow(a, fmtAmount(amt, a), "n/a", "n/a")
+			continue
+		}
+
+		value := money.New(price.Mul(amt), quote)
+		tbl.AddRow(a, fmtAmount(amt, a), fmtMoney(money.New(price, quote)), fmtMoney(value))
+		total = total.Add(value)
+	}
+
+	tbl.Print()
+
+	fmt.Printf("Total Value: %s\n", fmtMoney(total))
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+)
+
+var verbose bool
+var logLevel string
+
+func init() {
+	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "log every API request with its duration, the same as --log-level debug")
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "warn", "the least severe log messages written to stderr: debug, info, warn or error")
+}
+
+// initLogging sets the default slog logger, which writes to stderr at the level chosen by --log-level or --verbose.
+// The Coinbase client logs its requests, retries and rate limit waits to it.
+func initLogging() {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(logLevel)); err != nil {
+		errHandler(fmt.Errorf("invalid log level %q, use debug, info, warn or error", logLevel))
+	}
+	if verbose {
+		level = slog.LevelDebug
+	}
+
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level})))
+}
@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/KalebHawkins/crypto-client/coinmarketcap"
+	"github.com/KalebHawkins/crypto-client/money"
+	"github.com/fatih/color"
+	"github.com/rodaine/table"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+)
+
+// marketCmd represents the market command
+var marketCmd = &cobra.Command{
+	Use:   "market [symbol]...",
+	Short: "show market rankings and statistics from CoinMarketCap.",
+	Long: `Show market rankings, circulating supply and global market statistics from CoinMarketCap.
+
+Create a free API key at https://coinmarketcap.com/api and export it.
+
+	[Linux]
+	export COINMARKETCAP_KEY="API_KEY"
+
+	[Windows (Powershell)]
+	$env:COINMARKETCAP_KEY = "API_KEY"
+
+Without arguments the global market statistics and the highest ranked cryptocurrencies are shown.
+Given symbols, only those cryptocurrencies are shown.
+
+	$ crypto-client market --limit 10
+	$ crypto-client market BTC ETH SOL --convert EUR
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		start := time.Now()
+
+		c := coinmarketcap.APIKeyClient()
+		convert := strings.ToUpper(marketConvert)
+
+		if len(args) == 0 {
+			g, err := c.GetGlobalMetrics(convert)
+			errHandler(err)
+			printGlobalMetrics(g, convert)
+			fmt.Println()
+
+			listings, err := c.GetListings(marketLimit, convert)
+			errHandler(err)
+			printListings(listings, convert)
+		} else {
+			quotes, err := c.GetQuotes(convert, args...)
+			errHandler(err)
+
+			var listings []coinmarketcap.Listing
+			for _, a := range args {
+				if l, ok := quotes[strings.ToUpper(a)]; ok {
+					listings = append(listings, l)
+				}
+			}
+			printListings(listings, convert)
+		}
+
+		fmt.Println()
+		fmt.Println("Elapsed Run Time:", time.Since(start))
+	},
+}
+
+var marketConvert string
+var marketLimit int
+
+func init() {
+	rootCmd.AddCommand(marketCmd)
+	marketCmd.Flags().StringVar(&marketConvert, "convert", "USD", "the currency to show prices and market caps in")
+	marketCmd.Flags().IntVar(&marketLimit, "limit", 20, "the number of cryptocurrencies to rank")
+}
+
+// printGlobalMetrics prints the statistics of the whole market.
+func printGlobalMetrics(g coinmarketcap.GlobalMetrics, convert string) {
+	q := g.Quote[convert]
+
+	fmt.Println("Total Market Cap:", money.New(q.TotalMarketCap, convert).StringFixed(0))
+	fmt.Println("24h Volume:", money.New(q.TotalVolume24h, convert).StringFixed(0))
+	fmt.Println("BTC Dominance:", g.BTCDominance.StringFixed(2)+"%")
+	fmt.Println("ETH Dominance:", g.ETHDominance.StringFixed(2)+"%")
+	fmt.Println("Active Cryptocurrencies:", g.ActiveCryptocurrencies)
+}
+
+// printListings prints cryptocurrencies with their rank, supply and market data.
+func printListings(listings []coinmarketcap.Listing, convert string) {
+	table.DefaultHeaderFormatter = func(s string, i ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(s, i...))
+	}
+	headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
+	tbl := newTable("Rank", "Name", "Symbol", "Price", "24h", "7d", "Market Cap", "Circulating Supply", "Max Supply").WithHeaderFormatter(headerFmt)
+
+	for _, l := range listings {
+		q := l.Quote[convert]
+
+		maxSupply := "none"
+		if l.MaxSupply != nil {
+			maxSupply = abbreviate(*l.MaxSupply)
+		}
+
+		tbl.AddRow(l.Rank, l.Name, l.Symbol, fmtMoney(money.New(q.Price, convert)),
+			percentChange(q.PercentChange24h), percentChange(q.PercentChange7d),
+			abbreviate(q.MarketCap), abbreviate(l.CirculatingSupply), maxSupply)
+	}
+
+	tbl.Print()
+}
+
+// percentChange formats a percentage with its sign, colored green when positive and red when negative.
+func percentChange(d decimal.Decimal) string {
+	s := fmt.Sprintf("%+.2f%%", d.InexactFloat64())
+	switch d.Sign() {
+	case 1:
+		return color.GreenString(s)
+	case -1:
+		return color.RedString(s)
+	}
+
+	return s
+}
+
+// abbreviate formats a large number with a K, M, B or T suffix, for example 1.23B.
+func abbreviate(d decimal.Decimal) string {
+	units := []struct {
+		suffix string
+		exp    int32
+	}{{"T", 12}, {"B", 9}, {"M", 6}, {"K", 3}}
+
+	for _, u := range units {
+		if d.Abs().Cmp(decimal.New(1, u.exp)) >= 0 {
+			return d.Shift(-u.exp).StringFixed(2) + u.suffix
+		}
+	}
+
+	return d.StringFixed(2)
+}
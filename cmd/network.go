@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+var proxyURL string
+var caCertFile string
+var requestTimeout time.Duration
+var connectTimeout time.Duration
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&proxyURL, "proxy", "", "send API requests through this HTTP, HTTPS or SOCKS5 proxy, for example socks5://localhost:1080 (default is HTTPS_PROXY)")
+	rootCmd.PersistentFlags().DurationVar(&requestTimeout, "timeout", 30*time.Second, "the time limit of an API request, 0 disables the limit")
+	rootCmd.PersistentFlags().DurationVar(&connectTimeout, "connect-timeout", 10*time.Second, "the time limit for connecting to an API, 0 disables the limit")
+	rootCmd.PersistentFlags().StringVar(&caCertFile, "ca-cert", "", "a PEM file of additional certificate authorities to trust, for example that of a TLS intercepting proxy")
+}
+
+// initNetwork configures the timeouts, proxy and trusted certificate authorities of every provider and of the
+// WebSocket feeds with --timeout, --connect-timeout, --proxy and --ca-cert. The providers create their HTTP clients
+// without a transport, which makes them use http.DefaultTransport. Most of them have no request timeout of their
+// own, for them --timeout limits the wait for the response headers. It runs before the fault injection and
+// debugging transports wrap http.DefaultTransport.
+func initNetwork() {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	d := *websocket.DefaultDialer
+
+	t.DialContext = (&net.Dialer{Timeout: connectTimeout, KeepAlive: 30 * time.Second}).DialContext
+	t.TLSHandshakeTimeout = connectTimeout
+	t.ResponseHeaderTimeout = requestTimeout
+	d.HandshakeTimeout = connectTimeout
+
+	if proxyURL != "" {
+		u, err := url.Parse(proxyURL)
+		if err == nil && (u.Scheme == "" || u.Host == "") {
+			err = fmt.Errorf("invalid proxy %q, use a URL such as http://proxy.example.com:3128 or socks5://localhost:1080", proxyURL)
+		}
+		errHandler(err)
+
+		t.Proxy = http.ProxyURL(u)
+		d.Proxy = http.ProxyURL(u)
+	}
+
+	if caCertFile != "" {
+		pool, err := caPool(caCertFile)
+		errHandler(err)
+
+		t.TLSClientConfig = &tls.Config{RootCAs: pool}
+		d.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+
+	http.DefaultTransport = t
+	websocket.DefaultDialer = &d
+}
+
+// caPool returns the system certificate authorities along with those in the PEM file `path`. An error is returned
+// if the file cannot be read or holds no certificate.
+func caPool(path string) (*x509.CertPool, error) {
+	pem, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("%s holds no PEM encoded certificates", path)
+	}
+
+	return pool, nil
+}
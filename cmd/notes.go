@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/KalebHawkins/crypto-client/internal/userdata"
+	"github.com/fatih/color"
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+)
+
+// notesCmd represents the notes command
+var notesCmd = &cobra.Command{
+	Use:   "notes",
+	Short: "attach notes to assets and transactions.",
+	Long: `Attach free-form notes to assets and transactions.
+
+Notes are stored locally in the crypto-client configuration directory and are never sent
+to a provider. Use --transaction to attach the note to a transaction ID instead of an asset.
+
+	$ crypto-client notes add BTC "long term hold, do not sell before 2030"
+	$ crypto-client notes add --transaction 4117f7d6-5694-5b36-bc8f-847509850ea4 "birthday gift"
+	$ crypto-client notes list
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+// notesAddCmd represents the notes add command
+var notesAddCmd = &cobra.Command{
+	Use:   "add <asset|transaction-id> <note>",
+	Short: "attach a note to an asset or transaction.",
+	Args:  cobra.ExactArgs(2),
+
+	Run: func(cmd *cobra.Command, args []string) {
+		d, err := userdata.Load()
+		errHandler(err)
+
+		if noteTransaction {
+			d.AddTransactionNote(args[0], args[1])
+		} else {
+			d.AddAssetNote(args[0], args[1])
+		}
+
+		errHandler(d.Save())
+	},
+}
+
+// notesListCmd represents the notes list command
+var notesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list all notes and tags.",
+	Args:  cobra.NoArgs,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		listNotes()
+	},
+}
+
+var noteTransaction bool
+
+func init() {
+	rootCmd.AddCommand(notesCmd)
+	notesCmd.AddCommand(notesAddCmd)
+	notesCmd.AddCommand(notesListCmd)
+	notesAddCmd.Flags().BoolVar(&noteTransaction, "transaction", false, "treat the first argument as a transaction ID")
+}
+
+// listNotes prints every annotated asset and transaction with its tags and notes.
+func listNotes() {
+	d, err := userdata.Load()
+	errHandler(err)
+
+	table.DefaultHeaderFormatter = func(s string, i ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(s, i...))
+	}
+	headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
+	tbl := newTable("Kind", "Asset / Transaction", "Tags", "Notes").WithHeaderFormatter(headerFmt)
+
+	addRows := func(kind string, m map[string]userdata.Annotation) {
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			a := m[k]
+			tbl.AddRow(kind, k, strings.Join(a.Tags, ","), strings.Join(a.Notes, "; "))
+		}
+	}
+
+	addRows("asset", d.Assets)
+	addRows("transaction", d.Transactions)
+
+	tbl.Print()
+}
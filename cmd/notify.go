@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/KalebHawkins/crypto-client/notify"
+	"github.com/spf13/cobra"
+)
+
+// notifyCmd represents the notify command
+var notifyCmd = &cobra.Command{
+	Use:   "notify",
+	Short: "manage where notifications such as price alerts are sent.",
+	Long: `Notifications such as fired price alerts are sent to every channel configured in the notify
+section of the config file, in addition to being printed.
+
+	notify:
+	  slack:
+	    webhook: "https://hooks.slack.com/services/..."
+	  discord:
+	    webhook: "https://discord.com/api/webhooks/..."
+	  telegram:
+	    token: "123456:bot_token"
+	    chat_id: "987654"
+	  email:
+	    host: smtp.example.com
+	    port: 587
+	    username: "alerts@example.com"
+	    password: "app_password"
+	    from: "alerts@example.com"
+	    to: ["me@example.com"]
+
+	$ crypto-client notify test
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+// notifyTestCmd represents the notify test command
+var notifyTestCmd = &cobra.Command{
+	Use:   "test",
+	Short: "send a test message to every configured channel.",
+
+	Run: func(cmd *cobra.Command, args []string) {
+		n, err := configuredNotifier()
+		errHandler(err)
+		if n == nil {
+			errHandler(fmt.Errorf("no notification channels are configured, see crypto-client notify -h"))
+		}
+
+		errHandler(n.Notify(notify.Message{Title: "crypto-client test", Text: notifyTestText}))
+		fmt.Printf("Sent a test message to %s.\n", n.Name())
+	},
+}
+
+var notifyTestText string
+
+func init() {
+	rootCmd.AddCommand(notifyCmd)
+	notifyCmd.AddCommand(notifyTestCmd)
+	notifyTestCmd.Flags().StringVar(&notifyTestText, "message", "Notifications from crypto-client arrive here.", "the text of the test message")
+}
+
+// notifyConfig is the notify section of the config file.
+type notifyConfig struct {
+	Slack struct {
+		Webhook string `mapstructure:"webhook"`
+	} `mapstructure:"slack"`
+	Discord struct {
+		Webhook string `mapstructure:"webhook"`
+	} `mapstructure:"discord"`
+	Telegram struct {
+		Token  string `mapstructure:"token"`
+		ChatID string `mapstructure:"chat_id"`
+	} `mapstructure:"telegram"`
+	Email struct {
+		Host     string   `mapstructure:"host"`
+		Port     int      `mapstructure:"port"`
+		Username string   `mapstructure:"username"`
+		Password string   `mapstructure:"password"`
+		From     string   `mapstructure:"from"`
+		To       []string `mapstructure:"to"`
+	} `mapstructure:"email"`
+}
+
+// configuredNotifier returns a notifier for every channel in the notify section of the config file, or nil if
+// there are none. An error is returned if a channel is configured incompletely.
+func configuredNotifier() (notify.Notifier, error) {
+	var cfg notifyConfig
+	if err := loadedConfig.UnmarshalKey("notify", &cfg); err != nil {
+		return nil, fmt.Errorf("reading the notify section of the config file: %w", err)
+	}
+
+	var notifiers []notify.Notifier
+	if cfg.Slack.Webhook != "" {
+		notifiers = append(notifiers, notify.NewSlack(cfg.Slack.Webhook))
+	}
+
+	if cfg.Discord.Webhook != "" {
+		notifiers = append(notifiers, notify.NewDiscord(cfg.Discord.Webhook))
+	}
+
+	if t := cfg.Telegram; t.Token != "" || t.ChatID != "" {
+		if t.Token == "" || t.ChatID == "" {
+			return nil, fmt.Errorf("notify.telegram needs both a token and a chat_id")
+		}
+		notifiers = append(notifiers, notify.NewTelegram(t.Token, t.ChatID))
+	}
+
+	if e := cfg.Email; e.Host != "" || e.From != "" || len(e.To) > 0 {
+		if e.Host == "" || e.From == "" || len(e.To) == 0 {
+			return nil, fmt.Errorf("notify.email needs a host, a from address and at least one to address")
+		}
+		notifiers = append(notifiers, notify.Email{Host: e.Host, Port: e.Port, Username: e.Username,
+			Password: e.Password, From: e.From, To: e.To})
+	}
+
+	if len(notifiers) == 0 {
+		return nil, nil
+	}
+
+	return notify.Multi(notifiers...), nil
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/KalebHawkins/crypto-client/coinbase"
+	"github.com/KalebHawkins/crypto-client/internal/store"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// errOffline is returned for every request sent while --offline is set.
+var errOffline = errors.New("the network is not used with --offline")
+
+// offlineTransport fails every request with errOffline.
+type offlineTransport struct{}
+
+// RoundTrip fails `req` with errOffline.
+func (offlineTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return nil, errOffline
+}
+
+var offline bool
+
+var offlineOnce sync.Once
+var offlineClient store.Client
+
+func init() {
+	rootCmd.PersistentFlags().BoolVar(&offline, "offline", false, "render from the local store as of the last sync without using the network, see crypto-client cache -h")
+	rootCmd.PersistentPostRun = func(cmd *cobra.Command, args []string) {
+		reportOffline()
+	}
+}
+
+// newOfflineClient returns the client serving the local store with --offline. Everything that is not stored fails
+// instead of being downloaded.
+func newOfflineClient() coinbase.Client {
+	offlineOnce.Do(func() {
+		c := coinbase.APIKeyClient(append(coinbaseOptions(),
+			coinbase.WithHTTPClient(&http.Client{Transport: offlineTransport{}}), coinbase.WithRetries(0))...)
+		offlineClient = store.NewClient(c, openStore()).Offline()
+	})
+
+	return offlineClient
+}
+
+// reportOffline labels the output of a command run with --offline with the age of the oldest data it showed.
+func reportOffline() {
+	if !offline || offlineClient.AsOf().IsZero() {
+		return
+	}
+
+	at := offlineClient.AsOf()
+	msg := fmt.Sprintf("Offline: showing stored data as of %s, %s old. Run with --cache online to refresh it.",
+		at.Local().Format("2006-01-02 15:04"), time.Since(at).Round(time.Minute))
+
+	if accessible {
+		fmt.Fprintln(os.Stderr, msg)
+		return
+	}
+
+	color.New(color.FgYellow).Fprintln(os.Stderr, msg)
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/KalebHawkins/crypto-client/internal/output"
+	"github.com/fatih/color"
+	"github.com/rodaine/table"
+)
+
+var outputFormat string
+
+// results is where tables are written. In the machine readable formats it is the only writer left on standard
+// output so the results can be piped into other programs.
+var results io.Writer = os.Stdout
+
+func init() {
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", output.Table,
+		"the format results are printed in, one of "+strings.Join(output.Formats, ", "))
+}
+
+// initOutput validates --output. Commands print totals and progress next to their tables, rather than teaching
+// every command about formats everything but the tables is moved to standard error.
+func initOutput() {
+	errHandler(output.Validate(outputFormat))
+
+	if outputFormat != output.Table {
+		color.NoColor = true
+		results = os.Stdout
+		os.Stdout = os.Stderr
+		color.Output = os.Stderr
+	}
+}
+
+// newTable returns the table every command prints its results with. With --output the rows are rendered in the
+// chosen format, with --accessible they are printed as labeled lines which screen readers read out one value at
+// a time, otherwise as aligned columns.
+func newTable(columnHeaders ...interface{}) table.Table {
+	switch {
+	case outputFormat != output.Table:
+		headers := make([]string, len(columnHeaders))
+		for i, h := range columnHeaders {
+			headers[i] = fmt.Sprint(h)
+		}
+		return &recordTable{records: output.Records{Headers: headers}, writer: results}
+	case accessible:
+		return &linearTable{headers: columnHeaders, writer: results}
+	}
+
+	return table.New(columnHeaders...).WithWriter(results)
+}
+
+// recordTable is a table.Table collecting its rows and rendering them in the --output format when printed.
+// Formatting options only make sense for columns and are ignored.
+type recordTable struct {
+	records output.Records
+	writer  io.Writer
+}
+
+// WithHeaderFormatter implements table.Table.
+func (t *recordTable) WithHeaderFormatter(f table.Formatter) table.Table { return t }
+
+// WithFirstColumnFormatter implements table.Table.
+func (t *recordTable) WithFirstColumnFormatter(f table.Formatter) table.Table { return t }
+
+// WithPadding implements table.Table.
+func (t *recordTable) WithPadding(p int) table.Table { return t }
+
+// WithWidthFunc implements table.Table.
+func (t *recordTable) WithWidthFunc(f table.WidthFunc) table.Table { return t }
+
+// WithWriter implements table.Table.
+func (t *recordTable) WithWriter(w io.Writer) table.Table {
+	if w != nil {
+		t.writer = w
+	}
+
+	return t
+}
+
+// AddRow implements table.Table.
+func (t *recordTable) AddRow(vals ...interface{}) table.Table {
+	t.records.Rows = append(t.records.Rows, vals)
+
+	return t
+}
+
+// Print implements table.Table.
+func (t *recordTable) Print() {
+	errHandler(output.Write(t.writer, outputFormat, t.records))
+}
@@ -0,0 +1,248 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/KalebHawkins/crypto-client/coinbase"
+	"github.com/KalebHawkins/crypto-client/internal/fx"
+	"github.com/KalebHawkins/crypto-client/money"
+	"github.com/KalebHawkins/crypto-client/portfolio"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+)
+
+// portfolioCmd represents the portfolio command
+var portfolioCmd = &cobra.Command{
+	Use:   "portfolio",
+	Short: "analyze your portfolio.",
+	Long: `Analyze your portfolio.
+
+The portfolio commands build on the assets you hold with Coinbase, their current spot price
+and the amount you have invested in them through buys.
+
+With --by-currency the value of every asset and the portfolio total are shown in each of the
+given fiat currencies side by side. Add --as-of to value the portfolio as it was at the end of
+a past date, using the exchange rates of that date.
+
+	$ crypto-client portfolio --by-currency USD,EUR
+	$ crypto-client portfolio --by-currency USD,EUR --as-of 2021-12-31
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		if portfolioAsOf != "" && len(byCurrencies) == 0 {
+			errHandler(fmt.Errorf("--as-of only applies to --by-currency"))
+		}
+
+		if len(byCurrencies) == 0 {
+			cmd.Help()
+			return
+		}
+
+		date, err := parseAsOf(portfolioAsOf)
+		errHandler(err)
+
+		printPortfolioByCurrency(byCurrencies, date)
+	},
+}
+
+var byCurrencies []string
+var portfolioAsOf string
+
+func init() {
+	rootCmd.AddCommand(portfolioCmd)
+	portfolioCmd.Flags().StringSliceVar(&byCurrencies, "by-currency", nil, "show values in each of these fiat currencies, for example USD,EUR")
+	portfolioCmd.Flags().StringVar(&portfolioAsOf, "as-of", "", "value the portfolio as of a date (YYYY-MM-DD), used with --by-currency")
+}
+
+// holding is a single asset held in the portfolio. Spot and Invested are in the native currency.
+type holding struct {
+	Wallet   string
+	Currency string
+	Amount   decimal.Decimal
+	Spot     money.Money
+	Invested money.Money
+}
+
+// Value returns the value of the holding at the spot price.
+func (h holding) Value() money.Money {
+	return h.Spot.Mul(h.Amount)
+}
+
+// loadCoinbaseHoldings returns every Coinbase wallet with a positive balance along with its spot price and the
+// amount invested through buys, and the user's native currency the prices are expressed in.
+func loadCoinbaseHoldings(c coinbase.Client) ([]holding, string, error) {
+	user, err := c.GetUserProfile()
+	if err != nil {
+		return nil, "", err
+	}
+	nativeCurrency := user.Data.NativeCurrency
+
+	acts, err := getTrackedAccounts(c)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var holdings []holding
+	for _, a := range acts.Data {
+		if !a.Balance.IsPositive() {
+			continue
+		}
+
+		spotPrice, err := priceOn(c, a.Balance.Currency, nativeCurrency, time.Now())
+		if err != nil {
+			return nil, "", err
+		}
+		transactions, err := c.GetTransactionHistory(a.ID)
+		if err != nil {
+			return nil, "", err
+		}
+
+		invested := money.Zero(nativeCurrency)
+		for _, tr := range transactions.Data {
+			if tr.Type == coinbase.Buy {
+				v, err := nativeValue(c, tr, nativeCurrency)
+				if err != nil {
+					return nil, "", err
+				}
+				invested = invested.Add(v)
+			}
+		}
+
+		holdings = append(holdings, holding{
+			Wallet:   a.Name,
+			Currency: assetMap().Canonical(a.Balance.Currency),
+			Amount:   a.Balance.Amount,
+			Spot:     spotPrice,
+			Invested: invested,
+		})
+	}
+
+	return holdings, nativeCurrency, nil
+}
+
+// parseAsOf parses the date of --as-of in local time, like --since and --until. An empty date is the zero time.
+func parseAsOf(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+
+	d, err := time.ParseInLocation("2006-01-02", s, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --as-of: %w", err)
+	}
+
+	return d, nil
+}
+
+// loadCoinbaseHoldingsAsOf returns the Coinbase holdings as they were at the end of `date`. Balances and the amount
+// invested are rebuilt from the transaction history and valued at the spot price on that date.
+func loadCoinbaseHoldingsAsOf(c coinbase.Client, date time.Time) ([]holding, string, error) {
+	user, err := c.GetUserProfile()
+	if err != nil {
+		return nil, "", err
+	}
+	nativeCurrency := user.Data.NativeCurrency
+
+	acts, err := getTrackedAccounts(c)
+	if err != nil {
+		return nil, "", err
+	}
+
+	cutoff := date.AddDate(0, 0, 1)
+
+	var holdings []holding
+	for _, a := range acts.Data {
+		transactions, err := c.GetTransactionHistory(a.ID)
+		if err != nil {
+			return nil, "", err
+		}
+
+		amt := money.Zero(a.Balance.Currency)
+		invested := money.Zero(nativeCurrency)
+		for _, tr := range transactions.Data {
+			if !tr.CreatedAt.Before(cutoff) {
+				continue
+			}
+			amt = amt.Add(tr.Amount)
+			if tr.Type == coinbase.Buy {
+				v, err := nativeValue(c, tr, nativeCurrency)
+				if err != nil {
+					return nil, "", err
+				}
+				invested = invested.Add(v)
+			}
+		}
+
+		if !amt.IsPositive() {
+			continue
+		}
+
+		price, err := priceOn(c, a.Balance.Currency, nativeCurrency, date)
+		if err != nil {
+			return nil, "", err
+		}
+
+		holdings = append(holdings, holding{
+			Wallet:   a.Name,
+			Currency: assetMap().Canonical(a.Balance.Currency),
+			Amount:   amt.Amount,
+			Spot:     price,
+			Invested: invested,
+		})
+	}
+
+	return holdings, nativeCurrency, nil
+}
+
+// costBasisBook returns the book of `asset`, under its current ticker, over the transactions of every wallet in
+// `accounts` holding it under any of its tickers and the trades imported for it. Wallets emptied by a rename count
+// too, so the lots bought before the rename keep their cost.
+func costBasisBook(c coinbase.Client, method portfolio.Method, native, asset string, accounts coinbase.Account) (*portfolio.Book, error) {
+	assets := assetMap()
+	asset = assets.Canonical(asset)
+
+	var transactions []coinbase.TransactionData
+	for _, a := range accounts.Data {
+		if assets.Canonical(a.Balance.Currency) != asset {
+			continue
+		}
+
+		t, err := c.GetTransactionHistory(a.ID)
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, t.Data...)
+	}
+
+	events := portfolio.FromCoinbase(transactions, assets)
+	for _, ticker := range append([]string{asset}, assets.Aliases(asset)...) {
+		imported, err := importedEvents(ticker)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range imported {
+			e.Asset = asset
+			events = append(events, e)
+		}
+	}
+
+	return portfolio.Build(method, native, events)
+}
+
+// nativeValue returns the native amount of `t` in `native`. Transactions made while the user had another native
+// currency are converted at the exchange rate of their day. An error is returned if looking up the rate failed.
+func nativeValue(c coinbase.Client, t coinbase.TransactionData, native string) (money.Money, error) {
+	v := t.NativeAmount
+	if v.Currency == "" || strings.EqualFold(v.Currency, native) {
+		return v, nil
+	}
+
+	r, err := fx.Load(nativeClient(c), v.Currency, []string{native}, t.CreatedAt)
+	if err != nil {
+		return money.Money{}, fmt.Errorf("converting the %s amount of transaction %s to %s: %w", v.Currency, t.ID, native, err)
+	}
+
+	return r.Convert(v, native)
+}
@@ -0,0 +1,310 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/KalebHawkins/crypto-client/coinbase"
+	"github.com/KalebHawkins/crypto-client/money"
+	"github.com/fatih/color"
+	"github.com/rodaine/table"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+)
+
+// portfolioAttributionCmd represents the portfolio attribution command
+var portfolioAttributionCmd = &cobra.Command{
+	Use:   "attribution",
+	Short: "explain how your portfolio value changed over a period.",
+	Long: `Break the change in value of your portfolio over a period down into its causes, per asset.
+
+Snapshots of your holdings at the start and the end of the period are rebuilt from the transaction
+history and valued at the spot prices of those days. The difference is split into:
+
+	Bought          value of assets acquired through buys and trades
+	Sold            value of assets given up through sells and trades
+	Rewards         staking, inflation and interest rewards
+	Transfers       sends, receives, deposits and withdrawals
+	Fees            network fees paid on sends
+	Price Movement  everything else, the gain or loss from prices moving
+
+Flows are valued at the time they happened. The period defaults to the current month.
+
+	$ crypto-client portfolio attribution
+	$ crypto-client portfolio attribution --from 2022-01-01 --to 2022-03-31
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		now := time.Now().UTC()
+		from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+		var err error
+		if attributionFrom != "" {
+			from, err = time.Parse("2006-01-02", attributionFrom)
+			errHandler(err)
+		}
+		if attributionTo != "" {
+			to, err = time.Parse("2006-01-02", attributionTo)
+			errHandler(err)
+		}
+		if to.Before(from) {
+			errHandler(fmt.Errorf("--to %s is before --from %s", to.Format("2006-01-02"), from.Format("2006-01-02")))
+		}
+
+		printAttribution(from, to)
+	},
+}
+
+var attributionFrom string
+var attributionTo string
+
+func init() {
+	portfolioCmd.AddCommand(portfolioAttributionCmd)
+	portfolioAttributionCmd.Flags().StringVar(&attributionFrom, "from", "", "the first day of the period (YYYY-MM-DD), defaults to the start of the month")
+	portfolioAttributionCmd.Flags().StringVar(&attributionTo, "to", "", "the last day of the period (YYYY-MM-DD), defaults to today")
+}
+
+// rewardTypes are the Coinbase transaction types paying out rewards.
+var rewardTypes = map[string]bool{
+	"inflation_reward": true,
+	"staking_reward":   true,
+	"interest":         true,
+}
+
+// attribution is the change in value of a single asset over a period, split into its causes. Every amount is in
+// the native currency and StartValue plus all contributions equals EndValue.
+type attribution struct {
+	Asset         string
+	StartValue    money.Money
+	Bought        money.Money
+	Sold          money.Money
+	Rewards       money.Money
+	Transfers     money.Money
+	Fees          money.Money
+	PriceMovement money.Money
+	EndValue      money.Money
+}
+
+// newAttribution returns an attribution of `asset` with every amount zero in `native`.
+func newAttribution(asset, native string) attribution {
+	zero := money.Zero(native)
+
+	return attribution{Asset: asset, StartValue: zero, Bought: zero, Sold: zero, Rewards: zero, Transfers: zero,
+		Fees: zero, PriceMovement: zero, EndValue: zero}
+}
+
+// add returns the sum of two attributions, used for the portfolio total.
+func (a attribution) add(o attribution) attribution {
+	return attribution{
+		Asset:         a.Asset,
+		StartValue:    a.StartValue.Add(o.StartValue),
+		Bought:        a.Bought.Add(o.Bought),
+		Sold:          a.Sold.Add(o.Sold),
+		Rewards:       a.Rewards.Add(o.Rewards),
+		Transfers:     a.Transfers.Add(o.Transfers),
+		Fees:          a.Fees.Add(o.Fees),
+		PriceMovement: a.PriceMovement.Add(o.PriceMovement),
+		EndValue:      a.EndValue.Add(o.EndValue),
+	}
+}
+
+// attributeChanges returns the attribution of every Coinbase wallet that held anything during the period from
+// the start of `from` to the end of `to`, and the native currency it is expressed in.
+func attributeChanges(c coinbase.Client, from, to time.Time) ([]attribution, string, error) {
+	user, err := c.GetUserProfile()
+	if err != nil {
+		return nil, "", err
+	}
+	native := user.Data.NativeCurrency
+
+	acts, err := getTrackedAccounts(c)
+	if err != nil {
+		return nil, "", err
+	}
+
+	start := from
+	end := to.AddDate(0, 0, 1)
+
+	var attributions []attribution
+	index := map[string]int{}
+	for _, a := range acts.Data {
+		tr, err := c.GetTransactionHistory(a.ID)
+		if err != nil {
+			return nil, "", err
+		}
+
+		asset := a.Balance.Currency
+		startAmt := money.Zero(asset)
+		endAmt := money.Zero(asset)
+		at := newAttribution(asset, native)
+		active := false
+
+		for _, t := range tr.Data {
+			if !affectsBalance(t.Status) || !t.CreatedAt.Before(end) {
+				continue
+			}
+
+			endAmt = endAmt.Add(t.Amount)
+			if t.CreatedAt.Before(start) {
+				startAmt = startAmt.Add(t.Amount)
+				continue
+			}
+			active = true
+
+			value, err := nativeValue(c, t, native)
+			if err != nil {
+				return nil, "", err
+			}
+			t.NativeAmount = value
+
+			switch {
+			case rewardTypes[t.Type]:
+				at.Rewards = at.Rewards.Add(value)
+			case t.Type == coinbase.Buy, t.Type == coinbase.Sell, t.Type == "trade", t.Type == "advanced_trade_fill":
+				if t.Amount.IsPositive() {
+					at.Bought = at.Bought.Add(value)
+				} else {
+					at.Sold = at.Sold.Add(value)
+				}
+			default:
+				fee := networkFeeValue(t)
+				at.Fees = at.Fees.Sub(fee)
+				at.Transfers = at.Transfers.Add(value).Add(fee)
+			}
+		}
+
+		if !active && startAmt.IsZero() {
+			continue
+		}
+
+		startPrice, err := priceOn(c, asset, native, from)
+		if err != nil {
+			return nil, "", err
+		}
+		endPrice, err := priceOn(c, asset, native, to)
+		if err != nil {
+			return nil, "", err
+		}
+
+		at.StartValue = startPrice.Mul(startAmt.Amount)
+		at.EndValue = endPrice.Mul(endAmt.Amount)
+		at.PriceMovement = at.EndValue.Sub(at.StartValue).Sub(at.Bought).Sub(at.Sold).Sub(at.Rewards).Sub(at.Transfers).Sub(at.Fees)
+
+		// Wallets holding an asset under an old and a new ticker are reported as one asset.
+		at.Asset = assetMap().Canonical(asset)
+		if i, ok := index[at.Asset]; ok {
+			attributions[i] = attributions[i].add(at)
+			continue
+		}
+		index[at.Asset] = len(attributions)
+		attributions = append(attributions, at)
+	}
+
+	return attributions, native, nil
+}
+
+// networkFeeValue returns the native value of the network fee paid on a send, valued at the price implied by the
+// transaction itself.
+func networkFeeValue(t coinbase.TransactionData) money.Money {
+	fee := t.Network.TransactionFee
+	if fee.IsZero() || t.Amount.IsZero() {
+		return money.Zero(t.NativeAmount.Currency)
+	}
+
+	unitPrice := t.NativeAmount.Amount.Div(t.Amount.Amount).Abs()
+
+	return money.New(fee.Amount.Mul(unitPrice), t.NativeAmount.Currency)
+}
+
+// priceOn returns the spot price of `asset` in `native` on `date`. The native currency is always worth one and
+// today's price is the live spot price. Delisted assets are worth zero. Renamed assets are looked up under their
+// current ticker when Coinbase does not know the price under the old one, and the other way around.
+func priceOn(c coinbase.Client, asset, native string, date time.Time) (money.Money, error) {
+	if strings.EqualFold(asset, native) {
+		return money.New(decimal.NewFromInt(1), native), nil
+	}
+
+	assets := assetMap()
+	if assets.IsDelisted(asset) {
+		return money.Zero(native), nil
+	}
+
+	tickers := []string{strings.ToUpper(asset)}
+	current := assets.Canonical(asset)
+	for _, t := range append([]string{current}, assets.Aliases(current)...) {
+		if t != tickers[0] {
+			tickers = append(tickers, t)
+		}
+	}
+
+	var price money.Money
+	var err error
+	for _, t := range tickers {
+		if price, err = spotPriceOn(c, t, native, date); err == nil {
+			return price, nil
+		}
+	}
+
+	return price, err
+}
+
+// spotPriceOn returns the spot price of `asset` in `native` on `date`, today's price is the live spot price.
+func spotPriceOn(c coinbase.Client, asset, native string, date time.Time) (money.Money, error) {
+	pair := fmt.Sprintf("%s-%s", asset, native)
+	if !date.Before(time.Now().UTC().Truncate(24 * time.Hour)) {
+		p, err := c.GetPrice(pair, coinbase.Spot)
+		return p.Data.Money, err
+	}
+
+	p, err := c.GetPriceByDate(pair, date)
+
+	return p.Data.Money, err
+}
+
+// printAttribution prints the attribution of every asset and of the whole portfolio over a period.
+func printAttribution(from, to time.Time) {
+	c := newCoinbaseClient()
+	attributions, native, err := attributeChanges(c, from, to)
+	errHandler(err)
+
+	table.DefaultHeaderFormatter = func(s string, i ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(s, i...))
+	}
+	headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
+	tbl := newTable("Asset", "Start Value", "Bought", "Sold", "Rewards", "Transfers", "Fees", "Price Movement",
+		"End Value").WithHeaderFormatter(headerFmt)
+
+	total := newAttribution("Total", native)
+	for _, a := range attributions {
+		tbl.AddRow(attributionRow(a)...)
+		total = total.add(a)
+	}
+	tbl.AddRow(attributionRow(total)...)
+
+	fmt.Printf("Portfolio attribution in %s from %s to %s\n", native, from.Format("2006-01-02"), to.Format("2006-01-02"))
+	tbl.Print()
+
+	change := total.EndValue.Sub(total.StartValue)
+	fmt.Println()
+	fmt.Printf("Value Change: %s%s\n", fmtMoney(change), percentOf(change, total.StartValue))
+	fmt.Printf("From Price Movement: %s%s\n", fmtMoney(total.PriceMovement), percentOf(total.PriceMovement, total.StartValue))
+	fmt.Printf("From Flows: %s\n", fmtMoney(change.Sub(total.PriceMovement)))
+}
+
+// attributionRow formats an attribution as a table row.
+func attributionRow(a attribution) []interface{} {
+	return []interface{}{a.Asset, fmtMoney(a.StartValue), fmtMoney(a.Bought), fmtMoney(a.Sold),
+		fmtMoney(a.Rewards), fmtMoney(a.Transfers), fmtMoney(a.Fees), fmtMoney(a.PriceMovement),
+		fmtMoney(a.EndValue)}
+}
+
+// percentOf formats `part` as a percentage of `whole`, or nothing when `whole` is zero.
+func percentOf(part, whole money.Money) string {
+	if whole.IsZero() {
+		return ""
+	}
+
+	return fmt.Sprintf(" (%+.2f%%)", part.Amount.Div(whole.Amount).Shift(2).InexactFloat64())
+}
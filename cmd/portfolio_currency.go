@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/KalebHawkins/crypto-client/internal/fx"
+	"github.com/KalebHawkins/crypto-client/money"
+	"github.com/fatih/color"
+	"github.com/rodaine/table"
+)
+
+// printPortfolioByCurrency prints the value of every Coinbase holding and the portfolio total in each of
+// `currencies`. A zero `date` uses live prices and rates, otherwise the holdings and rates at the end of `date`.
+func printPortfolioByCurrency(currencies []string, date time.Time) {
+	c := newCoinbaseClient()
+
+	var holdings []holding
+	var nativeCurrency string
+	var err error
+	if date.IsZero() {
+		holdings, nativeCurrency, err = loadCoinbaseHoldings(c)
+	} else {
+		holdings, nativeCurrency, err = loadCoinbaseHoldingsAsOf(c, date)
+	}
+	errHandler(err)
+
+	rates, err := fx.Load(c, nativeCurrency, currencies, date)
+	errHandler(err)
+
+	table.DefaultHeaderFormatter = func(s string, i ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(s, i...))
+	}
+	headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
+
+	headers := []interface{}{"Asset", "Balance"}
+	var totals []money.Money
+	for _, cur := range currencies {
+		headers = append(headers, "Value "+strings.ToUpper(cur))
+		totals = append(totals, money.Zero(strings.ToUpper(cur)))
+	}
+	tbl := newTable(headers...).WithHeaderFormatter(headerFmt)
+
+	for _, h := range holdings {
+		row := []interface{}{h.Currency, fmtAmount(h.Amount, h.Currency)}
+		for i, cur := range currencies {
+			v, err := rates.Convert(h.Value(), cur)
+			errHandler(err)
+
+			row = append(row, fmtMoney(v))
+			totals[i] = totals[i].Add(v)
+		}
+		tbl.AddRow(row...)
+	}
+
+	if !date.IsZero() {
+		fmt.Println("Portfolio as of", date.Format("2006-01-02"))
+	}
+	tbl.Print()
+
+	fmt.Println()
+	for _, t := range totals {
+		rate, _ := rates.Rate(t.Currency)
+		fmt.Printf("Total Value %s: %s (1 %s = %s %s)\n", t.Currency, fmtMoney(t), nativeCurrency, rate.StringFixed(4), t.Currency)
+	}
+}
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/KalebHawkins/crypto-client/internal/userdata"
+	"github.com/KalebHawkins/crypto-client/money"
+	"github.com/fatih/color"
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+)
+
+// portfolioExposureCmd represents the portfolio exposure command
+var portfolioExposureCmd = &cobra.Command{
+	Use:   "exposure",
+	Short: "break down portfolio risk exposure.",
+	Long: `Break down your portfolio exposure by custody type, counterparty, asset category and asset.
+
+Any share above its concentration threshold is flagged with a warning. Asset categories come
+from a built-in list of well known assets and can be overridden by tagging an asset with
+category:<name>.
+
+Add --as-of to break down the holdings as they were at the end of a past date, valued at the
+prices of that date.
+
+	$ crypto-client tags add LINK category:oracle
+	$ crypto-client portfolio exposure --max-asset 40 --max-category 60
+	$ crypto-client portfolio exposure --as-of 2021-12-31
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		date, err := parseAsOf(exposureAsOf)
+		errHandler(err)
+
+		printExposure(date)
+	},
+}
+
+var maxAssetShare float64
+var maxCategoryShare float64
+var maxCounterpartyShare float64
+var exposureAsOf string
+
+func init() {
+	portfolioCmd.AddCommand(portfolioExposureCmd)
+	portfolioExposureCmd.Flags().Float64Var(&maxAssetShare, "max-asset", 50, "warn when a single asset exceeds this percentage of the portfolio")
+	portfolioExposureCmd.Flags().Float64Var(&maxCategoryShare, "max-category", 75, "warn when a single category exceeds this percentage of the portfolio")
+	portfolioExposureCmd.Flags().Float64Var(&maxCounterpartyShare, "max-counterparty", 100, "warn when a single counterparty exceeds this percentage of the portfolio")
+	portfolioExposureCmd.Flags().StringVar(&exposureAsOf, "as-of", "", "break down the holdings as of a date (YYYY-MM-DD)")
+}
+
+// assetCategories maps well known assets to a broad category.
+var assetCategories = map[string]string{
+	"BTC": "L1", "ETH": "L1", "SOL": "L1", "ADA": "L1", "AVAX": "L1", "DOT": "L1", "ATOM": "L1",
+	"ALGO": "L1", "XTZ": "L1", "LTC": "L1", "BCH": "L1", "ETC": "L1", "XLM": "L1", "NEAR": "L1",
+	"MATIC": "L2", "OP": "L2", "ARB": "L2",
+	"USDC": "stablecoin", "USDT": "stablecoin", "DAI": "stablecoin", "PAX": "stablecoin", "GUSD": "stablecoin",
+	"UNI": "DeFi", "AAVE": "DeFi", "COMP": "DeFi", "MKR": "DeFi", "SNX": "DeFi", "CRV": "DeFi",
+	"SUSHI": "DeFi", "YFI": "DeFi", "BAL": "DeFi", "1INCH": "DeFi",
+	"USD": "fiat", "EUR": "fiat", "GBP": "fiat",
+}
+
+// assetCategory returns the category of an asset, preferring a category:<name> tag over the built-in list.
+func assetCategory(notes *userdata.Data, currency string) string {
+	for _, t := range notes.Asset(currency).Tags {
+		if strings.HasPrefix(t, "category:") {
+			return strings.TrimPrefix(t, "category:")
+		}
+	}
+
+	if c, ok := assetCategories[strings.ToUpper(currency)]; ok {
+		return c
+	}
+
+	return "other"
+}
+
+// printExposure prints the exposure tables for the Coinbase holdings. A zero `date` uses the current holdings and
+// prices, otherwise the holdings and prices at the end of `date`.
+func printExposure(date time.Time) {
+	notes, err := userdata.Load()
+	errHandler(err)
+
+	c := newCoinbaseClient()
+	var holdings []holding
+	var nativeCurrency string
+	if date.IsZero() {
+		holdings, nativeCurrency, err = loadCoinbaseHoldings(c)
+	} else {
+		holdings, nativeCurrency, err = loadCoinbaseHoldingsAsOf(c, date)
+	}
+	errHandler(err)
+
+	total := money.Zero(nativeCurrency)
+	custody := map[string]money.Money{}
+	counterparty := map[string]money.Money{}
+	category := map[string]money.Money{}
+	asset := map[string]money.Money{}
+
+	for _, h := range holdings {
+		value := h.Value()
+		total = total.Add(value)
+		custody["exchange"] = custody["exchange"].Add(value)
+		counterparty["Coinbase"] = counterparty["Coinbase"].Add(value)
+		cat := assetCategory(notes, h.Currency)
+		category[cat] = category[cat].Add(value)
+		asset[h.Currency] = asset[h.Currency].Add(value)
+	}
+
+	if total.IsZero() {
+		fmt.Println("No holdings to report on.")
+		return
+	}
+
+	printExposureTable("Custody", custody, total, 100)
+	fmt.Println()
+	printExposureTable("Counterparty", counterparty, total, maxCounterpartyShare)
+	fmt.Println()
+	printExposureTable("Category", category, total, maxCategoryShare)
+	fmt.Println()
+	printExposureTable("Asset", asset, total, maxAssetShare)
+}
+
+// printExposureTable prints the share of `total` held by each key of `values`, largest first, flagging shares
+// above `threshold` percent.
+func printExposureTable(title string, values map[string]money.Money, total money.Money, threshold float64) {
+	table.DefaultHeaderFormatter = func(s string, i ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(s, i...))
+	}
+	headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
+	warnFmt := color.New(color.FgRed).SprintFunc()
+	tbl := newTable(title, "Value", "Share", "").WithHeaderFormatter(headerFmt)
+
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return values[keys[i]].Cmp(values[keys[j]]) > 0 })
+
+	for _, k := range keys {
+		share := values[k].Div(total.Amount).Float64() * 100
+
+		var warning string
+		if share > threshold {
+			warning = warnFmt(fmt.Sprintf("above %.0f%% threshold", threshold))
+		}
+
+		tbl.AddRow(k, fmtMoney(values[k]), fmt.Sprintf("%.1f%%", share), warning)
+	}
+
+	tbl.Print()
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPrintExposureAsOf(t *testing.T) {
+	local := time.Local
+	time.Local = time.FixedZone("UTC-5", -5*60*60)
+	t.Cleanup(func() { time.Local = local })
+
+	tests := []struct {
+		asOf string
+		want []string
+	}{
+		{asOf: "", want: []string{"exchange  32030.00 USD", "USD    1000.00 USD"}},
+		{asOf: "2020-12-31", want: []string{"exchange  15000.00 USD", "BTC    15000.00 USD  100.0%"}},
+		{asOf: "2021-01-31", want: []string{"exchange  21000.00 USD", "ETH    6000.00 USD"}},
+		// The ETH reward of 2021-06-01 00:00 UTC was received on May 31 in local time.
+		{asOf: "2021-05-31", want: []string{"exchange  31030.00 USD", "ETH    6030.00 USD"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.asOf, func(t *testing.T) {
+			useServer(t)
+			date, err := parseAsOf(tt.asOf)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			out := run(t, "", func() { printExposure(date) })
+			for _, want := range tt.want {
+				if !strings.Contains(out, want) {
+					t.Errorf("output is missing %q:\n%s", want, out)
+				}
+			}
+		})
+	}
+}
+
+func TestParseAsOf(t *testing.T) {
+	d, err := parseAsOf("2021-12-31")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2021, time.December, 31, 0, 0, 0, 0, time.Local); !d.Equal(want) {
+		t.Errorf("got %s, want %s", d, want)
+	}
+
+	if _, err := parseAsOf("12/31/2021"); err == nil {
+		t.Error("expected an error")
+	}
+}
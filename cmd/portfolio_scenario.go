@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/KalebHawkins/crypto-client/money"
+	"github.com/fatih/color"
+	"github.com/rodaine/table"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+)
+
+// portfolioScenarioCmd represents the portfolio scenario command
+var portfolioScenarioCmd = &cobra.Command{
+	Use:   "scenario",
+	Short: "model your portfolio under hypothetical prices.",
+	Long: `Model your portfolio under hypothetical prices.
+
+Your current holdings are revalued using the prices given with --set. Assets without a
+hypothetical price keep their current spot price. Prices are in your native currency.
+
+	$ crypto-client portfolio scenario --set BTC=100000,ETH=8000
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		prices := map[string]decimal.Decimal{}
+		for asset, price := range scenarioPrices {
+			p, err := decimal.NewFromString(price)
+			if err != nil {
+				errHandler(fmt.Errorf("invalid price %q for %s: %v", price, asset, err))
+			}
+			prices[strings.ToUpper(asset)] = p
+		}
+
+		runScenario(prices)
+	},
+}
+
+var scenarioPrices map[string]string
+
+func init() {
+	portfolioCmd.AddCommand(portfolioScenarioCmd)
+	portfolioScenarioCmd.Flags().StringToStringVar(&scenarioPrices, "set", nil, "hypothetical prices, for example BTC=100000,ETH=8000")
+	portfolioScenarioCmd.MarkFlagRequired("set")
+}
+
+// runScenario prints the current and hypothetical value and gain of every holding.
+func runScenario(prices map[string]decimal.Decimal) {
+	c := newCoinbaseClient()
+	holdings, nativeCurrency, err := loadCoinbaseHoldings(c)
+	errHandler(err)
+
+	table.DefaultHeaderFormatter = func(s string, i ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(s, i...))
+	}
+	headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
+	tbl := newTable("Asset", "Balance", "Spot Price", "Scenario Price", "Current Value",
+		"Scenario Value", "Invested", "Scenario Gain").WithHeaderFormatter(headerFmt)
+
+	totalCurrent := money.Zero(nativeCurrency)
+	totalScenario := money.Zero(nativeCurrency)
+	totalInvested := money.Zero(nativeCurrency)
+
+	for _, h := range holdings {
+		price := h.Spot
+		if p, ok := prices[h.Currency]; ok {
+			price = money.New(p, nativeCurrency)
+		}
+
+		current := h.Value()
+		scenario := price.Mul(h.Amount)
+
+		tbl.AddRow(h.Currency, fmtAmount(h.Amount, h.Currency),
+			fmtMoney(h.Spot),
+			fmtMoney(price),
+			fmtMoney(current),
+			fmtMoney(scenario),
+			fmtMoney(h.Invested),
+			fmtMoney(scenario.Sub(h.Invested)))
+
+		totalCurrent = totalCurrent.Add(current)
+		totalScenario = totalScenario.Add(scenario)
+		totalInvested = totalInvested.Add(h.Invested)
+	}
+
+	tbl.Print()
+
+	fmt.Println()
+	fmt.Printf("Current Value: %s\n", fmtMoney(totalCurrent))
+	fmt.Printf("Scenario Value: %s\n", fmtMoney(totalScenario))
+	fmt.Printf("Change: %s\n", fmtMoney(totalScenario.Sub(totalCurrent)))
+	fmt.Printf("Scenario Gain Over Invested: %s\n", fmtMoney(totalScenario.Sub(totalInvested)))
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/KalebHawkins/crypto-client/coinbasetest"
+	"github.com/shopspring/decimal"
+)
+
+func TestLoadCoinbaseHoldingsEarlierNativeCurrency(t *testing.T) {
+	srv := useServer(t)
+	srv.SetPrice("BTC-EUR", decimal.NewFromInt(40000))
+	srv.Handle(http.MethodGet, "/v2/accounts/"+coinbasetest.BTCAccountID+"/transactions", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"pagination": {}, "data": [
+			{"id": "1", "type": "buy", "status": "completed", "amount": {"amount": "0.1", "currency": "BTC"},
+			 "native_amount": {"amount": "1000.00", "currency": "EUR"}, "created_at": "2019-01-01T12:00:00Z"},
+			{"id": "2", "type": "buy", "status": "completed", "amount": {"amount": "0.4", "currency": "BTC"},
+			 "native_amount": {"amount": "8000.00", "currency": "USD"}, "created_at": "2020-01-01T12:00:00Z"}
+		]}`))
+	})
+
+	holdings, native, err := loadCoinbaseHoldings(newCoinbaseClient())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if native != "USD" {
+		t.Fatalf("native currency = %s, want USD", native)
+	}
+
+	for _, h := range holdings {
+		if h.Currency != "BTC" {
+			continue
+		}
+		if h.Invested.Currency != "USD" || !h.Invested.Amount.Equal(decimal.NewFromInt(9250)) {
+			t.Errorf("invested = %s, want 9250 USD", h.Invested)
+		}
+		return
+	}
+	t.Error("no BTC holding")
+}
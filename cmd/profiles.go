@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/KalebHawkins/crypto-client/coinbase"
+	"github.com/KalebHawkins/crypto-client/internal/credentials"
+	"github.com/KalebHawkins/crypto-client/internal/userdata"
+	"github.com/fatih/color"
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// profilesCmd represents the profiles command
+var profilesCmd = &cobra.Command{
+	Use:   "profiles",
+	Short: "manage credential profiles for several accounts.",
+	Long: `Manage named credential profiles, for example one per Coinbase account.
+
+Every profile has its own Coinbase API key in the OS keyring, its own OAuth token and optionally its
+own credentials in the profiles section of the config file. Select a profile with --profile on any
+command, the credential environment variables are then ignored.
+
+	profiles:
+	  work:
+	    coinbase:
+	      key: "api_key"
+	      secret: "api_secret"
+
+Running this command without a subcommand lists the profiles.
+
+	$ crypto-client profiles add work
+	$ crypto-client auth login --profile personal
+	$ crypto-client coinbase --profile work
+	$ crypto-client profiles remove work
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		listProfiles()
+	},
+}
+
+// profilesListCmd represents the profiles list command
+var profilesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list the profiles and the credentials stored for them.",
+
+	Run: func(cmd *cobra.Command, args []string) {
+		listProfiles()
+	},
+}
+
+// profilesAddCmd represents the profiles add command
+var profilesAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "add a profile and store its Coinbase API key in the OS keyring.",
+	Long: `Add a profile and prompt for the Coinbase API key and secret it uses, which are stored in the
+OS keyring. Use --skip-key to only add the profile, for example to sign in with OAuth afterwards
+using "auth login --profile <name>" or to keep its credentials in the config file.
+`,
+	Args: cobra.ExactArgs(1),
+
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+		if !userdata.ProfileName.MatchString(name) {
+			errHandler(fmt.Errorf("%q is not a valid profile name, use letters, digits, - and _", name))
+		}
+
+		if !profileSkipKey {
+			errHandler(storeAPIKey("coinbase", name))
+		}
+
+		d, err := userdata.Load()
+		errHandler(err)
+		d.AddProfile(name)
+		errHandler(d.Save())
+
+		fmt.Printf("Added profile %s, use it with --profile %s.\n", name, name)
+	},
+}
+
+// profilesRemoveCmd represents the profiles remove command
+var profilesRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "remove a profile and the credentials stored for it.",
+	Args:  cobra.ExactArgs(1),
+
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+
+		d, err := userdata.Load()
+		errHandler(err)
+		if !d.RemoveProfile(name) {
+			errHandler(fmt.Errorf("there is no profile %q", name))
+		}
+
+		if err := coinbase.DeleteKeyring(name); err != nil {
+			fmt.Fprintln(os.Stderr, "warning: removing the API key from the OS keyring:", err)
+		}
+		if err := credentials.DeleteToken(name); err != nil {
+			fmt.Fprintln(os.Stderr, "warning: removing the OAuth token from the OS keyring:", err)
+		}
+		errHandler(d.Save())
+
+		fmt.Printf("Removed profile %s. Credentials in the profiles section of the config file are left alone.\n", name)
+	},
+}
+
+var profile string
+var profileSkipKey bool
+
+// configProfiles holds the names of the profiles in the config file, see checkProfile().
+var configProfiles = map[string]bool{}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&profile, "profile", "", "the credential profile to use, see crypto-client profiles -h")
+	rootCmd.AddCommand(profilesCmd)
+	profilesCmd.AddCommand(profilesListCmd)
+	profilesCmd.AddCommand(profilesAddCmd)
+	profilesCmd.AddCommand(profilesRemoveCmd)
+	profilesAddCmd.Flags().BoolVar(&profileSkipKey, "skip-key", false, "add the profile without storing an API key")
+}
+
+// checkProfile returns an error if --profile names a profile that was neither added nor configured in the config
+// file `v`.
+func checkProfile(v *viper.Viper) error {
+	for name := range v.GetStringMap("profiles") {
+		configProfiles[name] = true
+	}
+
+	if profile == "" {
+		return nil
+	}
+
+	d, err := userdata.Load()
+	if err != nil {
+		return err
+	}
+
+	if !d.HasProfile(profile) && !v.IsSet("profiles."+profile) {
+		return fmt.Errorf("unknown profile %q, add it with `crypto-client profiles add %s`", profile, profile)
+	}
+
+	return nil
+}
+
+// listProfiles prints every profile with the credentials stored for it. The default profile is listed first.
+func listProfiles() {
+	d, err := userdata.Load()
+	errHandler(err)
+
+	names := append([]string{}, d.Profiles...)
+	for p := range configProfiles {
+		if !d.HasProfile(p) {
+			names = append(names, p)
+		}
+	}
+	sort.Strings(names)
+	names = append([]string{""}, names...)
+
+	table.DefaultHeaderFormatter = func(s string, i ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(s, i...))
+	}
+	headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
+	tbl := newTable("Profile", "Active", "Keyring API Key", "OAuth Token", "Config File").WithHeaderFormatter(headerFmt)
+
+	for _, name := range names {
+		label := name
+		if label == "" {
+			label = "(default)"
+		}
+
+		inConfig := "n/a"
+		if name != "" {
+			inConfig = yesNo(configProfiles[name])
+		}
+
+		tbl.AddRow(label, yesNo(name == profile), keyringStatus(name), tokenStatus(name), inConfig)
+	}
+
+	tbl.Print()
+}
+
+// keyringStatus reports whether a Coinbase API key is stored in the OS keyring for `name`.
+func keyringStatus(name string) string {
+	_, err := coinbase.KeyringClient(name)
+	switch {
+	case errors.Is(err, coinbase.ErrNoKeyringCredentials):
+		return "no"
+	case err != nil:
+		return "unavailable"
+	}
+
+	return "yes"
+}
+
+// tokenStatus reports whether an OAuth token is stored for `name`.
+func tokenStatus(name string) string {
+	t, err := credentials.LoadToken(name)
+	if err != nil {
+		return "unreadable"
+	}
+
+	return yesNo(t.AccessToken != "")
+}
+
+// yesNo formats a boolean for a table.
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+
+	return "no"
+}
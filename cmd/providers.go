@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/KalebHawkins/crypto-client/binance"
+	"github.com/KalebHawkins/crypto-client/coinbase"
+	"github.com/KalebHawkins/crypto-client/exchange"
+	"github.com/KalebHawkins/crypto-client/gemini"
+	"github.com/KalebHawkins/crypto-client/internal/credentials"
+	"github.com/KalebHawkins/crypto-client/kraken"
+	"github.com/KalebHawkins/crypto-client/kucoin"
+	"github.com/fatih/color"
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+)
+
+// providerCredentials lists the environment variables each provider needs, all of which have to be set together.
+// Providers with an open function can be used through the exchange.Provider interface.
+var providerCredentials = []struct {
+	provider string
+	env      []string
+	open     func() exchange.Provider
+}{
+	{"Coinbase", []string{"COINBASE_KEY", "COINBASE_SECRET"}, func() exchange.Provider {
+		return coinbase.AsProvider(newCoinbaseClient())
+	}},
+	{"Coinbase OAuth", []string{"COINBASE_CLIENT_ID", "COINBASE_CLIENT_SECRET"}, nil},
+	{"Coinbase Advanced Trade", []string{"COINBASE_ADVANCED_KEY_NAME", "COINBASE_ADVANCED_PRIVATE_KEY"}, nil},
+	{"Coinbase Exchange", []string{"COINBASE_EXCHANGE_KEY", "COINBASE_EXCHANGE_SECRET", "COINBASE_EXCHANGE_PASSPHRASE"}, func() exchange.Provider {
+		return newCoinbaseExchangeClient()
+	}},
+	{"Kraken", []string{"KRAKEN_KEY", "KRAKEN_SECRET"}, func() exchange.Provider {
+		return kraken.APIKeyClient()
+	}},
+	{"Binance", []string{"BINANCE_KEY", "BINANCE_SECRET"}, func() exchange.Provider {
+		return binance.APIKeyClient()
+	}},
+	{"Gemini", []string{"GEMINI_KEY", "GEMINI_SECRET"}, func() exchange.Provider {
+		return gemini.APIKeyClient()
+	}},
+	{"KuCoin", []string{"KUCOIN_KEY", "KUCOIN_SECRET", "KUCOIN_PASSPHRASE"}, func() exchange.Provider {
+		return kucoin.APIKeyClient()
+	}},
+}
+
+// providersCmd represents the providers command
+var providersCmd = &cobra.Command{
+	Use:   "providers",
+	Short: "list the providers, whether they are configured and what crypto-client supports for them.",
+	Long: `List every provider, whether its credentials are configured and the features crypto-client
+supports for it beyond balances, transactions and prices. Use --supports to only list the
+providers with a feature.
+
+	$ crypto-client providers
+	$ crypto-client providers --supports orders
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		if providersSupports != "" && !isCapability(exchange.Capability(providersSupports)) {
+			errHandler(fmt.Errorf("unknown feature %q, use one of %s", providersSupports,
+				exchange.Capabilities(exchange.AllCapabilities)))
+		}
+
+		printProviders(exchange.Capability(providersSupports))
+	},
+}
+
+var providersSupports string
+
+func init() {
+	rootCmd.AddCommand(providersCmd)
+	providersCmd.Flags().StringVar(&providersSupports, "supports", "", "only list providers supporting this feature")
+}
+
+// configuredProviders returns every provider whose credentials are set in the environment. Coinbase is also
+// configured when signed in with `auth login` or when its API key is stored in the OS keyring. Assets are reported
+// under their current tickers, see assetMap().
+func configuredProviders() []exchange.Provider {
+	var providers []exchange.Provider
+	for _, p := range providerCredentials {
+		if p.open != nil && providerConfigured(p.provider) {
+			providers = append(providers, exchange.WithAssetMap(p.open(), assetMap()))
+		}
+	}
+
+	return providers
+}
+
+// providerConfigured reports whether the credentials of `name`, as listed in providerCredentials, are set.
+func providerConfigured(name string) bool {
+	for _, p := range providerCredentials {
+		if p.provider != name {
+			continue
+		}
+
+		configured := true
+		for _, e := range p.env {
+			if os.Getenv(e) == "" {
+				configured = false
+			}
+		}
+
+		if !configured && p.provider == "Coinbase" {
+			t, err := credentials.LoadToken(profile)
+			configured = err == nil && t.AccessToken != ""
+			if !configured {
+				_, err = coinbase.KeyringClient(profile)
+				configured = err == nil
+			}
+		}
+
+		return configured
+	}
+
+	return false
+}
+
+// providerByName returns the provider whose name, lower cased with dashes for spaces, is `name`, for example
+// "coinbase-exchange". It is opened whether or not its credentials are set.
+func providerByName(name string) (exchange.Provider, bool) {
+	for _, p := range providerCredentials {
+		if p.open != nil && strings.ReplaceAll(strings.ToLower(p.provider), " ", "-") == strings.ToLower(name) {
+			return p.open(), true
+		}
+	}
+
+	return nil, false
+}
+
+// isCapability reports whether `c` is a known capability.
+func isCapability(c exchange.Capability) bool {
+	return exchange.Capabilities(exchange.AllCapabilities).Has(c)
+}
+
+// printProviders prints every provider with a yes or no for each capability. With `supports` only the providers
+// having that capability are listed.
+func printProviders(supports exchange.Capability) {
+	table.DefaultHeaderFormatter = func(s string, i ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(s, i...))
+	}
+	headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
+
+	headers := []interface{}{"Provider", "Configured"}
+	for _, c := range exchange.AllCapabilities {
+		headers = append(headers, string(c))
+	}
+	tbl := newTable(headers...).WithHeaderFormatter(headerFmt)
+
+	for _, p := range providerCredentials {
+		if p.open == nil {
+			continue
+		}
+
+		caps := p.open().Capabilities()
+		if supports != "" && !caps.Has(supports) {
+			continue
+		}
+
+		row := []interface{}{p.provider, yesNo(providerConfigured(p.provider))}
+		for _, c := range exchange.AllCapabilities {
+			row = append(row, yesNo(caps.Has(c)))
+		}
+		tbl.AddRow(row...)
+	}
+
+	tbl.Print()
+}
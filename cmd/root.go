@@ -1,6 +1,14 @@
 package cmd
 
 import (
+	"log/slog"
+	"math"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/KalebHawkins/crypto-client/coinbase"
+	"github.com/KalebHawkins/crypto-client/internal/credentials"
 	"github.com/spf13/cobra"
 )
 
@@ -17,13 +25,34 @@ The example below will output the help message for interacting with the Coinbase
 
 Supported APIs are listed in the table below.
 
-	╔══════════╤══════════════════╗
-	║ Provider │ Supported        ║
-	╠══════════╪══════════════════╣
-	║ Coinbase │ partial          ║
-	╟──────────┼──────────────────╢
-	║ Celsius  │ TBD              ║
-	╚══════════╧══════════════════╝
+	╔═══════════════════╤══════════════════╗
+	║ Provider          │ Supported        ║
+	╠═══════════════════╪══════════════════╣
+	║ Coinbase          │ partial          ║
+	╟───────────────────┼──────────────────╢
+	║ Coinbase Exchange │ partial          ║
+	╟───────────────────┼──────────────────╢
+	║ Kraken            │ partial          ║
+	╟───────────────────┼──────────────────╢
+	║ Binance           │ partial          ║
+	╟───────────────────┼──────────────────╢
+	║ Gemini            │ partial          ║
+	╟───────────────────┼──────────────────╢
+	║ KuCoin            │ partial          ║
+	╚═══════════════════╧══════════════════╝
+
+Credentials and defaults can be kept in ~/.config/crypto-client/config.yaml, or the file given
+with --config, instead of environment variables. Environment variables and flags take precedence.
+
+	output: table
+	currency: EUR
+	rounding: half-even
+	places:
+	  ETH: 8
+	kraken:
+	  key: "api_key"
+	  secret: "api_secret"
+	  currency: USD
 
 Please note that if the vendor makes breaking changes to their API it could break the cypto-client cli.
 `,
@@ -33,6 +62,65 @@ Please note that if the vendor makes breaking changes to their API it could brea
 	},
 }
 
+// newCoinbaseClient creates the Coinbase client used by every command. The OAuth token stored by `auth login` is
+// used when there is one, otherwise the API key set in the environment or, failing that, the one stored in the OS
+// keyring by `auth login coinbase`. With --cache the history is kept in the local store and with --currency the
+// amounts are shown in another currency. It can be replaced to run the commands against a coinbasetest.Server or a
+// fake coinbase.Client. With --offline only the store is used.
+var newCoinbaseClient = func() coinbase.Client {
+	if offline {
+		return withCurrency(newOfflineClient())
+	}
+
+	t, err := credentials.LoadToken(profile)
+	errHandler(err)
+
+	if t.AccessToken != "" {
+		return withCurrency(withStore(coinbase.OAuthClient(t, append(coinbaseOptions(), coinbase.WithOAuthConfig(oauthConfig()))...)))
+	}
+
+	if os.Getenv("COINBASE_KEY") == "" {
+		if c, err := coinbase.KeyringClient(profile, coinbaseOptions()...); err == nil {
+			return withCurrency(withStore(c))
+		}
+	}
+
+	return withCurrency(withStore(coinbase.APIKeyClient(coinbaseOptions()...)))
+}
+
+// coinbaseOptions returns the client options selected by the global flags. Every response is counted for the
+// scheduling of --watch. The rate limit and the cached prices are shared by the goroutines of a command as they
+// use the same client.
+func coinbaseOptions() []coinbase.Option {
+	hook := func(method string, path string, status int, body []byte) {
+		atomic.AddUint64(&apiRequests, 1)
+		if dumpRawDir != "" {
+			dumpRawResponse(method, path, status, body)
+		}
+	}
+
+	return []coinbase.Option{
+		coinbase.WithResponseHook(hook),
+		coinbase.WithRateLimit(rateLimit, int(math.Max(rateLimit, 1))),
+		coinbase.WithResponseCache(priceTTL),
+		coinbase.WithLogger(slog.Default()),
+		coinbase.WithTimeout(requestTimeout),
+	}
+}
+
+var dumpRawDir string
+var rateLimit float64
+var priceTTL time.Duration
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&dumpRawDir, "dump-raw", "", "write the raw response of every API call to timestamped files in this directory")
+	rootCmd.PersistentFlags().Float64Var(&rateLimit, "rate-limit", 10, "the most Coinbase API requests per second, 0 disables the limit")
+	rootCmd.PersistentFlags().DurationVar(&priceTTL, "price-ttl", 10*time.Second, "how long price and exchange rate lookups are reused, 0 looks them up every time")
+
+	// The config file is applied first as it may set the flags read by the other initializers.
+	cobra.OnInitialize(loadConfig, initLogging, initAccessible, initOutput, initFormat, initNetwork, initFaults, initDebugHTTP)
+}
+
 func Execute() {
 	cobra.CheckErr(rootCmd.Execute())
 }
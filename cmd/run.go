@@ -0,0 +1,253 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// runCmd represents the run command
+var runCmd = &cobra.Command{
+	Use:   "run <plan.yaml>",
+	Short: "run the steps of a plan file.",
+	Long: `Run the crypto-client commands listed in a plan file one after the other and summarize the results.
+
+Every step runs a crypto-client command line. A failing step is run again up to retries times,
+after which its on_error policy decides what happens: abort (the default) stops the plan and
+continue moves on to the next step. A step listing other steps under requires is skipped unless
+all of them succeeded, which allows a sell to only run once an overview succeeded.
+
+	steps:
+	  - name: overview
+	    run: coinbase
+	    on_error: continue
+	  - name: limits
+	    run: coinbase limits
+	    retries: 2
+	  - name: take profit
+	    run: [coinbase, sell, --asset, BTC, --amount, "0.001", --yes]
+	    requires: [overview, limits]
+	    timeout: 2m
+
+	$ crypto-client run plan.yaml
+	$ crypto-client run plan.yaml --dry-run
+`,
+	Args: cobra.ExactArgs(1),
+
+	Run: func(cmd *cobra.Command, args []string) {
+		p, err := loadPlan(args[0])
+		errHandler(err)
+
+		if !runPlan(p) {
+			os.Exit(1)
+		}
+	},
+}
+
+var runDryRun bool
+
+func init() {
+	rootCmd.AddCommand(runCmd)
+	runCmd.Flags().BoolVar(&runDryRun, "dry-run", false, "print the steps without running them")
+}
+
+// These constants are the error policies of a plan step.
+const (
+	onErrorAbort    = "abort"
+	onErrorContinue = "continue"
+)
+
+// plan is a list of crypto-client commands read from a plan file.
+type plan struct {
+	Steps []planStep `yaml:"steps"`
+}
+
+// planStep is a single command of a plan.
+type planStep struct {
+	Name     string        `yaml:"name"`
+	Run      planArgs      `yaml:"run"`
+	OnError  string        `yaml:"on_error"`
+	Retries  int           `yaml:"retries"`
+	Requires []string      `yaml:"requires"`
+	Timeout  time.Duration `yaml:"timeout"`
+}
+
+// planArgs are the arguments of a step. They are written either as a list or as a single string that is split on
+// white space.
+type planArgs []string
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (a *planArgs) UnmarshalYAML(n *yaml.Node) error {
+	if n.Kind == yaml.ScalarNode {
+		*a = strings.Fields(n.Value)
+		return nil
+	}
+
+	var args []string
+	if err := n.Decode(&args); err != nil {
+		return err
+	}
+	*a = args
+
+	return nil
+}
+
+// loadPlan reads and validates a plan file.
+func loadPlan(path string) (plan, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return plan{}, err
+	}
+
+	var p plan
+	if err := yaml.Unmarshal(b, &p); err != nil {
+		return plan{}, fmt.Errorf("invalid plan %s: %v", path, err)
+	}
+
+	seen := map[string]bool{}
+	for i := range p.Steps {
+		s := &p.Steps[i]
+		if s.Name == "" {
+			s.Name = fmt.Sprintf("step %d", i+1)
+		}
+		if s.Retries < 0 {
+			return plan{}, fmt.Errorf("invalid plan %s: %s has negative retries", path, s.Name)
+		}
+		if len(s.Run) == 0 {
+			return plan{}, fmt.Errorf("invalid plan %s: %s has nothing to run", path, s.Name)
+		}
+		if s.Run[0] == "run" {
+			return plan{}, fmt.Errorf("invalid plan %s: %s cannot run another plan", path, s.Name)
+		}
+
+		switch s.OnError {
+		case "":
+			s.OnError = onErrorAbort
+		case onErrorAbort, onErrorContinue:
+		default:
+			return plan{}, fmt.Errorf("invalid plan %s: %s has unknown on_error policy %q", path, s.Name, s.OnError)
+		}
+
+		for _, r := range s.Requires {
+			if !seen[r] {
+				return plan{}, fmt.Errorf("invalid plan %s: %s requires %q which is not an earlier step", path, s.Name, r)
+			}
+		}
+		seen[s.Name] = true
+	}
+
+	return p, nil
+}
+
+// runPlan runs the steps of `p`, prints a summary and reports whether every step succeeded. Each step runs in its
+// own crypto-client process so a failing command cannot take the plan down with it.
+func runPlan(p plan) bool {
+	exe, err := os.Executable()
+	errHandler(err)
+
+	table.DefaultHeaderFormatter = func(s string, i ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(s, i...))
+	}
+	headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
+	tbl := newTable("Step", "Status", "Attempts", "Duration", "Error").WithHeaderFormatter(headerFmt)
+
+	ok := true
+	aborted := false
+	succeeded := map[string]bool{}
+
+	for _, s := range p.Steps {
+		if aborted {
+			tbl.AddRow(s.Name, "not run", 0, "", "")
+			continue
+		}
+
+		if missing := unmetRequirements(s, succeeded); len(missing) > 0 {
+			tbl.AddRow(s.Name, "skipped", 0, "", "requires "+strings.Join(missing, ", "))
+			continue
+		}
+
+		color.Cyan("==> %s: crypto-client %s", s.Name, strings.Join(s.Run, " "))
+		if runDryRun {
+			succeeded[s.Name] = true
+			tbl.AddRow(s.Name, "dry run", 0, "", "")
+			continue
+		}
+
+		start := time.Now()
+		attempts := 0
+		for {
+			attempts++
+			err = runStep(exe, s)
+			if err == nil || attempts > s.Retries {
+				break
+			}
+			color.Yellow("==> %s failed: %v, retrying", s.Name, err)
+		}
+		elapsed := time.Since(start).Round(time.Millisecond)
+
+		if err == nil {
+			succeeded[s.Name] = true
+			tbl.AddRow(s.Name, "ok", attempts, elapsed, "")
+			continue
+		}
+
+		ok = false
+		tbl.AddRow(s.Name, "failed", attempts, elapsed, err)
+		if s.OnError == onErrorAbort {
+			aborted = true
+		}
+	}
+
+	fmt.Println()
+	tbl.Print()
+
+	return ok
+}
+
+// runStep runs the command of a step with the global flags of this invocation.
+func runStep(exe string, s planStep) error {
+	ctx := context.Background()
+	if s.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.Timeout)
+		defer cancel()
+	}
+
+	args := append([]string{}, s.Run...)
+	if dumpRawDir != "" {
+		args = append(args, "--dump-raw", dumpRawDir)
+	}
+
+	c := exec.CommandContext(ctx, exe, args...)
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+
+	err := c.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("timed out after %s", s.Timeout)
+	}
+
+	return err
+}
+
+// unmetRequirements returns the steps required by `s` that did not succeed.
+func unmetRequirements(s planStep, succeeded map[string]bool) []string {
+	var missing []string
+	for _, r := range s.Requires {
+		if !succeeded[r] {
+			missing = append(missing, r)
+		}
+	}
+
+	return missing
+}
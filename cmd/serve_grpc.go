@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+
+	"github.com/KalebHawkins/crypto-client/internal/userdata"
+	"github.com/KalebHawkins/crypto-client/rpc"
+	"github.com/KalebHawkins/crypto-client/rpc/portfoliopb"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+)
+
+// serveGRPCCmd represents the serve-grpc command
+var serveGRPCCmd = &cobra.Command{
+	Use:   "serve-grpc",
+	Short: "serve your Coinbase portfolio, prices and transactions over gRPC.",
+	Long: `Run a gRPC server exposing the Coinbase portfolio, prices and transactions to other services,
+including a streaming RPC with live prices from the Coinbase WebSocket feed.
+
+The service is cryptoclient.v1.PortfolioService, defined in rpc/portfoliopb/portfolio.proto. Go
+services can use the generated client in the rpc/portfoliopb package, other languages generate
+their own from the proto file. Ignored wallets are left out.
+
+The server speaks plain text gRPC. Listen on localhost or put it behind a service mesh or proxy
+that terminates TLS, as every caller can read the portfolio.
+
+	$ crypto-client serve-grpc --listen localhost:9110
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		lis, err := net.Listen("tcp", grpcListen)
+		errHandler(err)
+
+		s := rpc.NewServer(newCoinbaseClient())
+		s.Tracked = func(w rpc.Wallet) bool {
+			d, err := userdata.Load()
+			return err != nil || !d.IsIgnored(w.ID, w.Name)
+		}
+		s.Imported = importedEvents
+		s.Assets = assetMap()
+
+		gs := grpc.NewServer()
+		portfoliopb.RegisterPortfolioServiceServer(gs, s)
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		go func() {
+			<-ctx.Done()
+			gs.GracefulStop()
+		}()
+
+		fmt.Printf("Serving gRPC on %s\n", lis.Addr())
+		errHandler(gs.Serve(lis))
+	},
+}
+
+var grpcListen string
+
+func init() {
+	rootCmd.AddCommand(serveGRPCCmd)
+	serveGRPCCmd.Flags().StringVar(&grpcListen, "listen", "localhost:9110", "the address to listen on")
+}
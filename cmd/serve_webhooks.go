@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/KalebHawkins/crypto-client/coinbase/notifications"
+	"github.com/spf13/cobra"
+)
+
+// serveWebhooksCmd represents the serve-webhooks command
+var serveWebhooksCmd = &cobra.Command{
+	Use:   "serve-webhooks",
+	Short: "receive Coinbase webhook notifications.",
+	Long: `Run an HTTP listener that receives Coinbase webhook notifications.
+
+Every notification is verified against the Coinbase public key given with --public-key before it
+is printed. Notifications that fail verification are rejected. Download the public key from the
+Coinbase API documentation and configure the notification URL of your API key or OAuth
+application to point at this listener, usually through a reverse proxy that terminates TLS.
+
+	$ crypto-client serve-webhooks --public-key coinbase.pub --listen :8080 --path /webhooks/coinbase
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		key, err := ioutil.ReadFile(webhookPublicKey)
+		errHandler(err)
+
+		v, err := notifications.NewVerifier(key)
+		errHandler(err)
+
+		h := notifications.NewHandler(v)
+		h.On(notifications.NewPayment, printPayment)
+		h.OnAny(printNotification)
+
+		mux := http.NewServeMux()
+		mux.Handle(webhookPath, h)
+
+		fmt.Printf("Listening for Coinbase notifications on %s%s\n", webhookListen, webhookPath)
+		errHandler(http.ListenAndServe(webhookListen, mux))
+	},
+}
+
+var webhookListen string
+var webhookPath string
+var webhookPublicKey string
+
+func init() {
+	rootCmd.AddCommand(serveWebhooksCmd)
+	serveWebhooksCmd.Flags().StringVar(&webhookListen, "listen", ":8080", "the address to listen on")
+	serveWebhooksCmd.Flags().StringVar(&webhookPath, "path", "/", "the URL path notifications are posted to")
+	serveWebhooksCmd.Flags().StringVar(&webhookPublicKey, "public-key", "", "PEM file holding the Coinbase webhook public key")
+	serveWebhooksCmd.MarkFlagRequired("public-key")
+}
+
+// printNotification prints a one line summary of a notification.
+func printNotification(n notifications.Notification) {
+	fmt.Printf("%s  %-32s  account %s  attempt %d  %s\n",
+		n.CreatedAt.Local().Format("2006-01-02 15:04:05"), n.Type, n.Account.ID, n.DeliveryAttempts, n.ID)
+}
+
+// printPayment prints the payment received by an address.
+func printPayment(n notifications.Notification) {
+	p, err := n.Payment()
+	if err != nil {
+		fmt.Println("invalid payment notification:", err)
+		return
+	}
+
+	fmt.Printf("Received %s, transaction %s, hash %s\n", p.Amount, p.Transaction.ID, p.Hash)
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"github.com/KalebHawkins/crypto-client/internal/userdata"
+	"github.com/spf13/cobra"
+)
+
+// tagsCmd represents the tags command
+var tagsCmd = &cobra.Command{
+	Use:   "tags",
+	Short: "tag assets and transactions.",
+	Long: `Tag assets and transactions so list commands can be filtered with --tag.
+
+Tags are stored locally in the crypto-client configuration directory. Use --transaction
+to tag a transaction ID instead of an asset.
+
+	$ crypto-client tags add BTC long-term cold-storage
+	$ crypto-client tags remove BTC cold-storage
+	$ crypto-client coinbase --list-accounts --tag long-term
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+// tagsAddCmd represents the tags add command
+var tagsAddCmd = &cobra.Command{
+	Use:   "add <asset|transaction-id> <tag>...",
+	Short: "add tags to an asset or transaction.",
+	Args:  cobra.MinimumNArgs(2),
+
+	Run: func(cmd *cobra.Command, args []string) {
+		d, err := userdata.Load()
+		errHandler(err)
+
+		if tagTransaction {
+			d.TagTransaction(args[0], args[1:]...)
+		} else {
+			d.TagAsset(args[0], args[1:]...)
+		}
+
+		errHandler(d.Save())
+	},
+}
+
+// tagsRemoveCmd represents the tags remove command
+var tagsRemoveCmd = &cobra.Command{
+	Use:   "remove <asset|transaction-id> <tag>...",
+	Short: "remove tags from an asset or transaction.",
+	Args:  cobra.MinimumNArgs(2),
+
+	Run: func(cmd *cobra.Command, args []string) {
+		d, err := userdata.Load()
+		errHandler(err)
+
+		if tagTransaction {
+			d.UntagTransaction(args[0], args[1:]...)
+		} else {
+			d.UntagAsset(args[0], args[1:]...)
+		}
+
+		errHandler(d.Save())
+	},
+}
+
+var tagTransaction bool
+
+func init() {
+	rootCmd.AddCommand(tagsCmd)
+	tagsCmd.AddCommand(tagsAddCmd)
+	tagsCmd.AddCommand(tagsRemoveCmd)
+	tagsCmd.PersistentFlags().BoolVar(&tagTransaction, "transaction", false, "treat the first argument as a transaction ID")
+}
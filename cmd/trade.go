@@ -0,0 +1,288 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/KalebHawkins/crypto-client/advancedtrade"
+	"github.com/KalebHawkins/crypto-client/coinbase/exchange"
+	providerexchange "github.com/KalebHawkins/crypto-client/exchange"
+	"github.com/fatih/color"
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+)
+
+// maxBatchCancel is the largest number of orders Advanced Trade cancels in a single request.
+const maxBatchCancel = 100
+
+// tradeCmd represents the trade command
+var tradeCmd = &cobra.Command{
+	Use:   "trade",
+	Short: "manage your orders across providers.",
+	Long: `Manage the orders resting on the books of the providers supporting order management.
+
+	$ crypto-client trade cancel-all --provider coinbase
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+// tradeCancelAllCmd represents the trade cancel-all command
+var tradeCancelAllCmd = &cobra.Command{
+	Use:   "cancel-all",
+	Short: "cancel every open order.",
+	Long: `Cancel every open order of a provider, or of every configured provider with --provider all.
+
+This is the break-glass command for when markets go haywire. Open orders are always looked up live
+and canceled in parallel, then the outcome of every order is listed. The command exits with an error
+if any order could not be canceled so it can be retried.
+
+Supported providers are coinbase (Advanced Trade) and coinbase-exchange. You are asked to confirm
+once before anything is canceled, use --yes to skip the prompt.
+
+	$ crypto-client trade cancel-all --provider coinbase
+	$ crypto-client trade cancel-all --provider all --yes
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		start := time.Now()
+
+		cancelers, err := orderCancelers(strings.ToLower(cancelAllProvider))
+		errHandler(err)
+
+		if !cancelAllYes && !confirm(fmt.Sprintf("Cancel every open order on %s?", cancelAllProvider)) {
+			fmt.Println("No orders were canceled.")
+			return
+		}
+
+		outcomes := cancelAllOrders(cancelers)
+		failed := printCancelOutcomes(outcomes)
+
+		fmt.Println()
+		fmt.Println("Elapsed Run Time:", time.Since(start))
+
+		if failed > 0 {
+			errHandler(fmt.Errorf("%d cancellations failed, see the reasons above", failed))
+		}
+	},
+}
+
+var cancelAllProvider string
+var cancelAllYes bool
+
+func init() {
+	rootCmd.AddCommand(tradeCmd)
+	tradeCmd.AddCommand(tradeCancelAllCmd)
+	tradeCancelAllCmd.Flags().StringVar(&cancelAllProvider, "provider", "coinbase", "the provider to cancel orders on: coinbase, coinbase-exchange or all")
+	tradeCancelAllCmd.Flags().BoolVarP(&cancelAllYes, "yes", "y", false, "cancel without asking for confirmation")
+}
+
+// cancelOutcome is the result of canceling a single order. An empty OrderID reports a provider level failure,
+// such as the open orders not being listed.
+type cancelOutcome struct {
+	Provider string
+	OrderID  string
+	Product  string
+	Side     string
+	Canceled bool
+	Reason   string
+}
+
+// orderCanceler cancels every open order of a provider and returns the outcome of each of them.
+type orderCanceler func() []cancelOutcome
+
+// orderCancelers returns the cancelers for `provider`. With "all" every provider supporting order management whose
+// credentials are set is returned.
+func orderCancelers(provider string) ([]orderCanceler, error) {
+	all := []struct {
+		name string
+		env  []string
+		open func() orderCanceler
+	}{
+		{"coinbase", []string{"COINBASE_ADVANCED_KEY_NAME", "COINBASE_ADVANCED_PRIVATE_KEY"}, func() orderCanceler {
+			c, err := advancedtrade.APIKeyClient()
+			errHandler(err)
+			return func() []cancelOutcome { return cancelAdvancedTradeOrders(c) }
+		}},
+		{"coinbase-exchange", []string{"COINBASE_EXCHANGE_KEY", "COINBASE_EXCHANGE_SECRET", "COINBASE_EXCHANGE_PASSPHRASE"}, func() orderCanceler {
+			c := newCoinbaseExchangeClient()
+			return func() []cancelOutcome { return cancelCoinbaseExchangeOrders(c) }
+		}},
+	}
+
+	var cancelers []orderCanceler
+	for _, p := range all {
+		if provider == p.name {
+			return []orderCanceler{p.open()}, nil
+		}
+
+		if provider != "all" {
+			continue
+		}
+
+		configured := true
+		for _, e := range p.env {
+			if os.Getenv(e) == "" {
+				configured = false
+			}
+		}
+		if configured {
+			cancelers = append(cancelers, p.open())
+		}
+	}
+
+	if provider != "all" {
+		if p, ok := providerByName(provider); ok {
+			if err := providerexchange.Require(p, providerexchange.Orders); err != nil {
+				return nil, fmt.Errorf("%w, use coinbase, coinbase-exchange or all", err)
+			}
+		}
+		return nil, fmt.Errorf("canceling orders is not supported for provider %q, use coinbase, coinbase-exchange or all", provider)
+	}
+
+	if len(cancelers) == 0 {
+		return nil, fmt.Errorf("no credentials are set for a provider supporting order management, see crypto-client trade cancel-all -h")
+	}
+
+	return cancelers, nil
+}
+
+// cancelAllOrders runs every canceler in parallel and returns the combined outcomes in the order of `cancelers`.
+func cancelAllOrders(cancelers []orderCanceler) []cancelOutcome {
+	results := make([][]cancelOutcome, len(cancelers))
+
+	var wg sync.WaitGroup
+	for i, c := range cancelers {
+		wg.Add(1)
+		go func(i int, c orderCanceler) {
+			defer wg.Done()
+			results[i] = c()
+		}(i, c)
+	}
+	wg.Wait()
+
+	var outcomes []cancelOutcome
+	for _, r := range results {
+		outcomes = append(outcomes, r...)
+	}
+
+	return outcomes
+}
+
+// cancelAdvancedTradeOrders cancels every open Advanced Trade order in batches sent in parallel.
+func cancelAdvancedTradeOrders(c advancedtrade.Client) []cancelOutcome {
+	const provider = "Coinbase"
+
+	orders, err := c.ListOrders(advancedtrade.OrderFilter{OrderStatus: []string{"OPEN"}})
+	if err != nil {
+		return []cancelOutcome{{Provider: provider, Reason: err.Error()}}
+	}
+
+	byID := map[string]advancedtrade.Order{}
+	var batches [][]string
+	for i, o := range orders {
+		byID[o.OrderID] = o
+		if i%maxBatchCancel == 0 {
+			batches = append(batches, nil)
+		}
+		batches[len(batches)-1] = append(batches[len(batches)-1], o.OrderID)
+	}
+
+	results := make([][]cancelOutcome, len(batches))
+	var wg sync.WaitGroup
+	for i, ids := range batches {
+		wg.Add(1)
+		go func(i int, ids []string) {
+			defer wg.Done()
+
+			cancels, err := c.CancelOrders(ids...)
+			if err != nil {
+				for _, id := range ids {
+					o := byID[id]
+					results[i] = append(results[i], cancelOutcome{provider, id, o.ProductID, o.Side, false, err.Error()})
+				}
+				return
+			}
+
+			for _, r := range cancels {
+				o := byID[r.OrderID]
+				results[i] = append(results[i], cancelOutcome{provider, r.OrderID, o.ProductID, o.Side, r.Success, r.FailureReason})
+			}
+		}(i, ids)
+	}
+	wg.Wait()
+
+	var outcomes []cancelOutcome
+	for _, r := range results {
+		outcomes = append(outcomes, r...)
+	}
+
+	return outcomes
+}
+
+// cancelCoinbaseExchangeOrders cancels every open Coinbase Exchange order, one request per order sent in parallel.
+func cancelCoinbaseExchangeOrders(c exchange.Client) []cancelOutcome {
+	const provider = "Coinbase Exchange"
+
+	orders, err := c.GetOpenOrders()
+	if err != nil {
+		return []cancelOutcome{{Provider: provider, Reason: err.Error()}}
+	}
+
+	outcomes := make([]cancelOutcome, len(orders))
+	var wg sync.WaitGroup
+	for i, o := range orders {
+		wg.Add(1)
+		go func(i int, o exchange.Order) {
+			defer wg.Done()
+
+			outcomes[i] = cancelOutcome{Provider: provider, OrderID: o.ID, Product: o.ProductID, Side: o.Side, Canceled: true}
+			if err := c.CancelOrder(o.ID); err != nil {
+				outcomes[i].Canceled = false
+				outcomes[i].Reason = err.Error()
+			}
+		}(i, o)
+	}
+	wg.Wait()
+
+	return outcomes
+}
+
+// printCancelOutcomes lists the outcome of every order and returns the number of failures.
+func printCancelOutcomes(outcomes []cancelOutcome) int {
+	if len(outcomes) == 0 {
+		fmt.Println("There were no open orders to cancel.")
+		return 0
+	}
+
+	table.DefaultHeaderFormatter = func(s string, i ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(s, i...))
+	}
+	headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
+	tbl := newTable("Provider", "Order ID", "Product", "Side", "Result", "Reason").WithHeaderFormatter(headerFmt)
+
+	failed := 0
+	for _, o := range outcomes {
+		result := color.GreenString("canceled")
+		if !o.Canceled {
+			result = color.RedString("failed")
+			failed++
+		}
+
+		orderID := o.OrderID
+		if orderID == "" {
+			orderID = "n/a"
+		}
+
+		tbl.AddRow(o.Provider, orderID, o.Product, strings.ToLower(o.Side), result, o.Reason)
+	}
+
+	tbl.Print()
+
+	return failed
+}
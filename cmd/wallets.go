@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/KalebHawkins/crypto-client/coinbase"
+	"github.com/KalebHawkins/crypto-client/internal/userdata"
+	"github.com/fatih/color"
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+)
+
+// walletsCmd represents the wallets command
+var walletsCmd = &cobra.Command{
+	Use:   "wallets",
+	Short: "list your Coinbase wallets and choose which ones to ignore.",
+	Long: `List your Coinbase wallets and choose which ones to ignore.
+
+Ignored wallets are left out of the Coinbase overview, account and transaction listings, the
+portfolio views and goal progress, for example a wallet you manage on behalf of someone else.
+Wallets are identified by their ID or name. Commands acting on a single wallet, such as
+sell or ledger, still work on ignored wallets.
+
+	$ crypto-client wallets
+	$ crypto-client wallets ignore "Family BTC Wallet"
+	$ crypto-client wallets unignore "Family BTC Wallet"
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		d, err := userdata.Load()
+		errHandler(err)
+
+		acts, err := newCoinbaseClient().GetAccount()
+		errHandler(err)
+
+		table.DefaultHeaderFormatter = func(s string, i ...interface{}) string {
+			return strings.ToUpper(fmt.Sprintf(s, i...))
+		}
+		headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
+		tbl := newTable("Wallet", "Balance", "Ignored", "ID").WithHeaderFormatter(headerFmt)
+
+		for _, a := range acts.Data {
+			tbl.AddRow(a.Name, fmtMoney(a.Balance), d.IsIgnored(a.ID, a.Name), a.ID)
+		}
+
+		tbl.Print()
+	},
+}
+
+// walletsIgnoreCmd represents the wallets ignore command
+var walletsIgnoreCmd = &cobra.Command{
+	Use:   "ignore <wallet>...",
+	Short: "ignore wallets by ID or name.",
+	Args:  cobra.MinimumNArgs(1),
+
+	Run: func(cmd *cobra.Command, args []string) {
+		d, err := userdata.Load()
+		errHandler(err)
+		d.Ignore(args...)
+		errHandler(d.Save())
+	},
+}
+
+// walletsUnignoreCmd represents the wallets unignore command
+var walletsUnignoreCmd = &cobra.Command{
+	Use:   "unignore <wallet>...",
+	Short: "stop ignoring wallets.",
+	Args:  cobra.MinimumNArgs(1),
+
+	Run: func(cmd *cobra.Command, args []string) {
+		d, err := userdata.Load()
+		errHandler(err)
+		d.Unignore(args...)
+		errHandler(d.Save())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(walletsCmd)
+	walletsCmd.AddCommand(walletsIgnoreCmd)
+	walletsCmd.AddCommand(walletsUnignoreCmd)
+}
+
+// getTrackedAccounts returns the user's Coinbase wallets without the ignored ones.
+func getTrackedAccounts(c coinbase.Client) (coinbase.Account, error) {
+	acts, err := c.GetAccount()
+	if err != nil {
+		return coinbase.Account{}, err
+	}
+
+	d, err := userdata.Load()
+	if err != nil {
+		return coinbase.Account{}, err
+	}
+
+	tracked := acts
+	tracked.Data = nil
+	for _, a := range acts.Data {
+		if !d.IsIgnored(a.ID, a.Name) {
+			tracked.Data = append(tracked.Data, a)
+		}
+	}
+
+	return tracked, nil
+}
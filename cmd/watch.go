@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"time"
+
+	"github.com/KalebHawkins/crypto-client/coinbase"
+	"github.com/KalebHawkins/crypto-client/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// coinbaseRequestsPerHour is the number of requests Coinbase allows per API key and hour. Watch mode stays below
+// half of it so other commands run alongside keep working.
+const coinbaseRequestsPerHour = 10000
+
+// maxWatchBackoff caps the delay between renders after consecutive failures.
+const maxWatchBackoff = 10 * time.Minute
+
+var watchEnabled bool
+var watchInterval time.Duration
+
+// watching is set while a watch is rendering, errHandler then aborts the render instead of exiting.
+var watching bool
+
+// apiRequests counts the Coinbase API responses received, see coinbaseOptions().
+var apiRequests uint64
+
+// watchAbort carries the error that aborted a render in watch mode.
+type watchAbort struct {
+	err error
+}
+
+// addWatchFlags adds the --watch and --interval flags to `cmd`.
+func addWatchFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(&watchEnabled, "watch", "w", false, "clear the screen and refresh the output periodically until interrupted")
+	cmd.Flags().DurationVar(&watchInterval, "interval", 30*time.Second, "the time between refreshes with --watch")
+}
+
+// watch calls `render` once, or with --watch repeatedly until interrupted with Ctrl+C. The screen is cleared before
+// every render unless --accessible or a machine readable --output is used.
+//
+// The next render starts `interval` after the previous one finished, stretched when the requests a render makes
+// would use more than half of the Coinbase rate limit. Failed renders do not end the watch, they are retried with an
+// exponential backoff instead.
+func watch(render func()) {
+	if !watchEnabled {
+		render()
+		return
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	failures := 0
+	for {
+		if !accessible && outputFormat == output.Table {
+			fmt.Print("\033[H\033[2J")
+		}
+
+		before := atomic.LoadUint64(&apiRequests)
+		err := renderOnce(render)
+		requests := atomic.LoadUint64(&apiRequests) - before
+
+		delay := watchDelay(watchInterval, requests)
+		if err != nil {
+			failures++
+			delay = watchBackoff(delay, failures, errors.Is(err, coinbase.ErrRateLimited))
+			slog.Warn("refresh failed", "error", err, "retry_in", delay)
+		} else {
+			failures = 0
+		}
+
+		fmt.Printf("\nRefreshed at %s, next refresh in %s. Press Ctrl+C to stop.\n", time.Now().Format("15:04:05"), delay.Round(time.Second))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+	}
+}
+
+// renderOnce calls `render` and returns the error it aborted with.
+func renderOnce(render func()) (err error) {
+	watching = true
+	defer func() {
+		watching = false
+		if r := recover(); r != nil {
+			abort, ok := r.(watchAbort)
+			if !ok {
+				panic(r)
+			}
+			err = abort.err
+		}
+	}()
+
+	render()
+
+	return nil
+}
+
+// watchDelay returns `interval`, stretched so that a render making `requests` requests uses at most half of the
+// Coinbase rate limit.
+func watchDelay(interval time.Duration, requests uint64) time.Duration {
+	floor := time.Duration(requests) * time.Hour / (coinbaseRequestsPerHour / 2)
+	if floor > interval {
+		return floor
+	}
+
+	return interval
+}
+
+// watchBackoff returns the delay after the `failures`th consecutive failed render. Rate limited renders back off
+// twice as fast.
+func watchBackoff(delay time.Duration, failures int, rateLimited bool) time.Duration {
+	if rateLimited {
+		failures++
+	}
+
+	for i := 1; i < failures && delay < maxWatchBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxWatchBackoff {
+		delay = maxWatchBackoff
+	}
+
+	return delay
+}
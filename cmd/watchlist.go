@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/KalebHawkins/crypto-client/coinbase"
+	"github.com/KalebHawkins/crypto-client/internal/userdata"
+	"github.com/fatih/color"
+	"github.com/rodaine/table"
+	"github.com/spf13/cobra"
+)
+
+// watchlistCmd represents the watchlist command
+var watchlistCmd = &cobra.Command{
+	Use:   "watchlist",
+	Short: "track assets you do not hold.",
+	Long: `Track the price of assets you do not hold yet.
+
+Assets on the watchlist are shown below the Coinbase overview alongside your holdings.
+Running this command without a subcommand lists the watchlist with current spot prices, use --watch
+to keep the prices refreshed.
+
+	$ crypto-client watchlist add SOL ADA
+	$ crypto-client watchlist remove ADA
+	$ crypto-client watchlist
+	$ crypto-client watchlist --watch --interval 1m
+`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		watch(func() {
+			d, err := userdata.Load()
+			errHandler(err)
+
+			c := newCoinbaseClient()
+			user, err := c.GetUserProfile()
+			errHandler(err)
+
+			printWatchlist(c, d.Watchlist, user.Data.NativeCurrency)
+		})
+	},
+}
+
+// watchlistAddCmd represents the watchlist add command
+var watchlistAddCmd = &cobra.Command{
+	Use:   "add <asset>...",
+	Short: "add assets to the watchlist.",
+	Args:  cobra.MinimumNArgs(1),
+
+	Run: func(cmd *cobra.Command, args []string) {
+		d, err := userdata.Load()
+		errHandler(err)
+		d.Watch(args...)
+		errHandler(d.Save())
+	},
+}
+
+// watchlistRemoveCmd represents the watchlist remove command
+var watchlistRemoveCmd = &cobra.Command{
+	Use:   "remove <asset>...",
+	Short: "remove assets from the watchlist.",
+	Args:  cobra.MinimumNArgs(1),
+
+	Run: func(cmd *cobra.Command, args []string) {
+		d, err := userdata.Load()
+		errHandler(err)
+		d.Unwatch(args...)
+		errHandler(d.Save())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(watchlistCmd)
+	watchlistCmd.AddCommand(watchlistAddCmd)
+	watchlistCmd.AddCommand(watchlistRemoveCmd)
+	addWatchFlags(watchlistCmd)
+}
+
+// printWatchlist prints the spot, buy and sell price of every asset in `symbols` in the given native currency.
+func printWatchlist(c coinbase.Client, symbols []string, nativeCurrency string) {
+	if len(symbols) == 0 {
+		return
+	}
+
+	table.DefaultHeaderFormatter = func(s string, i ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(s, i...))
+	}
+	headerFmt := color.New(color.FgGreen, color.Underline).SprintfFunc()
+	tbl := newTable("Watching", "Spot Price Per Unit", "Buy Price Per Unit", "Sell Price Per Unit").WithHeaderFormatter(headerFmt)
+
+	for _, s := range symbols {
+		currencyPair := fmt.Sprintf("%s-%s", s, nativeCurrency)
+
+		var prices []string
+		for _, priceType := range []string{coinbase.Spot, coinbase.Buy, coinbase.Sell} {
+			p, err := c.GetPrice(currencyPair, priceType)
+			errHandler(err)
+			prices = append(prices, fmtMoney(p.Data.Money))
+		}
+
+		tbl.AddRow(s, prices[0], prices[1], prices[2])
+	}
+
+	tbl.Print()
+}
@@ -0,0 +1,82 @@
+package coinbase
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// cachedPaths are the prefixes of the resource paths whose responses are cached. They are idempotent lookups that
+// commands tend to repeat within a run, such as the price of the same pair.
+var cachedPaths = []string{"prices/", "exchange-rates"}
+
+// responseCache keeps the bodies of successful GET responses for a while. It is shared by the copies of the client.
+type responseCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]cachedResponse
+}
+
+// cachedResponse is a response body and when it expires.
+type cachedResponse struct {
+	body    []byte
+	expires time.Time
+}
+
+// newResponseCache returns a cache keeping responses for `ttl`. nil is returned, disabling the cache, when `ttl` is
+// not positive.
+func newResponseCache(ttl time.Duration) *responseCache {
+	if ttl <= 0 {
+		return nil
+	}
+
+	return &responseCache{ttl: ttl, entries: map[string]cachedResponse{}}
+}
+
+// get returns the cached body of `resourcePath` if it has not expired. A nil cache never has a body.
+func (rc *responseCache) get(resourcePath string) ([]byte, bool) {
+	if rc == nil {
+		return nil, false
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	e, ok := rc.entries[resourcePath]
+	if !ok || time.Now().After(e.expires) {
+		delete(rc.entries, resourcePath)
+		return nil, false
+	}
+
+	return e.body, true
+}
+
+// put caches `body` as the response to `resourcePath` when the path is one of cachedPaths. Expired entries are
+// dropped on the way so the cache does not grow in long running commands.
+func (rc *responseCache) put(resourcePath string, body []byte) {
+	if rc == nil || !cacheable(resourcePath) {
+		return
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	now := time.Now()
+	for p, e := range rc.entries {
+		if now.After(e.expires) {
+			delete(rc.entries, p)
+		}
+	}
+	rc.entries[resourcePath] = cachedResponse{body: body, expires: now.Add(rc.ttl)}
+}
+
+// cacheable reports whether responses to `resourcePath` may be cached.
+func cacheable(resourcePath string) bool {
+	for _, p := range cachedPaths {
+		if strings.HasPrefix(resourcePath, p) {
+			return true
+		}
+	}
+
+	return false
+}
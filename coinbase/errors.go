@@ -0,0 +1,103 @@
+package coinbase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// These errors classify an APIError and can be matched with errors.Is(), for example
+//
+//	errors.Is(err, coinbase.ErrRateLimited)
+var (
+	ErrUnauthorized = errors.New("coinbase: authentication failed")
+	ErrForbidden    = errors.New("coinbase: insufficient permissions")
+	ErrNotFound     = errors.New("coinbase: resource not found")
+	ErrRateLimited  = errors.New("coinbase: rate limit exceeded")
+)
+
+// APIError is returned when the Coinbase API responds with a non 2xx status. The error IDs and messages are
+// parsed from Coinbase's error envelope, use errors.As() to access them.
+type APIError struct {
+	StatusCode int           `json:"-"`
+	Status     string        `json:"-"`
+	Errors     []ErrorDetail `json:"errors"`
+	Body       string        `json:"-"`
+}
+
+// ErrorDetail is a single entry of Coinbase's error envelope.
+type ErrorDetail struct {
+	ID      string `json:"id"`
+	Message string `json:"message"`
+	URL     string `json:"url,omitempty"`
+}
+
+// newAPIError builds an APIError from a failed response and its body. The raw body is kept when it does not
+// contain an error envelope.
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	e := &APIError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+	}
+
+	if err := json.Unmarshal(body, e); err != nil || len(e.Errors) == 0 {
+		e.Body = string(body)
+	}
+
+	return e
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	if len(e.Errors) == 0 {
+		return fmt.Sprintf("bad HTTP status return code: %v\n%v", e.Status, e.Body)
+	}
+
+	msgs := make([]string, 0, len(e.Errors))
+	for _, d := range e.Errors {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", d.ID, d.Message))
+	}
+
+	return fmt.Sprintf("coinbase API error (%v): %s", e.Status, strings.Join(msgs, "; "))
+}
+
+// Is reports whether the error matches one of the classification errors such as ErrUnauthorized.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.hasID("authentication_error", "invalid_token", "expired_token", "revoked_token")
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden || e.hasID("invalid_scope")
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound || e.hasID("not_found")
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests || e.hasID("rate_limit_exceeded")
+	}
+
+	return false
+}
+
+// hasID reports whether any entry of the error envelope has one of the given IDs.
+func (e *APIError) hasID(ids ...string) bool {
+	for _, d := range e.Errors {
+		for _, id := range ids {
+			if d.ID == id {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// IsAuthError reports whether `err` was caused by missing, invalid or expired credentials.
+func IsAuthError(err error) bool {
+	return errors.Is(err, ErrUnauthorized)
+}
+
+// IsRateLimited reports whether `err` was caused by exceeding the Coinbase API rate limit.
+func IsRateLimited(err error) bool {
+	return errors.Is(err, ErrRateLimited)
+}
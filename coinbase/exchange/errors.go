@@ -0,0 +1,59 @@
+package exchange
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/KalebHawkins/crypto-client/coinbase"
+)
+
+// APIError is returned when the Exchange API responds with a non 2xx status. It matches the classification errors
+// of the coinbase package, for example errors.Is(err, coinbase.ErrRateLimited).
+type APIError struct {
+	StatusCode int    `json:"-"`
+	Status     string `json:"-"`
+	Message    string `json:"message"`
+	Body       string `json:"-"`
+}
+
+// newAPIError builds an APIError from a failed response and its body. The raw body is kept when it does not
+// contain an error message.
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	e := &APIError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+	}
+
+	if err := json.Unmarshal(body, e); err != nil || e.Message == "" {
+		e.Body = string(body)
+	}
+
+	return e
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("bad HTTP status return code: %v\n%v", e.Status, e.Body)
+	}
+
+	return fmt.Sprintf("coinbase exchange API error (%v): %s", e.Status, e.Message)
+}
+
+// Is reports whether the error matches one of the coinbase classification errors such as
+// coinbase.ErrUnauthorized.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case coinbase.ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case coinbase.ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case coinbase.ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case coinbase.ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+
+	return false
+}
@@ -0,0 +1,245 @@
+/*
+Package exchange is used to query the Coinbase Exchange REST API for institutional accounts, fills and transfers.
+
+Coinbase Exchange is separate from the retail v2 API of the coinbase package. Its API keys are created per profile
+at https://exchange.coinbase.com/profile/api and come with a passphrase. Every request is signed with a base64
+encoded HMAC-SHA256 of the timestamp, method, path and body keyed with the base64 decoded API secret.
+*/
+package exchange
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+)
+
+// APIKeyClient creates a client from the Exchange API key set in the environment.
+//
+//	export COINBASE_EXCHANGE_KEY="api_key"
+//	export COINBASE_EXCHANGE_SECRET="api_secret"
+//	export COINBASE_EXCHANGE_PASSPHRASE="passphrase"
+//
+// An error is returned if the API secret is not base64 encoded.
+func APIKeyClient(opts ...Option) (Client, error) {
+	return NewClient(os.Getenv("COINBASE_EXCHANGE_KEY"), os.Getenv("COINBASE_EXCHANGE_SECRET"),
+		os.Getenv("COINBASE_EXCHANGE_PASSPHRASE"), opts...)
+}
+
+// NewClient creates a client for the API key `apiKey` with its secret and passphrase. An error is returned if the
+// API secret is not base64 encoded.
+func NewClient(apiKey string, apiSecret string, passphrase string, opts ...Option) (Client, error) {
+	if _, err := base64.StdEncoding.DecodeString(apiSecret); err != nil {
+		return Client{}, fmt.Errorf("coinbase exchange: API secret is not base64 encoded: %v", err)
+	}
+
+	c := Client{
+		apiKey:     apiKey,
+		apiSecret:  apiSecret,
+		passphrase: passphrase,
+		baseURL:    apiEndpointBase,
+		httpClient: &http.Client{},
+	}
+
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return c, nil
+}
+
+// ─── EXCHANGE METHODS ───────────────────────────────────────────────────────────
+
+// GetAccounts upon a successful API request returns the accounts of the profile the API key belongs to. An error
+// is returned if creating or sending the request failed.
+func (c Client) GetAccounts() ([]Account, error) {
+	var a []Account
+	_, err := c.get("/accounts", nil, &a)
+
+	return a, err
+}
+
+// GetFills upon a successful API request returns every fill of `productID`, for example "BTC-USD", newest first.
+// An error is returned if any request failed.
+func (c Client) GetFills(productID string) ([]Fill, error) {
+	var fills []Fill
+	q := url.Values{"product_id": {productID}}
+
+	err := c.paginate("/fills", q, func(body []byte) (int, error) {
+		var page []Fill
+		if err := json.Unmarshal(body, &page); err != nil {
+			return 0, err
+		}
+		fills = append(fills, page...)
+
+		return len(page), nil
+	})
+
+	return fills, err
+}
+
+// GetTransfers upon a successful API request returns every deposit and withdrawal of the profile, newest first.
+// An error is returned if any request failed.
+func (c Client) GetTransfers() ([]Transfer, error) {
+	var transfers []Transfer
+
+	err := c.paginate("/transfers", url.Values{}, func(body []byte) (int, error) {
+		var page []Transfer
+		if err := json.Unmarshal(body, &page); err != nil {
+			return 0, err
+		}
+		transfers = append(transfers, page...)
+
+		return len(page), nil
+	})
+
+	return transfers, err
+}
+
+// GetTicker upon a successful API request returns the last trade and the best bid and ask of `productID`.
+// An error is returned if creating or sending the request failed.
+func (c Client) GetTicker(productID string) (Ticker, error) {
+	var t Ticker
+	_, err := c.get("/products/"+productID+"/ticker", nil, &t)
+
+	return t, err
+}
+
+// GetOpenOrders upon a successful API request returns every order still resting on the book. An error is returned
+// if creating or sending a request failed.
+func (c Client) GetOpenOrders() ([]Order, error) {
+	var orders []Order
+	q := url.Values{"status": {"open"}}
+
+	err := c.paginate("/orders", q, func(body []byte) (int, error) {
+		var page []Order
+		if err := json.Unmarshal(body, &page); err != nil {
+			return 0, err
+		}
+		orders = append(orders, page...)
+
+		return len(page), nil
+	})
+
+	return orders, err
+}
+
+// CancelOrder upon a successful API request cancels the order matching `orderID`. An error is returned if creating
+// or sending the request failed.
+func (c Client) CancelOrder(orderID string) error {
+	_, _, err := c.sendRequest("DELETE", "/orders/"+orderID, nil)
+
+	return err
+}
+
+//
+// ───────────────────────────────────────────────────────── EXCHANGE METHODS ─────
+//
+
+// ─── HELPER FUNCTIONS ───────────────────────────────────────────────────────────
+
+// get sends a signed GET request for `resourcePath` with the query `q`, parses the response into `v` and returns
+// the response headers.
+func (c Client) get(resourcePath string, q url.Values, v interface{}) (http.Header, error) {
+	if len(q) > 0 {
+		resourcePath += "?" + q.Encode()
+	}
+
+	body, header, err := c.sendRequest("GET", resourcePath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return header, json.Unmarshal(body, v)
+}
+
+// paginate fetches the pages of `resourcePath`, newest first, until a page is not full or the API stops returning
+// a CB-AFTER cursor. `page` parses a response body and returns the number of items it held.
+func (c Client) paginate(resourcePath string, q url.Values, page func(body []byte) (int, error)) error {
+	q.Set("limit", strconv.Itoa(maxPageSize))
+
+	for {
+		body, header, err := c.sendRequest("GET", resourcePath+"?"+q.Encode(), nil)
+		if err != nil {
+			return err
+		}
+
+		n, err := page(body)
+		if err != nil {
+			return err
+		}
+
+		after := header.Get("CB-AFTER")
+		if after == "" || n < maxPageSize {
+			return nil
+		}
+		q.Set("after", after)
+	}
+}
+
+// sendRequest signs and sends a request for `resourcePath`, which includes the query. A non 2xx response is
+// returned as an *APIError.
+func (c Client) sendRequest(method string, resourcePath string, payload interface{}) ([]byte, http.Header, error) {
+	var data []byte
+	if payload != nil {
+		var err error
+		if data, err = json.Marshal(payload); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+resourcePath, bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	signature, err := c.createSignature(timestamp, method, resourcePath, string(data))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("CB-ACCESS-KEY", c.apiKey)
+	req.Header.Set("CB-ACCESS-SIGN", signature)
+	req.Header.Set("CB-ACCESS-TIMESTAMP", timestamp)
+	req.Header.Set("CB-ACCESS-PASSPHRASE", c.passphrase)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, nil, newAPIError(resp, body)
+	}
+
+	return body, resp.Header, nil
+}
+
+// createSignature returns the base64 encoded HMAC-SHA256 of the request keyed with the decoded API secret.
+func (c Client) createSignature(timestamp, method, resourcePath, body string) (string, error) {
+	key, err := base64.StdEncoding.DecodeString(c.apiSecret)
+	if err != nil {
+		return "", err
+	}
+
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(timestamp + method + resourcePath + body))
+
+	return base64.StdEncoding.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,35 @@
+package exchange
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Option configures a Client when it is created with APIKeyClient() or NewClient().
+type Option func(*Client)
+
+// WithHTTPClient makes the client send its requests using `hc`.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithTimeout sets the time limit for each request made by the client, including reading the response body.
+// The http.Client passed to WithHTTPClient() is copied rather than modified.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		hc := *c.httpClient
+		hc.Timeout = d
+		c.httpClient = &hc
+	}
+}
+
+// WithBaseURL points the client at a different API endpoint, for example the sandbox at
+// https://api-public.sandbox.exchange.coinbase.com or a test server.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
@@ -0,0 +1,86 @@
+package exchange
+
+import (
+	"sort"
+	"strings"
+
+	provider "github.com/KalebHawkins/crypto-client/exchange"
+	"github.com/KalebHawkins/crypto-client/money"
+)
+
+var _ provider.Provider = Client{}
+
+// Name implements exchange.Provider.
+func (c Client) Name() string {
+	return "Coinbase Exchange"
+}
+
+// Capabilities implements exchange.Provider. Open orders can be canceled, see CancelOrder().
+func (c Client) Capabilities() provider.Capabilities {
+	return provider.Capabilities{provider.Orders}
+}
+
+// Balances implements exchange.Provider.
+func (c Client) Balances() ([]provider.Balance, error) {
+	accounts, err := c.GetAccounts()
+	if err != nil {
+		return nil, err
+	}
+
+	var balances []provider.Balance
+	for _, a := range accounts {
+		if a.Balance.IsZero() {
+			continue
+		}
+		balances = append(balances, provider.Balance{Asset: a.Currency, Amount: a.Balance})
+	}
+
+	return balances, nil
+}
+
+// Transactions implements exchange.Provider. Deposits and withdrawals that were not canceled are returned, fills
+// can only be listed per product with GetFills().
+func (c Client) Transactions() ([]provider.Transaction, error) {
+	transfers, err := c.GetTransfers()
+	if err != nil {
+		return nil, err
+	}
+
+	var transactions []provider.Transaction
+	for _, t := range transfers {
+		if t.Status() == "canceled" {
+			continue
+		}
+
+		amount := t.Amount
+		typ := provider.Deposit
+		if strings.HasPrefix(t.Type, "withdraw") {
+			amount = amount.Neg()
+			typ = provider.Withdrawal
+		}
+
+		transactions = append(transactions, provider.Transaction{
+			ID:          t.ID,
+			Type:        typ,
+			Time:        t.CreatedAt.Time,
+			Amount:      money.New(amount, t.Currency),
+			Description: t.Type,
+		})
+	}
+
+	sort.SliceStable(transactions, func(i, j int) bool {
+		return transactions[i].Time.Before(transactions[j].Time)
+	})
+
+	return transactions, nil
+}
+
+// Price implements exchange.Provider. The price of the last trade is returned.
+func (c Client) Price(pair provider.Pair) (money.Money, error) {
+	t, err := c.GetTicker(pair.String())
+	if err != nil {
+		return money.Money{}, err
+	}
+
+	return money.New(t.Price, pair.Quote), nil
+}
@@ -0,0 +1,147 @@
+package exchange
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	apiEndpointBase string = "https://api.exchange.coinbase.com"
+	maxPageSize     int    = 100
+)
+
+// These constants are the sides of a fill.
+const (
+	Buy  string = "buy"
+	Sell string = "sell"
+)
+
+// Client is used to query the Coinbase Exchange API. Create one with APIKeyClient() or NewClient().
+type Client struct {
+	apiKey     string
+	apiSecret  string
+	passphrase string
+	baseURL    string
+	httpClient *http.Client
+}
+
+// Time is a timestamp sent by the Exchange API. Most resources use RFC 3339 but transfers use a Postgres style
+// layout such as "2021-02-04 19:52:09.046584+00", both are accepted.
+type Time struct {
+	time.Time
+}
+
+// timeLayouts are the layouts tried in order when parsing a Time.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999-07",
+	"2006-01-02 15:04:05.999999-07:00",
+}
+
+// UnmarshalJSON implements json.Unmarshaler. A null timestamp decodes as the zero time.
+func (t *Time) UnmarshalJSON(b []byte) error {
+	s := strings.Trim(string(b), `"`)
+	if s == "" || s == "null" {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	var err error
+	for _, layout := range timeLayouts {
+		var parsed time.Time
+		if parsed, err = time.Parse(layout, s); err == nil {
+			t.Time = parsed
+			return nil
+		}
+	}
+
+	return err
+}
+
+// Account is the balance of a currency in a profile.
+type Account struct {
+	ID             string          `json:"id"`
+	Currency       string          `json:"currency"`
+	Balance        decimal.Decimal `json:"balance"`
+	Hold           decimal.Decimal `json:"hold"`
+	Available      decimal.Decimal `json:"available"`
+	ProfileID      string          `json:"profile_id"`
+	TradingEnabled bool            `json:"trading_enabled"`
+}
+
+// Fill is a partial or complete execution of an order.
+type Fill struct {
+	TradeID   int64           `json:"trade_id"`
+	ProductID string          `json:"product_id"`
+	OrderID   string          `json:"order_id"`
+	ProfileID string          `json:"profile_id"`
+	Liquidity string          `json:"liquidity"`
+	Price     decimal.Decimal `json:"price"`
+	Size      decimal.Decimal `json:"size"`
+	Fee       decimal.Decimal `json:"fee"`
+	Side      string          `json:"side"`
+	Settled   bool            `json:"settled"`
+	USDVolume decimal.Decimal `json:"usd_volume"`
+	CreatedAt Time            `json:"created_at"`
+}
+
+// Transfer is a deposit into or withdrawal out of a profile.
+type Transfer struct {
+	ID          string          `json:"id"`
+	Type        string          `json:"type"`
+	Amount      decimal.Decimal `json:"amount"`
+	Currency    string          `json:"currency"`
+	Details     TransferDetails `json:"details"`
+	CreatedAt   Time            `json:"created_at"`
+	CompletedAt Time            `json:"completed_at"`
+	CanceledAt  Time            `json:"canceled_at"`
+	ProcessedAt Time            `json:"processed_at"`
+}
+
+// Status returns "completed", "canceled" or "pending" depending on which of the transfer timestamps are set.
+func (t Transfer) Status() string {
+	switch {
+	case !t.CanceledAt.IsZero():
+		return "canceled"
+	case !t.CompletedAt.IsZero():
+		return "completed"
+	}
+
+	return "pending"
+}
+
+// TransferDetails describes where a transfer came from or went to.
+type TransferDetails struct {
+	CryptoAddress         string `json:"crypto_address"`
+	CryptoTransactionHash string `json:"crypto_transaction_hash"`
+	CoinbaseAccountID     string `json:"coinbase_account_id"`
+	CoinbaseTransactionID string `json:"coinbase_transaction_id"`
+	DestinationTag        string `json:"destination_tag"`
+}
+
+// Order is an order placed on the exchange.
+type Order struct {
+	ID         string          `json:"id"`
+	ProductID  string          `json:"product_id"`
+	Side       string          `json:"side"`
+	Type       string          `json:"type"`
+	Price      decimal.Decimal `json:"price"`
+	Size       decimal.Decimal `json:"size"`
+	FilledSize decimal.Decimal `json:"filled_size"`
+	Status     string          `json:"status"`
+	CreatedAt  Time            `json:"created_at"`
+}
+
+// Ticker is the last trade and the best bid and ask of a product.
+type Ticker struct {
+	TradeID int64           `json:"trade_id"`
+	Price   decimal.Decimal `json:"price"`
+	Size    decimal.Decimal `json:"size"`
+	Bid     decimal.Decimal `json:"bid"`
+	Ask     decimal.Decimal `json:"ask"`
+	Volume  decimal.Decimal `json:"volume"`
+	Time    Time            `json:"time"`
+}
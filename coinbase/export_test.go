@@ -0,0 +1,8 @@
+package coinbase
+
+import "net/http"
+
+// Sign returns the CB-ACCESS-SIGN header of `r` for a client with the API secret `secret`.
+func Sign(secret string, timestamp int64, r *http.Request, body []byte) string {
+	return CoinbaseClient{apiSecret: secret}.createSignature(timestamp, r, body)
+}
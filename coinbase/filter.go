@@ -0,0 +1,63 @@
+package coinbase
+
+import (
+	"strings"
+	"time"
+)
+
+// TransactionFilter selects transactions by date, type and asset. Zero fields match every transaction.
+type TransactionFilter struct {
+	// Since and Until bound the creation time of the transactions. Since is inclusive, Until exclusive.
+	Since time.Time
+	Until time.Time
+
+	// Types are transaction types such as Buy, Sell, Send or InflationReward.
+	Types []string
+
+	// Assets are the currencies of the transaction amounts, for example BTC.
+	Assets []string
+}
+
+// Match reports whether `t` is selected by the filter.
+func (f TransactionFilter) Match(t TransactionData) bool {
+	if !f.Since.IsZero() && t.CreatedAt.Before(f.Since) {
+		return false
+	}
+
+	if !f.Until.IsZero() && !t.CreatedAt.Before(f.Until) {
+		return false
+	}
+
+	if len(f.Types) > 0 && !containsFold(f.Types, t.Type) {
+		return false
+	}
+
+	if len(f.Assets) > 0 && !containsFold(f.Assets, t.Amount.Currency) {
+		return false
+	}
+
+	return true
+}
+
+// Apply returns the transactions selected by the filter, keeping their order.
+func (f TransactionFilter) Apply(transactions []TransactionData) []TransactionData {
+	var selected []TransactionData
+	for _, t := range transactions {
+		if f.Match(t) {
+			selected = append(selected, t)
+		}
+	}
+
+	return selected
+}
+
+// containsFold reports whether `list` contains `s`, ignoring case.
+func containsFold(list []string, s string) bool {
+	for _, x := range list {
+		if strings.EqualFold(x, s) {
+			return true
+		}
+	}
+
+	return false
+}
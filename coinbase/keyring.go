@@ -0,0 +1,76 @@
+package coinbase
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/zalando/go-keyring"
+)
+
+// The API key is stored in the OS keyring (Keychain on macOS, the Secret Service on Linux and the Credential
+// Manager on Windows) under this service. The user is "coinbase" for the default profile and "coinbase/<profile>"
+// for named profiles.
+const keyringService = "crypto-client"
+
+// ErrNoKeyringCredentials is returned by KeyringClient() when no API key is stored in the OS keyring.
+var ErrNoKeyringCredentials = errors.New("coinbase: no API key stored in the OS keyring")
+
+// keyringCredentials is the secret stored in the OS keyring.
+type keyringCredentials struct {
+	APIKey    string `json:"api_key"`
+	APISecret string `json:"api_secret"`
+}
+
+// StoreKeyring stores the API key and API secret of `profile` in the OS keyring, replacing any previously stored
+// key. The empty profile is the default one. An error is returned if the keyring is not available.
+func StoreKeyring(profile string, apiKey string, apiSecret string) error {
+	b, err := json.Marshal(keyringCredentials{apiKey, apiSecret})
+	if err != nil {
+		return err
+	}
+
+	return keyring.Set(keyringService, keyringUser(profile), string(b))
+}
+
+// DeleteKeyring removes the API key of `profile` from the OS keyring. It is not an error if no key is stored.
+func DeleteKeyring(profile string) error {
+	if err := keyring.Delete(keyringService, keyringUser(profile)); err != nil && !errors.Is(err, keyring.ErrNotFound) {
+		return err
+	}
+
+	return nil
+}
+
+// KeyringClient creates a client from the API key of `profile` stored in the OS keyring with StoreKeyring() instead
+// of the COINBASE_KEY and COINBASE_SECRET environment variables. It accepts the same options as APIKeyClient().
+// ErrNoKeyringCredentials is returned if no key is stored, any other error if the keyring cannot be read.
+func KeyringClient(profile string, opts ...Option) (CoinbaseClient, error) {
+	secret, err := keyring.Get(keyringService, keyringUser(profile))
+	if errors.Is(err, keyring.ErrNotFound) {
+		return CoinbaseClient{}, ErrNoKeyringCredentials
+	}
+
+	if err != nil {
+		return CoinbaseClient{}, err
+	}
+
+	var creds keyringCredentials
+	if err := json.Unmarshal([]byte(secret), &creds); err != nil {
+		return CoinbaseClient{}, err
+	}
+
+	c := APIKeyClient(opts...)
+	c.apiKey = creds.APIKey
+	c.apiSecret = creds.APISecret
+
+	return c, nil
+}
+
+// keyringUser returns the keyring user the API key of `profile` is stored under.
+func keyringUser(profile string) string {
+	if profile == "" {
+		return "coinbase"
+	}
+
+	return "coinbase/" + profile
+}
@@ -6,30 +6,58 @@ package coinbase
 
 import (
 	"bytes"
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"log/slog"
+	"math/rand"
 	"net/http"
+	"net/url"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/rodaine/table"
+	"github.com/shopspring/decimal"
 )
 
 // APIKeyClient sets the API key and API secret for Coinbase authentication.
 // to use your API Key and API secret set your environment variables.
-//  export COINBASE_API="api_key"
-//  export COINBASE_SECRET="api_secret"
-func APIKeyClient() CoinbaseClient {
-	cbAPIKey = os.Getenv("COINBASE_KEY")
-	cbAPISecret = os.Getenv("COINBASE_SECRET")
+//
+//	export COINBASE_API="api_key"
+//	export COINBASE_SECRET="api_secret"
+//
+// Setting COINBASE_SANDBOX=1 points the client at the Coinbase sandbox, see WithSandbox().
+// The client can be customized by passing options such as WithHTTPClient(), WithTimeout(), WithBaseURL(),
+// WithRetries() or WithRateLimit().
+func APIKeyClient(opts ...Option) CoinbaseClient {
+	c := CoinbaseClient{
+		apiKey:      os.Getenv("COINBASE_KEY"),
+		apiSecret:   os.Getenv("COINBASE_SECRET"),
+		baseURL:     apiEndpointBase,
+		httpClient:  &http.Client{Timeout: defaultTimeout},
+		maxRetries:  defaultMaxRetries,
+		backoffBase: defaultBackoffBase,
+		backoffMax:  defaultBackoffMax,
+		limiter:     newLimiter(defaultRateLimit, defaultRateBurst),
+	}
+
+	if sandbox, _ := strconv.ParseBool(os.Getenv("COINBASE_SANDBOX")); sandbox {
+		c.baseURL = sandboxEndpoint
+	}
 
-	return CoinbaseClient{}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return c
 }
 
 // ─── COINBASE METHODS ───────────────────────────────────────────────────────────
@@ -38,7 +66,7 @@ func APIKeyClient() CoinbaseClient {
 // if creating or sending the request failed.
 func (c CoinbaseClient) GetUserProfile() (User, error) {
 
-	body, err := createRequest("user")
+	body, err := c.createRequest("user")
 
 	if err != nil {
 		return User{}, err
@@ -58,7 +86,7 @@ func (c CoinbaseClient) GetUserProfile() (User, error) {
 // if creating or sending the request failed.
 func (c CoinbaseClient) GetAccount() (Account, error) {
 
-	body, err := createRequest("accounts")
+	body, err := c.createRequest("accounts")
 
 	if err != nil {
 		return Account{}, err
@@ -74,20 +102,26 @@ func (c CoinbaseClient) GetAccount() (Account, error) {
 	return account, nil
 }
 
-// GetExchangeRate() upon a successful API request returns coinbase exchange rate information. An error is returned
-// if creating or sending the request failed.
-func (c CoinbaseClient) GetExchangeRate() (ExchangeRate, error) {
-	body, err := createRequest("exchange-rates")
+// GetExchangeRate() upon a successful API request returns the exchange rates from `currency` to every currency
+// Coinbase knows about. An empty `currency` uses USD. An error is returned if creating or sending the request
+// failed.
+func (c CoinbaseClient) GetExchangeRate(currency string) (ExchangeRate, error) {
+	resourcePath := "exchange-rates"
+	if currency != "" {
+		resourcePath += "?currency=" + url.QueryEscape(strings.ToUpper(currency))
+	}
+
+	body, err := c.createRequest(resourcePath)
 
 	if err != nil {
-		return nil, err
+		return ExchangeRate{}, err
 	}
 
 	var exchangeRate ExchangeRate
 	err = json.Unmarshal(body, &exchangeRate)
 
 	if err != nil {
-		return nil, err
+		return ExchangeRate{}, err
 	}
 
 	return exchangeRate, nil
@@ -106,21 +140,62 @@ func (c CoinbaseClient) GetExchangeRate() (ExchangeRate, error) {
 //
 // These string values are mapped using the constant values `coinbase.Buy`, `coinbase.Sell`, and `coinbase.Spot` defined in the `types.go` file.
 func (c CoinbaseClient) GetPrice(currencyPair string, priceType string) (Price, error) {
-	body, err := createRequest(fmt.Sprintf("prices/%s/%s", currencyPair, priceType))
+	body, err := c.createRequest(fmt.Sprintf("prices/%s/%s", currencyPair, priceType))
 
 	if err != nil {
-		return Price{}, nil
+		return Price{}, err
 	}
 
 	var sp Price
 	err = json.Unmarshal(body, &sp)
 
 	if err != nil {
-		return Price{}, nil
+		return Price{}, err
 	}
 	return sp, nil
 }
 
+// GetPrices upon successful API requests returns the current `priceType` price of every currency pair in `pairs`,
+// keyed by the pair as given. Duplicate pairs are looked up once and a few lookups are sent concurrently. An error is
+// returned if any of the lookups failed.
+func (c CoinbaseClient) GetPrices(pairs []string, priceType string) (map[string]Price, error) {
+	var unique []string
+	seen := map[string]bool{}
+	for _, p := range pairs {
+		if !seen[p] {
+			seen[p] = true
+			unique = append(unique, p)
+		}
+	}
+
+	prices := make([]Price, len(unique))
+	errs := make([]error, len(unique))
+
+	sem := make(chan struct{}, maxPriceLookups)
+	var wg sync.WaitGroup
+	for i, p := range unique {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, p string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			prices[i], errs[i] = c.GetPrice(p, priceType)
+		}(i, p)
+	}
+	wg.Wait()
+
+	result := make(map[string]Price, len(unique))
+	for i, p := range unique {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		result[p] = prices[i]
+	}
+
+	return result, nil
+}
+
 // GetPriceByDate() upon a successful API request returns coinbase price information. An error is returned
 // if creating or sending the request failed.
 // The `currencyPair` parameter is the currency in which you want to get the
@@ -129,7 +204,7 @@ func (c CoinbaseClient) GetPrice(currencyPair string, priceType string) (Price,
 // The `year` is a time object formatted as YYYY-MM-DD.
 func (c CoinbaseClient) GetPriceByDate(currencyPair string, year time.Time) (Price, error) {
 
-	body, err := createRequest(fmt.Sprintf("prices/%s/spot?date=%s", currencyPair, year.Format("2006-01-02")))
+	body, err := c.createRequest(fmt.Sprintf("prices/%s/spot?date=%s", currencyPair, year.Format("2006-01-02")))
 
 	if err != nil {
 		return Price{}, err
@@ -145,26 +220,350 @@ func (c CoinbaseClient) GetPriceByDate(currencyPair string, year time.Time) (Pri
 	return p, nil
 }
 
+// GetPriceHistory upon a successful API request returns the spot price of `currencyPair` on every date from `from`
+// to `to` inclusive, spaced by `granularity`. An error is returned if any of the lookups failed.
+// The v2 API has no candles so every point is a separate GetPriceByDate() lookup, a few of which are sent
+// concurrently.
+func (c CoinbaseClient) GetPriceHistory(currencyPair string, from, to time.Time, granularity Granularity) (PriceHistory, error) {
+	dates := granularity.Dates(from, to)
+	if len(dates) == 0 {
+		return PriceHistory{}, fmt.Errorf("empty price history range %s to %s", from.Format("2006-01-02"), to.Format("2006-01-02"))
+	}
+
+	history := PriceHistory{CurrencyPair: currencyPair, Points: make([]PricePoint, len(dates))}
+	errs := make([]error, len(dates))
+
+	sem := make(chan struct{}, maxHistoryLookups)
+	var wg sync.WaitGroup
+	for i, d := range dates {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, d time.Time) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			p, err := c.GetPriceByDate(currencyPair, d)
+			history.Points[i] = PricePoint{Time: d, Price: p.Data.Money}
+			errs[i] = err
+		}(i, d)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return PriceHistory{}, err
+		}
+	}
+
+	return history, nil
+}
+
+// Dates returns the dates of the points of a price history from `from` to `to` inclusive, starting at midnight UTC
+// of `from`. Monthly points fall on the day of the month of `from`, or the last day of shorter months.
+func (g Granularity) Dates(from, to time.Time) []time.Time {
+	start := from.UTC().Truncate(24 * time.Hour)
+
+	var dates []time.Time
+	for i, d := 0, start; !d.After(to); i, d = i+1, g.point(start, i+1) {
+		dates = append(dates, d)
+	}
+
+	return dates
+}
+
+// Return is the relative change in price from the first to the last point of the history, for example 0.25 for a
+// 25% gain. It is zero when the history has fewer than two points or starts at a zero price.
+func (h PriceHistory) Return() decimal.Decimal {
+	if len(h.Points) < 2 || h.Points[0].Price.IsZero() {
+		return decimal.Zero
+	}
+
+	first, last := h.Points[0].Price, h.Points[len(h.Points)-1].Price
+	return last.Sub(first).Amount.Div(first.Amount)
+}
+
 // GetTransactionHistory upon a successful API request returns coinbase transaction information. An error is returned
 // if creating or sending the request failed. The `accountID` parameter is the account ID in which you want to get the
 // transactions for.
-func (c CoinbaseClient) GetTransactionHistory(accountId string) (Transaction, error) {
-	body, err := createRequest(fmt.Sprintf("accounts/%v/transactions", accountId))
+// Transactions are returned newest first. Every page of the history is fetched and flattened into the returned
+// Transaction unless `opts` sets a Limit, in which case only the most recent `Limit` transactions are returned, or
+// Since, in which case paging stops at the first transaction created before it.
+func (c CoinbaseClient) GetTransactionHistory(accountId string, opts ...ListOptions) (Transaction, error) {
+	var o ListOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+
+	pageSize := maxPageSize
+	if o.Limit > 0 && o.Limit < pageSize {
+		pageSize = o.Limit
+	}
+
+	resourcePath := fmt.Sprintf("accounts/%v/transactions?limit=%d", accountId, pageSize)
+
+	var all Transaction
+	next := ""
+	for {
+		path := resourcePath
+		if next != "" {
+			path += "&starting_after=" + next
+		}
+
+		body, err := c.createRequest(path)
+
+		if err != nil {
+			return Transaction{}, err
+		}
+
+		var t Transaction
+		err = json.Unmarshal(body, &t)
+
+		if err != nil {
+			return Transaction{}, err
+		}
+
+		older := false
+		for _, tr := range t.Data {
+			if !o.Since.IsZero() && tr.CreatedAt.Before(o.Since) {
+				older = true
+				break
+			}
+			all.Data = append(all.Data, tr)
+		}
+		all.Pagination = t.Pagination
+
+		if o.Limit > 0 && len(all.Data) >= o.Limit {
+			all.Data = all.Data[:o.Limit]
+			break
+		}
+
+		next, _ = t.Pagination.NextStartingAfter.(string)
+		if next == "" || older {
+			break
+		}
+	}
+
+	return all, nil
+}
+
+// GetBuy upon a successful API request returns the buy matching `buyID` of the account matching `accountID`,
+// including the fee Coinbase charged. An error is returned if creating or sending the request failed.
+func (c CoinbaseClient) GetBuy(accountID string, buyID string) (BuyOrder, error) {
+	body, err := c.createRequest(fmt.Sprintf("accounts/%v/buys/%v", accountID, buyID))
+
+	if err != nil {
+		return BuyOrder{}, err
+	}
+
+	var b BuyOrder
+	err = json.Unmarshal(body, &b)
+
+	if err != nil {
+		return BuyOrder{}, err
+	}
+
+	return b, nil
+}
+
+// GetSell upon a successful API request returns the sell matching `sellID` of the account matching `accountID`,
+// including the fee Coinbase charged. An error is returned if creating or sending the request failed.
+func (c CoinbaseClient) GetSell(accountID string, sellID string) (SellOrder, error) {
+	body, err := c.createRequest(fmt.Sprintf("accounts/%v/sells/%v", accountID, sellID))
+
+	if err != nil {
+		return SellOrder{}, err
+	}
+
+	var s SellOrder
+	err = json.Unmarshal(body, &s)
+
+	if err != nil {
+		return SellOrder{}, err
+	}
+
+	return s, nil
+}
+
+// PlaceSellOrder upon a successful API request places a sell order for the account matching `accountID` and
+// returns the resulting sell. An error is returned if creating or sending the request failed.
+// When `req.Commit` is false the sell is only quoted, allowing the fees and totals to be reviewed before
+// finalizing it with CommitSellOrder().
+func (c CoinbaseClient) PlaceSellOrder(accountID string, req SellRequest) (SellOrder, error) {
+	body, err := c.sendRequest("POST", fmt.Sprintf("accounts/%v/sells", accountID), req, nil)
 
 	if err != nil {
-		return Transaction{}, err
+		return SellOrder{}, err
 	}
 
-	var t Transaction
+	var s SellOrder
+	err = json.Unmarshal(body, &s)
+
+	if err != nil {
+		return SellOrder{}, err
+	}
+
+	return s, nil
+}
+
+// CommitSellOrder upon a successful API request commits a previously placed, uncommitted sell order and
+// returns the finalized sell. An error is returned if creating or sending the request failed.
+func (c CoinbaseClient) CommitSellOrder(accountID string, sellID string) (SellOrder, error) {
+	body, err := c.sendRequest("POST", fmt.Sprintf("accounts/%v/sells/%v/commit", accountID, sellID), nil, nil)
+
+	if err != nil {
+		return SellOrder{}, err
+	}
+
+	var s SellOrder
+	err = json.Unmarshal(body, &s)
+
+	if err != nil {
+		return SellOrder{}, err
+	}
+
+	return s, nil
+}
+
+// SendMoney upon a successful API request sends crypto currency from the account matching `accountID` to an
+// external address or email and returns the created transaction. An error is returned if creating or sending
+// the request failed.
+// Setting `req.Idem` makes retries safe, Coinbase will not send the funds twice for the same idempotency key.
+// If the account is protected by two factor authentication the request is rejected until it is repeated with
+// `req.TwoFactorToken` set.
+func (c CoinbaseClient) SendMoney(accountID string, req SendRequest) (SendTransaction, error) {
+	payload := struct {
+		Type string `json:"type"`
+		SendRequest
+	}{Send, req}
+
+	var headers map[string]string
+	if req.TwoFactorToken != "" {
+		headers = map[string]string{"CB-2FA-TOKEN": req.TwoFactorToken}
+	}
+
+	body, err := c.sendRequest("POST", fmt.Sprintf("accounts/%v/transactions", accountID), payload, headers)
+
+	if err != nil {
+		return SendTransaction{}, err
+	}
+
+	var t SendTransaction
 	err = json.Unmarshal(body, &t)
 
 	if err != nil {
-		return Transaction{}, nil
+		return SendTransaction{}, err
 	}
 
 	return t, nil
 }
 
+// GetAddresses upon a successful API request returns the receive addresses of the account matching `accountID`.
+// An error is returned if creating or sending the request failed.
+func (c CoinbaseClient) GetAddresses(accountID string) (Addresses, error) {
+	body, err := c.createRequest(fmt.Sprintf("accounts/%v/addresses", accountID))
+
+	if err != nil {
+		return Addresses{}, err
+	}
+
+	var a Addresses
+	err = json.Unmarshal(body, &a)
+
+	if err != nil {
+		return Addresses{}, err
+	}
+
+	return a, nil
+}
+
+// CreateAddress upon a successful API request creates a new receive address for the account matching `accountID`
+// and returns it. An error is returned if creating or sending the request failed. The `name` parameter is an
+// optional label for the address.
+func (c CoinbaseClient) CreateAddress(accountID string, name string) (Address, error) {
+	payload := struct {
+		Name string `json:"name,omitempty"`
+	}{name}
+
+	body, err := c.sendRequest("POST", fmt.Sprintf("accounts/%v/addresses", accountID), payload, nil)
+
+	if err != nil {
+		return Address{}, err
+	}
+
+	var a Address
+	err = json.Unmarshal(body, &a)
+
+	if err != nil {
+		return Address{}, err
+	}
+
+	return a, nil
+}
+
+// GetAuthInfo upon a successful API request returns the authentication method and scopes of the current
+// credentials. An error is returned if creating or sending the request failed.
+func (c CoinbaseClient) GetAuthInfo() (AuthInfo, error) {
+	body, err := c.createRequest("user/auth")
+
+	if err != nil {
+		return AuthInfo{}, err
+	}
+
+	var a AuthInfo
+	err = json.Unmarshal(body, &a)
+
+	if err != nil {
+		return AuthInfo{}, err
+	}
+
+	return a, nil
+}
+
+// GetPaymentMethods upon a successful API request returns the payment methods linked to the user's account.
+// An error is returned if creating or sending the request failed.
+func (c CoinbaseClient) GetPaymentMethods() (PaymentMethods, error) {
+	body, err := c.createRequest("payment-methods")
+
+	if err != nil {
+		return PaymentMethods{}, err
+	}
+
+	var pm PaymentMethods
+	err = json.Unmarshal(body, &pm)
+
+	if err != nil {
+		return PaymentMethods{}, err
+	}
+
+	return pm, nil
+}
+
+// Deposit upon a successful API request deposits fiat from a payment method into the fiat account matching
+// `accountID` and returns the resulting deposit. An error is returned if creating or sending the request failed.
+// When `req.Commit` is false the deposit is only quoted and must be finalized with CommitDeposit().
+func (c CoinbaseClient) Deposit(accountID string, req TransferRequest) (Transfer, error) {
+	return c.createTransfer(fmt.Sprintf("accounts/%v/deposits", accountID), req)
+}
+
+// CommitDeposit upon a successful API request commits a previously quoted deposit and returns the finalized
+// deposit. An error is returned if creating or sending the request failed.
+func (c CoinbaseClient) CommitDeposit(accountID string, depositID string) (Transfer, error) {
+	return c.createTransfer(fmt.Sprintf("accounts/%v/deposits/%v/commit", accountID, depositID), nil)
+}
+
+// Withdraw upon a successful API request withdraws fiat from the fiat account matching `accountID` to a payment
+// method and returns the resulting withdrawal. An error is returned if creating or sending the request failed.
+// When `req.Commit` is false the withdrawal is only quoted and must be finalized with CommitWithdrawal().
+func (c CoinbaseClient) Withdraw(accountID string, req TransferRequest) (Transfer, error) {
+	return c.createTransfer(fmt.Sprintf("accounts/%v/withdrawals", accountID), req)
+}
+
+// CommitWithdrawal upon a successful API request commits a previously quoted withdrawal and returns the
+// finalized withdrawal. An error is returned if creating or sending the request failed.
+func (c CoinbaseClient) CommitWithdrawal(accountID string, withdrawalID string) (Transfer, error) {
+	return c.createTransfer(fmt.Sprintf("accounts/%v/withdrawals/%v/commit", accountID, withdrawalID), nil)
+}
+
 //
 // ────────────────────────────────────────────────────────── COIBASE METHODS ─────
 //
@@ -189,13 +588,8 @@ func (a Account) String() string {
 	tbl := table.New("Wallet Name", "Balance", "Currency").WithWriter(&buf)
 
 	for _, act := range a.Data {
-		amount, err := strconv.ParseFloat(act.Balance.Amount, 64)
-		if err != nil {
-			panic(err)
-		}
-
-		if amount > 0 {
-			tbl.AddRow(act.Name, fmt.Sprintf("%f", amount), act.Balance.Currency)
+		if act.Balance.IsPositive() {
+			tbl.AddRow(act.Name, act.Balance.Amount.StringFixed(6), act.Balance.Currency)
 		}
 	}
 	tbl.Print()
@@ -204,29 +598,35 @@ func (a Account) String() string {
 }
 
 // ExchangeRate.String() is a stringer function for a coinbase ExchangeRate object.
-// It only displays a subset of information about accounts.
+// It lists the rates sorted by currency.
 func (e ExchangeRate) String() string {
-	data := e["data"].(map[string]interface{})
-	currency := data["currency"].(string)
-	rates := data["rates"].(map[string]interface{})
-
 	var buf bytes.Buffer
 	table.DefaultHeaderFormatter = func(format string, vals ...interface{}) string {
 		return strings.ToUpper(fmt.Sprintf(format, vals...))
 	}
 	tbl := table.New("Currency", "Crypto", "Rate").WithWriter(&buf)
 
-	for k, v := range rates {
-		tbl.AddRow(currency, k, v.(string))
+	for _, k := range e.Currencies() {
+		tbl.AddRow(e.Data.Currency, k, e.Data.Rates[k].String())
 	}
 	tbl.Print()
 	return buf.String()
 }
 
+// ExchangeRate.Currencies() returns the currencies with a rate in alphabetical order.
+func (e ExchangeRate) Currencies() []string {
+	var currencies []string
+	for k := range e.Data.Rates {
+		currencies = append(currencies, k)
+	}
+	sort.Strings(currencies)
+
+	return currencies
+}
+
 // SpotPrice.String() is a stringer function for a coinbase SpotPrice object.
 func (p Price) String() string {
-	amt, _ := strconv.ParseFloat(p.Data.Amount, 64)
-	return fmt.Sprintf("%s: %.2f %s", p.Data.Base, amt, p.Data.Currency)
+	return fmt.Sprintf("%s: %s", p.Data.Base, p.Data.StringFixed(2))
 }
 
 // Transaction.String() is a stringer function for a coinbase Transaction object.
@@ -240,16 +640,100 @@ func (tr Transaction) String() string {
 	tbl := table.New("Transaction Type", "Crypto", "Amount", "Native Curreny", "Amount", "Date", "Payment Method", "Summary").WithWriter(&buf)
 
 	for _, t := range tr.Data {
-		cAmt, _ := strconv.ParseFloat(t.Amount.Amount, 64)
-		ncAmt, _ := strconv.ParseFloat(t.NativeAmount.Amount, 64)
+		tbl.AddRow(t.Type, t.Amount.Currency, t.Amount.Amount, t.NativeAmount.Currency, t.NativeAmount.Amount, t.CreatedAt.Format("2006-01-02 15:04"), t.Details.PaymentMethodName, t.Details.Header)
+	}
+	tbl.Print()
+
+	return buf.String()
+}
+
+// SellOrder.String() is a stringer function for a coinbase SellOrder object.
+func (s SellOrder) String() string {
+	return fmt.Sprintf("Sell ID: %v\nStatus: %v\nCommitted: %v\nAmount: %v %v\nSubtotal: %v %v\nFee: %v %v\nTotal: %v %v\nPayout At: %v\n",
+		s.Data.ID, s.Data.Status, s.Data.Committed,
+		s.Data.Amount.Amount, s.Data.Amount.Currency,
+		s.Data.Subtotal.Amount, s.Data.Subtotal.Currency,
+		s.Data.Fee.Amount, s.Data.Fee.Currency,
+		s.Data.Total.Amount, s.Data.Total.Currency,
+		s.Data.PayoutAt.Local().Format("01-02-2006 15:04"))
+}
+
+// SendTransaction.String() is a stringer function for a coinbase SendTransaction object.
+func (s SendTransaction) String() string {
+	return fmt.Sprintf("Transaction ID: %v\nStatus: %v\nAmount: %v %v\nNative Amount: %v %v\nTo: %v\nNetwork Status: %v\n",
+		s.Data.ID, s.Data.Status,
+		s.Data.Amount.Amount, s.Data.Amount.Currency,
+		s.Data.NativeAmount.Amount, s.Data.NativeAmount.Currency,
+		s.Data.To.Address, s.Data.Network.Status)
+}
+
+// Addresses.String() is a stringer function for a coinbase Addresses object.
+func (a Addresses) String() string {
+	var buf bytes.Buffer
+	table.DefaultHeaderFormatter = func(format string, vals ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(format, vals...))
+	}
+
+	tbl := table.New("Name", "Address", "Network", "Created").WithWriter(&buf)
+
+	for _, addr := range a.Data {
+		tbl.AddRow(addr.Name, addr.Address, addr.Network, addr.CreatedAt.Local().Format("2006-01-02 15:04"))
+	}
+	tbl.Print()
+
+	return buf.String()
+}
+
+// Address.String() is a stringer function for a coinbase Address object.
+func (a Address) String() string {
+	return fmt.Sprintf("Name: %v\nAddress: %v\nNetwork: %v\nCreated: %v\n",
+		a.Data.Name, a.Data.Address, a.Data.Network, a.Data.CreatedAt.Local().Format("01-02-2006 15:04"))
+}
+
+// PaymentMethods.String() is a stringer function for a coinbase PaymentMethods object.
+func (p PaymentMethods) String() string {
+	var buf bytes.Buffer
+	table.DefaultHeaderFormatter = func(format string, vals ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(format, vals...))
+	}
+
+	tbl := table.New("Name", "Type", "Currency", "Buy", "Sell", "Deposit", "Withdraw", "ID").WithWriter(&buf)
+
+	for _, pm := range p.Data {
+		tbl.AddRow(pm.Name, pm.Type, pm.Currency, pm.AllowBuy, pm.AllowSell, pm.AllowDeposit, pm.AllowWithdraw, pm.ID)
+	}
+	tbl.Print()
+
+	return buf.String()
+}
+
+// PriceHistory.String() is a stringer function for a coinbase PriceHistory object.
+func (h PriceHistory) String() string {
+	var buf bytes.Buffer
+	table.DefaultHeaderFormatter = func(format string, vals ...interface{}) string {
+		return strings.ToUpper(fmt.Sprintf(format, vals...))
+	}
 
-		tbl.AddRow(t.Type, t.Amount.Currency, cAmt, t.NativeAmount.Currency, ncAmt, t.CreatedAt.Format("2006-01-02 15:04"), t.Details.PaymentMethodName, t.Details.Header)
+	tbl := table.New("Date", h.CurrencyPair).WithWriter(&buf)
+
+	for _, p := range h.Points {
+		tbl.AddRow(p.Time.Format("2006-01-02"), p.Price.StringFixed(2))
 	}
 	tbl.Print()
 
 	return buf.String()
 }
 
+// Transfer.String() is a stringer function for a coinbase Transfer object.
+func (t Transfer) String() string {
+	return fmt.Sprintf("Transfer ID: %v\nStatus: %v\nCommitted: %v\nAmount: %v %v\nSubtotal: %v %v\nFee: %v %v\nPayout At: %v\n",
+		t.Data.ID, t.Data.Status, t.Data.Committed,
+		t.Data.Amount.Amount, t.Data.Amount.Currency,
+		t.Data.Subtotal.Amount, t.Data.Subtotal.Currency,
+		t.Data.Fee.Amount, t.Data.Fee.Currency,
+		t.Data.PayoutAt.Local().Format("01-02-2006 15:04"))
+}
+
 //
 // ───────────────────────────────────────────────────────── STRINGER METHODS ─────
 //
@@ -257,60 +741,245 @@ func (tr Transaction) String() string {
 // ─── HELPER FUNCTIONS ───────────────────────────────────────────────────────────
 
 // createSignature returns the sha value for the CB-ACCESS-SIGN header that Coinbase requires for its API calls.
-func createSignature(r *http.Request) string {
-	timestamp := time.Now().Unix()
-	h := hmac.New(sha256.New, []byte(cbAPISecret))
-	h.Write([]byte(fmt.Sprintf("%v%v%v", timestamp, r.Method, r.URL.Path)))
+// The same `timestamp` must be sent in the CB-ACCESS-TIMESTAMP header.
+func (c CoinbaseClient) createSignature(timestamp int64, r *http.Request, body []byte) string {
+	h := hmac.New(sha256.New, []byte(c.apiSecret))
+	h.Write([]byte(prehash(timestamp, r.Method, r.URL.RequestURI(), body)))
 
 	return hex.EncodeToString(h.Sum(nil))
 }
 
+// prehash builds the string Coinbase expects to be signed: the timestamp, the upper case HTTP method, the request
+// path including any query string, and the request body.
+func prehash(timestamp int64, method string, requestPath string, body []byte) string {
+	return strconv.FormatInt(timestamp, 10) + strings.ToUpper(method) + requestPath + string(body)
+}
+
 // appendHeaders appends the Coinbase required API Headers
-func appendHeaders(r *http.Request, sig string) {
-	r.Header.Add("CB-ACCESS-KEY", cbAPIKey)
+func (c CoinbaseClient) appendHeaders(r *http.Request, sig string, timestamp int64) {
+	r.Header.Add("CB-ACCESS-KEY", c.apiKey)
 	r.Header.Add("CB-ACCESS-SIGN", sig)
-	r.Header.Add("CB-ACCESS-TIMESTAMP", fmt.Sprintf("%v", time.Now().Unix()))
+	r.Header.Add("CB-ACCESS-TIMESTAMP", strconv.FormatInt(timestamp, 10))
 	r.Header.Add("CB-VERSION", cbAPIVersion)
 	r.Header.Add("Content-Type", "application/json")
 }
 
-// createRequest sends a request to the specified resource path.
-func createRequest(resourcePath string) ([]byte, error) {
-	req, err := http.NewRequest("GET", apiEndpointBase+resourcePath, nil)
+// createRequest sends a GET request to the specified resource path, or returns the cached response set up by
+// WithResponseCache().
+func (c CoinbaseClient) createRequest(resourcePath string) ([]byte, error) {
+	if body, ok := c.cache.get(resourcePath); ok {
+		c.log(slog.LevelDebug, "cached response", "path", resourcePath)
+		return body, nil
+	}
+
+	body, err := c.sendRequest("GET", resourcePath, nil, nil)
 	if err != nil {
-		return []byte{}, err
+		return body, err
+	}
+	c.cache.put(resourcePath, body)
+
+	return body, nil
+}
+
+// sendRequest sends a request with the given method to the specified resource path. If `payload` is not nil
+// it is encoded as JSON and sent as the request body. Any `headers` are added to the request in addition to
+// the Coinbase authentication headers.
+// Requests rejected with HTTP 429 are retried after the delay in the Retry-After header, or a jittered exponential
+// backoff when the header is missing. GET requests are also retried on network errors and 5xx responses. Other
+// methods are not, since the original request may already have been carried out.
+func (c CoinbaseClient) sendRequest(method string, resourcePath string, payload interface{}, headers map[string]string) ([]byte, error) {
+	if c.err != nil {
+		return []byte{}, c.err
 	}
 
-	// fmt.Println("fetching:", apiEndpointBase+req.URL.Path)
+	var reqBody []byte
+	if payload != nil {
+		b, err := json.Marshal(payload)
+		if err != nil {
+			return []byte{}, err
+		}
+		reqBody = b
+	}
 
-	sig := createSignature(req)
-	appendHeaders(req, sig)
+	refreshed := false
+	for attempt := 0; ; attempt++ {
+		resp, body, err := c.doRequest(method, resourcePath, reqBody, headers)
+
+		// An OAuth access token may be revoked or expire early, refresh it once and try again.
+		if err == nil && resp.StatusCode == http.StatusUnauthorized && c.oauth != nil && !refreshed {
+			refreshed = true
+			if c.oauth.forceRefresh(c.httpClient) {
+				c.log(slog.LevelDebug, "refreshed the OAuth access token", "path", resourcePath)
+				attempt--
+				continue
+			}
+		}
 
-	hc := http.Client{}
-	resp, err := hc.Do(req)
+		retryable := false
+		var retryAfter time.Duration
+		switch {
+		case err != nil:
+			retryable = method == "GET"
+		case resp.StatusCode == http.StatusTooManyRequests:
+			retryable = true
+			retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+		case resp.StatusCode >= 500:
+			retryable = method == "GET"
+			retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+		}
 
+		if retryable && attempt < c.maxRetries {
+			if retryAfter <= 0 {
+				retryAfter = c.backoff(attempt)
+			}
+			args := []interface{}{"method", method, "path", resourcePath, "attempt", attempt + 1, "delay", retryAfter}
+			if err != nil {
+				args = append(args, "error", err)
+			} else {
+				args = append(args, "status", resp.StatusCode)
+			}
+			c.log(slog.LevelWarn, "retrying request", args...)
+			time.Sleep(retryAfter)
+			continue
+		}
+
+		if err != nil {
+			return []byte{}, err
+		}
+
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return []byte{}, newAPIError(resp, body)
+		}
+
+		return body, nil
+	}
+}
+
+// doRequest sends a single signed request and returns the response along with its fully read body. It waits for
+// the rate limit first.
+func (c CoinbaseClient) doRequest(method string, resourcePath string, reqBody []byte, headers map[string]string) (*http.Response, []byte, error) {
+	if d := c.limiter.wait(); d > 0 {
+		c.log(slog.LevelDebug, "waited for the rate limit", "path", resourcePath, "delay", d)
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+resourcePath, bytes.NewReader(reqBody))
 	if err != nil {
-		return []byte{}, err
+		return nil, nil, err
+	}
+
+	if c.oauth != nil {
+		token, err := c.oauth.accessToken(c.httpClient)
+		if err != nil {
+			return nil, nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("CB-VERSION", cbAPIVersion)
+		req.Header.Set("Content-Type", "application/json")
+	} else {
+		timestamp := time.Now().Unix()
+		sig := c.createSignature(timestamp, req, reqBody)
+		c.appendHeaders(req, sig, timestamp)
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+
+	if err != nil {
+		c.log(slog.LevelDebug, "request failed", "method", method, "url", c.baseURL+resourcePath, "duration", time.Since(start), "error", err)
+		return nil, nil, err
 	}
 	defer resp.Body.Close()
 
 	body, err := ioutil.ReadAll(resp.Body)
 
 	if err != nil {
-		return []byte{}, err
+		return nil, nil, err
+	}
+
+	c.log(slog.LevelDebug, "request", "method", method, "url", c.baseURL+resourcePath, "status", resp.StatusCode, "duration", time.Since(start))
+
+	if c.responseHook != nil {
+		c.responseHook(method, resourcePath, resp.StatusCode, body)
+	}
+
+	return resp, body, nil
+}
+
+// log logs `msg` with the attribute pairs `args` when the client has a logger.
+func (c CoinbaseClient) log(level slog.Level, msg string, args ...interface{}) {
+	if c.logger != nil {
+		c.logger.Log(context.Background(), level, msg, args...)
+	}
+}
+
+// backoff returns how long to wait before retry number `attempt`, counting from zero. The delay doubles with
+// every attempt up to the configured maximum and is randomized to avoid retrying in lockstep.
+func (c CoinbaseClient) backoff(attempt int) time.Duration {
+	d := c.backoffBase << uint(attempt)
+	if d <= 0 || d > c.backoffMax {
+		d = c.backoffMax
+	}
+
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either a number of seconds or an HTTP date.
+// Zero is returned when the header is empty or invalid.
+func parseRetryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
 	}
 
-	if resp.StatusCode != 200 {
-		return []byte{}, fmt.Errorf("bad HTTP status return code: %v\n%v", resp.Status, string(body))
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second
 	}
 
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t)
+	}
+
+	return 0
+}
+
+// createTransfer posts `payload` to a deposit or withdrawal resource path and parses the resulting transfer.
+func (c CoinbaseClient) createTransfer(resourcePath string, payload interface{}) (Transfer, error) {
+	body, err := c.sendRequest("POST", resourcePath, payload, nil)
+
 	if err != nil {
-		return []byte{}, err
+		return Transfer{}, err
 	}
 
-	return body, nil
+	var t Transfer
+	err = json.Unmarshal(body, &t)
+
+	if err != nil {
+		return Transfer{}, err
+	}
+
+	return t, nil
 }
 
 //
 // ───────────────────────────────────────────────────────── HELPER FUNCTIONS ─────
 //
+
+// point returns the date of the `i`th point after `start`. Each point is counted from the start rather than from
+// the previous point, so a monthly series starting on the 31st is back on the 31st after a shorter month.
+func (g Granularity) point(start time.Time, i int) time.Time {
+	switch g {
+	case Weekly:
+		return start.AddDate(0, 0, 7*i)
+	case Monthly:
+		d := start.AddDate(0, i, 0)
+		if d.Day() != start.Day() {
+			// AddDate normalizes overflowing days into the next month, step back to the last day of the month.
+			d = d.AddDate(0, 0, -d.Day())
+		}
+		return d
+	}
+
+	return start.AddDate(0, 0, i)
+}
@@ -0,0 +1,230 @@
+package coinbase_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KalebHawkins/crypto-client/coinbase"
+	"github.com/KalebHawkins/crypto-client/coinbasetest"
+)
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"amount":"0.01","currency":"BTC"}`)
+	r, err := http.NewRequest("post", "https://api.coinbase.com/v2/accounts/abc/sells?preview=true", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// HMAC-SHA256 with the key "secret" of `1617181920POST/v2/accounts/abc/sells?preview=true` followed by the body.
+	const want = "b02999075429cb3b815427ec53aed712a30776164002d1710d3caa9177a79f38"
+	if got := coinbase.Sign("secret", 1617181920, r, body); got != want {
+		t.Errorf("signature = %s, want %s", got, want)
+	}
+}
+
+func TestRequestSignature(t *testing.T) {
+	t.Setenv("COINBASE_KEY", "key")
+	t.Setenv("COINBASE_SECRET", "secret")
+
+	srv := coinbasetest.NewServer()
+	defer srv.Close()
+	c := srv.Client()
+
+	if _, err := c.GetPriceByDate("BTC-USD", time.Date(2021, time.March, 11, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.PlaceSellOrder(coinbasetest.BTCAccountID, coinbase.SellRequest{Amount: "0.01", Currency: "BTC"}); err != nil {
+		t.Fatal(err)
+	}
+
+	requests := srv.Requests()
+	if len(requests) != 2 {
+		t.Fatalf("got %d requests, want 2", len(requests))
+	}
+	if !strings.Contains(requests[0].Path, "?date=") || len(requests[1].Body) == 0 {
+		t.Fatalf("want a query and a body to be signed, got %s and %s %q", requests[0].Path, requests[1].Path, requests[1].Body)
+	}
+
+	for _, r := range requests {
+		h := hmac.New(sha256.New, []byte("secret"))
+		h.Write([]byte(r.Header.Get("CB-ACCESS-TIMESTAMP") + r.Method + r.Path + string(r.Body)))
+		want := hex.EncodeToString(h.Sum(nil))
+
+		if got := r.Header.Get("CB-ACCESS-SIGN"); got != want {
+			t.Errorf("%s %s: CB-ACCESS-SIGN = %s, want %s", r.Method, r.Path, got, want)
+		}
+		if got := r.Header.Get("CB-ACCESS-KEY"); got != "key" {
+			t.Errorf("%s %s: CB-ACCESS-KEY = %s, want key", r.Method, r.Path, got)
+		}
+	}
+}
+
+func TestGranularityDates(t *testing.T) {
+	date := func(s string) time.Time {
+		d, err := time.Parse("2006-01-02", s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+
+	tests := []struct {
+		name        string
+		granularity coinbase.Granularity
+		from, to    string
+		want        []string
+	}{
+		{name: "daily", granularity: coinbase.Daily, from: "2021-02-27", to: "2021-03-01", want: []string{"2021-02-27", "2021-02-28", "2021-03-01"}},
+		{name: "weekly", granularity: coinbase.Weekly, from: "2021-01-01", to: "2021-01-20", want: []string{"2021-01-01", "2021-01-08", "2021-01-15"}},
+		{
+			name:        "monthly from the 31st",
+			granularity: coinbase.Monthly,
+			from:        "2021-01-31",
+			to:          "2021-05-31",
+			want:        []string{"2021-01-31", "2021-02-28", "2021-03-31", "2021-04-30", "2021-05-31"},
+		},
+		{
+			name:        "monthly over a leap day",
+			granularity: coinbase.Monthly,
+			from:        "2023-12-30",
+			to:          "2024-03-30",
+			want:        []string{"2023-12-30", "2024-01-30", "2024-02-29", "2024-03-30"},
+		},
+		{name: "empty", granularity: coinbase.Daily, from: "2021-02-01", to: "2021-01-31"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, d := range tt.granularity.Dates(date(tt.from), date(tt.to)) {
+				got = append(got, d.Format("2006-01-02"))
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("got dates %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("got dates %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+// failing returns a handler answering the first `failures` requests with `status` and `header`, and every later
+// request with `body`.
+func failing(failures int, status int, header http.Header, body string) http.HandlerFunc {
+	n := 0
+	return func(w http.ResponseWriter, r *http.Request) {
+		n++
+		if n <= failures {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(status)
+			w.Write([]byte(`{"errors":[{"id":"failed","message":"failed"}]}`))
+			return
+		}
+		w.Write([]byte(body))
+	}
+}
+
+func TestSendRequestRetries(t *testing.T) {
+	const user = `{"data":{"id":"user","name":"Test"}}`
+
+	tests := []struct {
+		name     string
+		handler  http.HandlerFunc
+		requests int
+		status   int
+		minDelay time.Duration
+	}{
+		{
+			name:     "too many requests with retry-after",
+			handler:  failing(1, http.StatusTooManyRequests, http.Header{"Retry-After": {"1"}}, user),
+			requests: 2,
+			minDelay: time.Second,
+		},
+		{name: "server error then success", handler: failing(2, http.StatusBadGateway, nil, user), requests: 3},
+		{name: "server error every time", handler: failing(10, http.StatusInternalServerError, nil, user), requests: 4, status: http.StatusInternalServerError},
+		{name: "not retryable", handler: failing(1, http.StatusBadRequest, nil, user), requests: 1, status: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := coinbasetest.NewServer()
+			defer srv.Close()
+			srv.Handle(http.MethodGet, "/v2/user", tt.handler)
+
+			start := time.Now()
+			u, err := srv.Client(coinbase.WithRetries(3), coinbase.WithBackoff(time.Millisecond, time.Millisecond)).GetUserProfile()
+			elapsed := time.Since(start)
+
+			if got := len(srv.Requests()); got != tt.requests {
+				t.Errorf("got %d requests, want %d", got, tt.requests)
+			}
+			if elapsed < tt.minDelay {
+				t.Errorf("retried after %s, want at least %s", elapsed, tt.minDelay)
+			}
+
+			if tt.status == 0 {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if u.Data.ID != "user" {
+					t.Errorf("user id = %q, want user", u.Data.ID)
+				}
+				return
+			}
+
+			var apiErr *coinbase.APIError
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.status {
+				t.Errorf("error = %v, want an API error with status %d", err, tt.status)
+			}
+		})
+	}
+}
+
+func TestSendRequestRetryResendsBody(t *testing.T) {
+	srv := coinbasetest.NewServer()
+	defer srv.Close()
+	path := "/v2/accounts/" + coinbasetest.BTCAccountID + "/sells"
+	srv.Handle(http.MethodPost, path, failing(1, http.StatusTooManyRequests, nil, `{"data":{"id":"sell","status":"created"}}`))
+
+	c := srv.Client(coinbase.WithBackoff(time.Millisecond, time.Millisecond))
+	if _, err := c.PlaceSellOrder(coinbasetest.BTCAccountID, coinbase.SellRequest{Amount: "0.01", Currency: "BTC"}); err != nil {
+		t.Fatal(err)
+	}
+
+	requests := srv.Requests()
+	if len(requests) != 2 {
+		t.Fatalf("got %d requests, want 2", len(requests))
+	}
+	if len(requests[0].Body) == 0 || string(requests[1].Body) != string(requests[0].Body) {
+		t.Errorf("retried with body %q, want %q", requests[1].Body, requests[0].Body)
+	}
+}
+
+func TestSendRequestDoesNotRetryFailedPost(t *testing.T) {
+	srv := coinbasetest.NewServer()
+	defer srv.Close()
+	path := "/v2/accounts/" + coinbasetest.BTCAccountID + "/sells"
+	srv.Handle(http.MethodPost, path, failing(1, http.StatusServiceUnavailable, nil, `{"data":{"id":"sell"}}`))
+
+	c := srv.Client(coinbase.WithBackoff(time.Millisecond, time.Millisecond))
+	if _, err := c.PlaceSellOrder(coinbasetest.BTCAccountID, coinbase.SellRequest{Amount: "0.01", Currency: "BTC"}); err == nil {
+		t.Error("expected an error")
+	}
+
+	if got := len(srv.Requests()); got != 1 {
+		t.Errorf("got %d requests, want 1", got)
+	}
+}
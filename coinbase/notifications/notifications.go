@@ -0,0 +1,219 @@
+/*
+Package notifications parses and verifies the webhook notifications Coinbase sends to a notification URL.
+
+Every notification is signed by Coinbase. The CB-SIGNATURE header holds a base64 encoded RSA SHA-256 signature of
+the raw request body, which is verified against Coinbase's public key. The key is published in the Coinbase API
+documentation and has to be supplied by the caller.
+*/
+package notifications
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/KalebHawkins/crypto-client/money"
+)
+
+// SignatureHeader is the request header carrying the signature of a notification.
+const SignatureHeader = "CB-SIGNATURE"
+
+// maxBodySize limits the size of a notification accepted by a Handler.
+const maxBodySize = 1 << 20
+
+// These constants are the notification types Coinbase sends.
+const (
+	Ping                  = "ping"
+	NewPayment            = "wallet:addresses:new-payment"
+	BuyCreated            = "wallet:buys:created"
+	BuyCompleted          = "wallet:buys:completed"
+	BuyCanceled           = "wallet:buys:canceled"
+	SellCreated           = "wallet:sells:created"
+	SellCompleted         = "wallet:sells:completed"
+	SellCanceled          = "wallet:sells:canceled"
+	DepositCreated        = "wallet:deposit:created"
+	DepositCompleted      = "wallet:deposit:completed"
+	DepositCanceled       = "wallet:deposit:canceled"
+	WithdrawalCreated     = "wallet:withdrawal:created"
+	WithdrawalCompleted   = "wallet:withdrawal:completed"
+	WithdrawalCanceled    = "wallet:withdrawal:canceled"
+	MerchantPaymentSent   = "wallet:merchant-payment:sent"
+	MerchantPaymentFailed = "wallet:merchant-payment:failed"
+)
+
+// ErrInvalidSignature is returned when a notification was not signed by the configured key.
+var ErrInvalidSignature = errors.New("notifications: invalid signature")
+
+// Notification is a webhook notification sent by Coinbase. The resource the notification is about is kept raw in
+// Data because its shape depends on Type.
+type Notification struct {
+	ID               string          `json:"id"`
+	Type             string          `json:"type"`
+	Data             json.RawMessage `json:"data"`
+	AdditionalData   json.RawMessage `json:"additional_data"`
+	DeliveryAttempts int             `json:"delivery_attempts"`
+	CreatedAt        time.Time       `json:"created_at"`
+	Resource         string          `json:"resource"`
+	ResourcePath     string          `json:"resource_path"`
+	User             Reference       `json:"user"`
+	Account          Reference       `json:"account"`
+}
+
+// Reference points at another Coinbase resource.
+type Reference struct {
+	ID           string `json:"id"`
+	Resource     string `json:"resource"`
+	ResourcePath string `json:"resource_path"`
+}
+
+// Payment is the additional data of a NewPayment notification.
+type Payment struct {
+	Hash        string      `json:"hash"`
+	Amount      money.Money `json:"amount"`
+	Transaction Reference   `json:"transaction"`
+}
+
+// Parse parses the body of a notification without verifying it.
+func Parse(body []byte) (Notification, error) {
+	var n Notification
+	if err := json.Unmarshal(body, &n); err != nil {
+		return Notification{}, fmt.Errorf("notifications: invalid notification: %v", err)
+	}
+
+	return n, nil
+}
+
+// Payment returns the payment received by an address. It is only valid for NewPayment notifications.
+func (n Notification) Payment() (Payment, error) {
+	if n.Type != NewPayment {
+		return Payment{}, fmt.Errorf("notifications: %s notification carries no payment", n.Type)
+	}
+
+	var p Payment
+	if err := json.Unmarshal(n.AdditionalData, &p); err != nil {
+		return Payment{}, err
+	}
+
+	return p, nil
+}
+
+// DecodeData decodes the resource the notification is about into `v`, for example a coinbase.TransactionData.
+func (n Notification) DecodeData(v interface{}) error {
+	return json.Unmarshal(n.Data, v)
+}
+
+// Verifier checks that notifications were signed by Coinbase.
+type Verifier struct {
+	key *rsa.PublicKey
+}
+
+// NewVerifier returns a Verifier for the PEM encoded RSA public key `publicKey`.
+func NewVerifier(publicKey []byte) (*Verifier, error) {
+	block, _ := pem.Decode(publicKey)
+	if block == nil {
+		return nil, errors.New("notifications: public key is not PEM encoded")
+	}
+
+	k, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("notifications: invalid public key: %v", err)
+	}
+
+	key, ok := k.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("notifications: public key is not an RSA key")
+	}
+
+	return &Verifier{key: key}, nil
+}
+
+// Verify returns ErrInvalidSignature unless `signature`, the value of the CB-SIGNATURE header, is a valid signature
+// of `body`.
+func (v *Verifier) Verify(body []byte, signature string) error {
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	digest := sha256.Sum256(body)
+	if rsa.VerifyPKCS1v15(v.key, crypto.SHA256, digest[:], sig) != nil {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// Handler is an http.Handler receiving notifications. Every request is verified and parsed before it is
+// dispatched to the functions registered for its type. Requests with an invalid signature are rejected with
+// 401 Unauthorized and never dispatched.
+type Handler struct {
+	verifier *Verifier
+
+	mu       sync.RWMutex
+	handlers map[string][]func(Notification)
+	fallback []func(Notification)
+}
+
+// NewHandler returns a Handler that verifies notifications with `v`.
+func NewHandler(v *Verifier) *Handler {
+	return &Handler{verifier: v, handlers: map[string][]func(Notification){}}
+}
+
+// On registers `fn` to be called for notifications of type `notificationType`, for example NewPayment.
+func (h *Handler) On(notificationType string, fn func(Notification)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.handlers[notificationType] = append(h.handlers[notificationType], fn)
+}
+
+// OnAny registers `fn` to be called for every notification.
+func (h *Handler) OnAny(fn func(Notification)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.fallback = append(h.fallback, fn)
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		http.Error(w, "invalid body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.verifier.Verify(body, r.Header.Get(SignatureHeader)); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	n, err := Parse(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	h.mu.RLock()
+	fns := append(append([]func(Notification){}, h.handlers[n.Type]...), h.fallback...)
+	h.mu.RUnlock()
+
+	for _, fn := range fns {
+		fn(n)
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,244 @@
+package coinbase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Coinbase OAuth2 endpoints.
+const (
+	oauthAuthorizeURL = "https://www.coinbase.com/oauth/authorize"
+	oauthTokenURL     = "https://api.coinbase.com/oauth/token"
+	oauthRevokeURL    = "https://api.coinbase.com/oauth/revoke"
+)
+
+// Token is an OAuth2 token issued by Coinbase, for example through Coinbase Connect.
+type Token struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	TokenType    string    `json:"token_type,omitempty"`
+	Scope        string    `json:"scope,omitempty"`
+	ExpiresIn    int       `json:"expires_in,omitempty"`
+	Expiry       time.Time `json:"expiry,omitempty"`
+}
+
+// OAuthConfig holds the OAuth2 application credentials needed to refresh access tokens.
+type OAuthConfig struct {
+	ClientID     string
+	ClientSecret string
+	// TokenURL defaults to https://api.coinbase.com/oauth/token when empty.
+	TokenURL string
+	// OnRefresh, when set, is called with every newly issued token so it can be persisted.
+	OnRefresh func(Token)
+}
+
+// oauthState is shared between copies of a CoinbaseClient so a refreshed token is seen by all of them.
+type oauthState struct {
+	mu     sync.Mutex
+	token  Token
+	config OAuthConfig
+}
+
+// OAuthClient creates a client that authenticates with an OAuth2 bearer token instead of an API key, for apps
+// acting on behalf of other users. Pass WithOAuthConfig() to have expired access tokens refreshed automatically.
+func OAuthClient(token Token, opts ...Option) CoinbaseClient {
+	c := APIKeyClient(opts...)
+	c.apiKey = ""
+	c.apiSecret = ""
+
+	if c.oauth == nil {
+		c.oauth = &oauthState{}
+	}
+	c.oauth.token = token
+
+	return c
+}
+
+// WithOAuthConfig sets the OAuth2 application credentials used by OAuthClient() to refresh access tokens.
+func WithOAuthConfig(cfg OAuthConfig) Option {
+	return func(c *CoinbaseClient) {
+		if cfg.TokenURL == "" {
+			cfg.TokenURL = oauthTokenURL
+		}
+		if c.oauth == nil {
+			c.oauth = &oauthState{}
+		}
+		c.oauth.config = cfg
+	}
+}
+
+// AuthorizeURL returns the Coinbase page where the user grants the application `cfg` the given `scopes`. After
+// consenting the user is redirected to `redirectURI` with a code to pass to ExchangeCode() and `state`, which the
+// caller should check to guard against forged redirects.
+func AuthorizeURL(cfg OAuthConfig, redirectURI string, state string, scopes []string) string {
+	q := url.Values{
+		"response_type": {"code"},
+		"client_id":     {cfg.ClientID},
+		"redirect_uri":  {redirectURI},
+		"state":         {state},
+		"scope":         {strings.Join(scopes, ",")},
+	}
+
+	return oauthAuthorizeURL + "?" + q.Encode()
+}
+
+// ExchangeCode exchanges the authorization code received on `redirectURI` for a token. An error is returned if
+// sending the request failed or Coinbase rejected the code.
+func ExchangeCode(cfg OAuthConfig, code string, redirectURI string) (Token, error) {
+	if cfg.TokenURL == "" {
+		cfg.TokenURL = oauthTokenURL
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"client_id":     {cfg.ClientID},
+		"client_secret": {cfg.ClientSecret},
+		"redirect_uri":  {redirectURI},
+	}
+
+	return requestToken(http.DefaultClient, cfg.TokenURL, form)
+}
+
+// RevokeToken revokes the access token of an OAuth client, which also invalidates its refresh token. An error is
+// returned for API key clients or if Coinbase rejected the request.
+func (c CoinbaseClient) RevokeToken() error {
+	if c.oauth == nil {
+		return errors.New("revoking OAuth token failed: client does not use OAuth")
+	}
+
+	c.oauth.mu.Lock()
+	token := c.oauth.token.AccessToken
+	c.oauth.mu.Unlock()
+
+	req, err := http.NewRequest("POST", oauthRevokeURL, strings.NewReader(url.Values{"token": {token}}.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("revoking OAuth token failed: %v\n%v", resp.Status, string(body))
+	}
+
+	return nil
+}
+
+// Token returns the current OAuth2 token of the client, which may have been refreshed since the client was
+// created. The zero Token is returned for API key clients.
+func (c CoinbaseClient) Token() Token {
+	if c.oauth == nil {
+		return Token{}
+	}
+
+	c.oauth.mu.Lock()
+	defer c.oauth.mu.Unlock()
+
+	return c.oauth.token
+}
+
+// accessToken returns a valid access token, refreshing it first when it is about to expire.
+func (s *oauthState) accessToken(hc *http.Client) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.token.Expiry.IsZero() && time.Until(s.token.Expiry) < time.Minute && s.canRefresh() {
+		if err := s.refresh(hc); err != nil {
+			return "", err
+		}
+	}
+
+	return s.token.AccessToken, nil
+}
+
+// forceRefresh refreshes the access token regardless of its expiry, for example after the API rejected it.
+// It reports whether a new token was obtained.
+func (s *oauthState) forceRefresh(hc *http.Client) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.canRefresh() {
+		return false
+	}
+
+	return s.refresh(hc) == nil
+}
+
+// canRefresh reports whether there is enough information to refresh the token. The lock must be held.
+func (s *oauthState) canRefresh() bool {
+	return s.token.RefreshToken != "" && s.config.ClientID != ""
+}
+
+// refresh exchanges the refresh token for a new token. The lock must be held.
+func (s *oauthState) refresh(hc *http.Client) error {
+	form := url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {s.token.RefreshToken},
+		"client_id":     {s.config.ClientID},
+		"client_secret": {s.config.ClientSecret},
+	}
+
+	t, err := requestToken(hc, s.config.TokenURL, form)
+	if err != nil {
+		return err
+	}
+
+	if t.RefreshToken == "" {
+		t.RefreshToken = s.token.RefreshToken
+	}
+
+	s.token = t
+	if s.config.OnRefresh != nil {
+		s.config.OnRefresh(t)
+	}
+
+	return nil
+}
+
+// requestToken posts `form` to the token endpoint `tokenURL` and parses the issued token.
+func requestToken(hc *http.Client, tokenURL string, form url.Values) (Token, error) {
+	resp, err := hc.Post(tokenURL, "application/x-www-form-urlencoded", strings.NewReader(form.Encode()))
+	if err != nil {
+		return Token{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return Token{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return Token{}, fmt.Errorf("requesting OAuth token failed: %v\n%v", resp.Status, string(body))
+	}
+
+	var t Token
+	if err := json.Unmarshal(body, &t); err != nil {
+		return Token{}, err
+	}
+
+	if t.AccessToken == "" {
+		return Token{}, errors.New("requesting OAuth token failed: no access token in response")
+	}
+
+	if t.ExpiresIn > 0 {
+		t.Expiry = time.Now().Add(time.Duration(t.ExpiresIn) * time.Second)
+	}
+
+	return t, nil
+}
@@ -0,0 +1,196 @@
+package coinbase
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Option configures a CoinbaseClient when it is created with APIKeyClient().
+type Option func(*CoinbaseClient)
+
+// WithHTTPClient makes the client send its requests using `hc`, for example to route them through a proxy or
+// to use a custom TLS configuration. WithProxy(), WithRootCAs() and WithConnectTimeout() passed after it configure a
+// copy of its *http.Transport. A RoundTripper wrapping that transport is kept when it has the methods
+// `Unwrap() http.RoundTripper` and `WithBase(base http.RoundTripper) http.RoundTripper`, otherwise every request
+// fails.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *CoinbaseClient) {
+		c.httpClient = hc
+	}
+}
+
+// WithProxy sends the requests of the client through the proxy at `proxyURL`, for example
+// http://proxy.example.com:3128 or socks5://localhost:1080. By default the proxy is taken from the HTTPS_PROXY and
+// NO_PROXY environment variables.
+func WithProxy(proxyURL *url.URL) Option {
+	return func(c *CoinbaseClient) {
+		t := c.transport()
+		t.Proxy = http.ProxyURL(proxyURL)
+	}
+}
+
+// WithRootCAs makes the client trust the certificate authorities in `pool` instead of those of the system, for
+// example to include the CA of a TLS intercepting corporate proxy. Add the system pool from x509.SystemCertPool() to
+// `pool` to trust both.
+func WithRootCAs(pool *x509.CertPool) Option {
+	return func(c *CoinbaseClient) {
+		t := c.transport()
+		if t.TLSClientConfig == nil {
+			t.TLSClientConfig = &tls.Config{}
+		}
+		t.TLSClientConfig.RootCAs = pool
+	}
+}
+
+// WithTimeout sets the time limit for each request made by the client, including reading the response body. The
+// default is 30 seconds, zero disables the limit. The http.Client passed to WithHTTPClient() is copied rather than
+// modified, its own timeout applies when WithTimeout() is not passed after it.
+func WithTimeout(d time.Duration) Option {
+	return func(c *CoinbaseClient) {
+		hc := *c.httpClient
+		hc.Timeout = d
+		c.httpClient = &hc
+	}
+}
+
+// WithConnectTimeout sets the time limit for connecting to the API, including the TLS handshake, so an unreachable
+// host fails fast while slow responses still get the time set by WithTimeout(). The default is that of
+// http.DefaultTransport.
+func WithConnectTimeout(d time.Duration) Option {
+	return func(c *CoinbaseClient) {
+		t := c.transport()
+		t.DialContext = (&net.Dialer{Timeout: d, KeepAlive: 30 * time.Second}).DialContext
+		t.TLSHandshakeTimeout = d
+	}
+}
+
+// WithBaseURL points the client at a different API endpoint, for example a test server. The URL should include
+// the version path such as https://api.coinbase.com/v2/.
+func WithBaseURL(baseURL string) Option {
+	return func(c *CoinbaseClient) {
+		if !strings.HasSuffix(baseURL, "/") {
+			baseURL += "/"
+		}
+		c.baseURL = baseURL
+	}
+}
+
+// WithRetries sets how many times a failed request is retried. Zero disables retries.
+func WithRetries(n int) Option {
+	return func(c *CoinbaseClient) {
+		c.maxRetries = n
+	}
+}
+
+// WithBackoff sets the delay before the first retry and the maximum delay between retries. The delay doubles
+// after every attempt until it reaches `max`.
+func WithBackoff(base time.Duration, max time.Duration) Option {
+	return func(c *CoinbaseClient) {
+		c.backoffBase = base
+		c.backoffMax = max
+	}
+}
+
+// WithRateLimit limits the client to `perSecond` requests per second on average, allowing bursts of up to `burst`
+// requests. The limit is shared by every goroutine using the client and applies to retries as well. The default is
+// 10 requests per second, zero disables the limit.
+func WithRateLimit(perSecond float64, burst int) Option {
+	return func(c *CoinbaseClient) {
+		c.limiter = newLimiter(perSecond, burst)
+	}
+}
+
+// WithResponseCache reuses the responses to price and exchange rate lookups for `ttl`, so a command looking up the
+// same pair several times sends one request. The cache is shared by every goroutine using the client. Responses are
+// not cached by default.
+func WithResponseCache(ttl time.Duration) Option {
+	return func(c *CoinbaseClient) {
+		c.cache = newResponseCache(ttl)
+	}
+}
+
+// WithLogger logs every request the client sends to `logger` at debug level, along with its status and duration,
+// and retries and waits for the rate limit at warn and debug level. Nothing is logged by default.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *CoinbaseClient) {
+		c.logger = logger
+	}
+}
+
+// WithSandbox points the client at the Coinbase sandbox environment so buy and sell flows can be developed and
+// tested without touching real funds. Sandbox credentials are separate from production ones.
+func WithSandbox() Option {
+	return WithBaseURL(sandboxEndpoint)
+}
+
+// ResponseHook is called with the raw body of every response the client receives, including error responses and
+// responses to requests that are retried. `path` is the request path relative to the API version, including any
+// query string.
+type ResponseHook func(method string, path string, status int, body []byte)
+
+// WithResponseHook calls `hook` for every response the client receives, for example to archive the raw API data.
+// The hook may be called concurrently when the client is shared between goroutines.
+func WithResponseHook(hook ResponseHook) Option {
+	return func(c *CoinbaseClient) {
+		c.responseHook = hook
+	}
+}
+
+// wrappingTransport is a RoundTripper sending its requests through another one, such as a transport logging or
+// altering the requests. WithProxy(), WithRootCAs() and WithConnectTimeout() configure the innermost *http.Transport
+// of such transports and keep the wrappers.
+type wrappingTransport interface {
+	http.RoundTripper
+	// Unwrap returns the RoundTripper the requests are sent through.
+	Unwrap() http.RoundTripper
+	// WithBase returns a copy of the transport sending its requests through `base` instead.
+	WithBase(base http.RoundTripper) http.RoundTripper
+}
+
+// transport gives the client an *http.Transport of its own to configure and returns it. The transport of the
+// http.Client passed to WithHTTPClient(), or http.DefaultTransport, is copied rather than modified, along with any
+// wrappingTransport around it. If the innermost transport is not an *http.Transport, or is wrapped by a RoundTripper
+// that is not a wrappingTransport, the transport returned is not used and every request of the client fails.
+func (c *CoinbaseClient) transport() *http.Transport {
+	hc := *c.httpClient
+	c.httpClient = &hc
+
+	rt := hc.Transport
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+
+	clone, t := cloneTransport(rt)
+	if t == nil {
+		c.err = fmt.Errorf("coinbase: cannot configure the %T transport of the HTTP client, it does not wrap an *http.Transport", rt)
+		return &http.Transport{}
+	}
+	c.httpClient.Transport = clone
+
+	return t
+}
+
+// cloneTransport returns a copy of `rt` and of the *http.Transport at its core, or a nil *http.Transport if there is
+// none.
+func cloneTransport(rt http.RoundTripper) (http.RoundTripper, *http.Transport) {
+	switch v := rt.(type) {
+	case *http.Transport:
+		t := v.Clone()
+		return t, t
+	case wrappingTransport:
+		base, t := cloneTransport(v.Unwrap())
+		if t == nil {
+			return rt, nil
+		}
+		return v.WithBase(base), t
+	}
+
+	return rt, nil
+}
@@ -0,0 +1,66 @@
+package coinbase_test
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/KalebHawkins/crypto-client/coinbase"
+	"github.com/KalebHawkins/crypto-client/coinbasetest"
+)
+
+// countingTransport counts the requests sent through Base.
+type countingTransport struct {
+	Base http.RoundTripper
+	n    *int32
+}
+
+func (t countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	atomic.AddInt32(t.n, 1)
+	return t.Base.RoundTrip(req)
+}
+
+// wrappingTransport is a countingTransport that can be configured through.
+type wrappingTransport struct{ countingTransport }
+
+func (t wrappingTransport) Unwrap() http.RoundTripper { return t.Base }
+
+func (t wrappingTransport) WithBase(base http.RoundTripper) http.RoundTripper {
+	return wrappingTransport{countingTransport{Base: base, n: t.n}}
+}
+
+func TestTransportOptionsKeepWrapper(t *testing.T) {
+	srv := coinbasetest.NewServer()
+	defer srv.Close()
+
+	var n int32
+	hc := &http.Client{Transport: wrappingTransport{countingTransport{Base: http.DefaultTransport, n: &n}}}
+	c := srv.Client(coinbase.WithHTTPClient(hc), coinbase.WithConnectTimeout(5*time.Second), coinbase.WithProxy(nil))
+
+	if _, err := c.GetUserProfile(); err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Errorf("got %d requests through the wrapping transport, want 1", n)
+	}
+	if _, ok := hc.Transport.(wrappingTransport); !ok {
+		t.Errorf("the transport of the HTTP client was replaced by %T", hc.Transport)
+	}
+}
+
+func TestTransportOptionsUnknownWrapper(t *testing.T) {
+	srv := coinbasetest.NewServer()
+	defer srv.Close()
+
+	var n int32
+	hc := &http.Client{Transport: countingTransport{Base: http.DefaultTransport, n: &n}}
+	c := srv.Client(coinbase.WithHTTPClient(hc), coinbase.WithConnectTimeout(5*time.Second))
+
+	if _, err := c.GetUserProfile(); err == nil {
+		t.Error("expected an error")
+	}
+	if n != 0 || len(srv.Requests()) != 0 {
+		t.Errorf("got %d requests, want none", len(srv.Requests()))
+	}
+}
@@ -0,0 +1,119 @@
+package coinbase
+
+import (
+	"sort"
+
+	"github.com/KalebHawkins/crypto-client/exchange"
+	"github.com/KalebHawkins/crypto-client/money"
+)
+
+// provider adapts a Client to exchange.Provider.
+type provider struct {
+	c Client
+}
+
+// AsProvider returns `c` as an exchange.Provider.
+func AsProvider(c Client) exchange.Provider {
+	return provider{c: c}
+}
+
+// Name implements exchange.Provider.
+func (p provider) Name() string {
+	return "Coinbase"
+}
+
+// Capabilities implements exchange.Provider. Orders are managed through Advanced Trade, which needs its own API
+// key.
+func (p provider) Capabilities() exchange.Capabilities {
+	return exchange.Capabilities{exchange.Trading, exchange.Orders, exchange.Transfers, exchange.PriceHistory,
+		exchange.Webhooks, exchange.Streaming}
+}
+
+// Balances implements exchange.Provider.
+func (p provider) Balances() ([]exchange.Balance, error) {
+	acts, err := p.c.GetAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	var balances []exchange.Balance
+	for _, a := range acts.Data {
+		if a.Balance.IsZero() {
+			continue
+		}
+		balances = append(balances, exchange.Balance{Asset: a.Balance.Currency, Amount: a.Balance.Amount})
+	}
+
+	return balances, nil
+}
+
+// Transactions implements exchange.Provider. Failed, canceled and expired transactions are left out because
+// they never moved a balance.
+func (p provider) Transactions() ([]exchange.Transaction, error) {
+	acts, err := p.c.GetAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	var transactions []exchange.Transaction
+	for _, a := range acts.Data {
+		tr, err := p.c.GetTransactionHistory(a.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, t := range tr.Data {
+			switch t.Status {
+			case "failed", "canceled", "expired":
+				continue
+			}
+
+			transactions = append(transactions, exchange.Transaction{
+				ID:          t.ID,
+				Type:        transactionType(t),
+				Time:        t.CreatedAt,
+				Amount:      t.Amount,
+				Value:       t.NativeAmount,
+				Description: t.Details.Header,
+			})
+		}
+	}
+
+	sort.SliceStable(transactions, func(i, j int) bool {
+		return transactions[i].Time.Before(transactions[j].Time)
+	})
+
+	return transactions, nil
+}
+
+// Price implements exchange.Provider.
+func (p provider) Price(pair exchange.Pair) (money.Money, error) {
+	price, err := p.c.GetPrice(pair.String(), Spot)
+	if err != nil {
+		return money.Money{}, err
+	}
+
+	return price.Data.Money, nil
+}
+
+// transactionType maps the type of a Coinbase transaction to an exchange transaction type. Sends are deposits
+// when they were received.
+func transactionType(t TransactionData) string {
+	switch t.Type {
+	case Buy:
+		return exchange.Buy
+	case Sell:
+		return exchange.Sell
+	case "fiat_deposit", "exchange_deposit":
+		return exchange.Deposit
+	case "fiat_withdrawal", "exchange_withdrawal":
+		return exchange.Withdrawal
+	case "send":
+		if t.Amount.IsPositive() {
+			return exchange.Deposit
+		}
+		return exchange.Withdrawal
+	}
+
+	return exchange.Other
+}
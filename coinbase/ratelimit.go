@@ -0,0 +1,56 @@
+package coinbase
+
+import (
+	"sync"
+	"time"
+)
+
+// limiter is a token bucket spacing out the requests of a client. It is shared by the copies of the client, so
+// goroutines using the same client share its rate.
+type limiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newLimiter returns a limiter allowing `perSecond` requests per second on average and bursts of up to `burst`
+// requests. nil is returned, disabling the limit, when `perSecond` is not positive.
+func newLimiter(perSecond float64, burst int) *limiter {
+	if perSecond <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &limiter{rate: perSecond, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+// wait blocks until a request may be sent and returns how long it waited. The token is taken right away, so
+// concurrent callers queue up in the order they called rather than racing for the next token. A nil limiter never
+// blocks.
+func (l *limiter) wait() time.Duration {
+	if l == nil {
+		return 0
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+	l.tokens--
+	delay := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return 0
+	}
+	time.Sleep(delay)
+
+	return delay
+}
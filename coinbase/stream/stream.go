@@ -0,0 +1,192 @@
+/*
+Package stream delivers live market data from the Coinbase WebSocket feed. The feed is public, no credentials are
+needed to subscribe to the ticker channel.
+
+	updates := stream.New().Ticker(ctx, "BTC-USD", "ETH-USD")
+	for t := range updates {
+		fmt.Println(t.ProductID, t.Price)
+	}
+*/
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/shopspring/decimal"
+)
+
+// feedURL is the public Coinbase WebSocket feed.
+const feedURL = "wss://ws-feed.exchange.coinbase.com"
+
+// Default connection behaviour of a Client, see its fields.
+const (
+	defaultHeartbeatTimeout = 10 * time.Second
+	defaultBackoffBase      = time.Second
+	defaultBackoffMax       = 30 * time.Second
+)
+
+// Ticker is a price update sent whenever a product trades.
+type Ticker struct {
+	Sequence  int64           `json:"sequence"`
+	ProductID string          `json:"product_id"`
+	Price     decimal.Decimal `json:"price"`
+	Open24h   decimal.Decimal `json:"open_24h"`
+	Volume24h decimal.Decimal `json:"volume_24h"`
+	Low24h    decimal.Decimal `json:"low_24h"`
+	High24h   decimal.Decimal `json:"high_24h"`
+	BestBid   decimal.Decimal `json:"best_bid"`
+	BestAsk   decimal.Decimal `json:"best_ask"`
+	Side      string          `json:"side"`
+	Time      time.Time       `json:"time"`
+	TradeID   int64           `json:"trade_id"`
+	LastSize  decimal.Decimal `json:"last_size"`
+}
+
+// Client subscribes to the Coinbase WebSocket feed. The zero value is not usable, create one with New().
+type Client struct {
+	// URL is the feed to connect to.
+	URL string
+	// HeartbeatTimeout is how long the connection may stay silent before it is considered dead and reconnected.
+	// The heartbeat channel is subscribed alongside every other channel so a healthy connection is never silent
+	// for more than a second.
+	HeartbeatTimeout time.Duration
+	// BackoffBase and BackoffMax bound the delay between reconnection attempts. The delay doubles after every
+	// failed attempt and is reset once a connection delivers data.
+	BackoffBase time.Duration
+	BackoffMax  time.Duration
+	// OnError, when set, is called with every connection error before reconnecting.
+	OnError func(error)
+	// Dialer is used to open connections.
+	Dialer *websocket.Dialer
+}
+
+// New returns a Client for the public Coinbase feed.
+func New() *Client {
+	return &Client{
+		URL:              feedURL,
+		HeartbeatTimeout: defaultHeartbeatTimeout,
+		BackoffBase:      defaultBackoffBase,
+		BackoffMax:       defaultBackoffMax,
+		Dialer:           websocket.DefaultDialer,
+	}
+}
+
+// Ticker subscribes to the ticker channel of `productIDs` and delivers every update on the returned channel.
+// Dropped connections are reconnected automatically. The channel is closed once `ctx` is done.
+// Updates are delivered in order per connection; a slow reader delays the stream rather than losing updates.
+func (c *Client) Ticker(ctx context.Context, productIDs ...string) <-chan Ticker {
+	out := make(chan Ticker)
+
+	go func() {
+		defer close(out)
+
+		attempt := 0
+		for ctx.Err() == nil {
+			delivered, err := c.run(ctx, productIDs, out)
+			if ctx.Err() != nil {
+				return
+			}
+
+			if c.OnError != nil {
+				c.OnError(err)
+			}
+
+			if delivered {
+				attempt = 0
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(c.backoff(attempt)):
+			}
+			attempt++
+		}
+	}()
+
+	return out
+}
+
+// message is the envelope shared by every message of the feed.
+type message struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+	Reason  string `json:"reason"`
+}
+
+// run holds a single connection open until it fails or `ctx` is done. It reports whether any update was
+// delivered over the connection.
+func (c *Client) run(ctx context.Context, productIDs []string, out chan<- Ticker) (bool, error) {
+	conn, _, err := c.Dialer.DialContext(ctx, c.URL, nil)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	// Unblock ReadMessage when the context is canceled.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	sub := map[string]interface{}{
+		"type":        "subscribe",
+		"product_ids": productIDs,
+		"channels":    []string{"ticker", "heartbeat"},
+	}
+	if err := conn.WriteJSON(sub); err != nil {
+		return false, err
+	}
+
+	delivered := false
+	for {
+		conn.SetReadDeadline(time.Now().Add(c.HeartbeatTimeout))
+
+		_, b, err := conn.ReadMessage()
+		if err != nil {
+			return delivered, err
+		}
+
+		var m message
+		if err := json.Unmarshal(b, &m); err != nil {
+			return delivered, err
+		}
+
+		switch m.Type {
+		case "error":
+			return delivered, fmt.Errorf("stream: %s: %s", m.Message, m.Reason)
+		case "ticker":
+			var t Ticker
+			if err := json.Unmarshal(b, &t); err != nil {
+				return delivered, err
+			}
+
+			select {
+			case out <- t:
+				delivered = true
+			case <-ctx.Done():
+				return delivered, ctx.Err()
+			}
+		}
+	}
+}
+
+// backoff returns how long to wait before reconnection attempt `attempt`, counting from zero.
+func (c *Client) backoff(attempt int) time.Duration {
+	d := c.BackoffBase << uint(attempt)
+	if d <= 0 || d > c.BackoffMax {
+		d = c.BackoffMax
+	}
+
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
@@ -1,14 +1,41 @@
 package coinbase
 
 import (
+	"log/slog"
+	"net/http"
 	"time"
+
+	"github.com/KalebHawkins/crypto-client/money"
+	"github.com/shopspring/decimal"
 )
 
 var (
-	cbAPIKey        string
-	cbAPISecret     string
 	cbAPIVersion    string = "2017-08-31"
 	apiEndpointBase string = "https://api.coinbase.com/v2/"
+	sandboxEndpoint string = "https://api.sandbox.coinbase.com/v2/"
+	maxPageSize     int    = 100
+
+	// maxHistoryLookups is the number of concurrent price lookups made by GetPriceHistory().
+	maxHistoryLookups int = 8
+
+	// maxPriceLookups is the number of concurrent price lookups made by GetPrices().
+	maxPriceLookups int = 8
+)
+
+// Default retry behaviour of a client, see WithRetries() and WithBackoff().
+const (
+	defaultMaxRetries  = 3
+	defaultBackoffBase = 500 * time.Millisecond
+	defaultBackoffMax  = 30 * time.Second
+)
+
+// defaultTimeout is the time limit of a request, see WithTimeout().
+const defaultTimeout = 30 * time.Second
+
+// Default request rate of a client, see WithRateLimit(). Coinbase allows an API key about 10 requests per second.
+const (
+	defaultRateLimit = 10
+	defaultRateBurst = 10
 )
 
 // These constants are used to map the types of prices that can be used to pass to the
@@ -18,9 +45,65 @@ const (
 	Sell            string = "sell"
 	Spot            string = "spot"
 	InflationReward string = "inflation_reward"
+	Send            string = "send"
 )
 
-type CoinbaseClient struct{}
+// Client is implemented by CoinbaseClient. Code that depends on Client rather than CoinbaseClient can be tested
+// against a fake implementation or a coinbasetest.Server.
+type Client interface {
+	GetUserProfile() (User, error)
+	GetAuthInfo() (AuthInfo, error)
+	GetAccount() (Account, error)
+	GetExchangeRate(currency string) (ExchangeRate, error)
+	GetPrice(currencyPair string, priceType string) (Price, error)
+	GetPrices(pairs []string, priceType string) (map[string]Price, error)
+	GetPriceByDate(currencyPair string, year time.Time) (Price, error)
+	GetPriceHistory(currencyPair string, from, to time.Time, granularity Granularity) (PriceHistory, error)
+	GetTransactionHistory(accountId string, opts ...ListOptions) (Transaction, error)
+	GetPaymentMethods() (PaymentMethods, error)
+	GetAddresses(accountID string) (Addresses, error)
+	CreateAddress(accountID string, name string) (Address, error)
+	GetBuy(accountID string, buyID string) (BuyOrder, error)
+	GetSell(accountID string, sellID string) (SellOrder, error)
+	PlaceSellOrder(accountID string, req SellRequest) (SellOrder, error)
+	CommitSellOrder(accountID string, sellID string) (SellOrder, error)
+	SendMoney(accountID string, req SendRequest) (SendTransaction, error)
+	Deposit(accountID string, req TransferRequest) (Transfer, error)
+	CommitDeposit(accountID string, depositID string) (Transfer, error)
+	Withdraw(accountID string, req TransferRequest) (Transfer, error)
+	CommitWithdrawal(accountID string, withdrawalID string) (Transfer, error)
+}
+
+var _ Client = CoinbaseClient{}
+
+// CoinbaseClient is used to query the Coinbase API. Create one with APIKeyClient() or OAuthClient().
+type CoinbaseClient struct {
+	apiKey     string
+	apiSecret  string
+	baseURL    string
+	httpClient *http.Client
+	oauth      *oauthState
+
+	responseHook ResponseHook
+
+	maxRetries  int
+	backoffBase time.Duration
+	backoffMax  time.Duration
+
+	limiter *limiter
+	cache   *responseCache
+	logger  *slog.Logger
+
+	// err is the error of an option that could not be applied, every request fails with it.
+	err error
+}
+
+// ListOptions controls how much of a paginated list is fetched. A zero Limit fetches every page. A non-zero Since
+// only returns the items created at or after it, so paging stops at the first older item.
+type ListOptions struct {
+	Limit int
+	Since time.Time
+}
 
 // User is a structure containing user profile information parsed from the https://api.coinbase.com/v2/user api endpoint path.
 type User struct {
@@ -85,69 +168,62 @@ type Account struct {
 		NextURI       interface{} `json:"next_uri"`
 	} `json:"pagination"`
 	Data []struct {
-		ID       string      `json:"id"`
-		Name     string      `json:"name"`
-		Primary  bool        `json:"primary"`
-		Type     string      `json:"type"`
-		Currency interface{} `json:"currency"`
-		Balance  struct {
-			Amount   string `json:"amount"`
-			Currency string `json:"currency"`
-		} `json:"balance"`
-		CreatedAt    time.Time `json:"created_at"`
-		UpdatedAt    time.Time `json:"updated_at"`
-		Resource     string    `json:"resource"`
-		ResourcePath string    `json:"resource_path"`
-		Ready        bool      `json:"ready,omitempty"`
+		ID           string      `json:"id"`
+		Name         string      `json:"name"`
+		Primary      bool        `json:"primary"`
+		Type         string      `json:"type"`
+		Currency     interface{} `json:"currency"`
+		Balance      money.Money `json:"balance"`
+		CreatedAt    time.Time   `json:"created_at"`
+		UpdatedAt    time.Time   `json:"updated_at"`
+		Resource     string      `json:"resource"`
+		ResourcePath string      `json:"resource_path"`
+		Ready        bool        `json:"ready,omitempty"`
 	} `json:"data"`
 }
 
-// ExchangeRate is used to parse the current exchange rates for crypto currencies available in Coinbase.
-type ExchangeRate map[string]interface{}
+// ExchangeRate is used to parse the current exchange rates for crypto currencies available in Coinbase. A rate is
+// the amount of the other currency one unit of Currency buys.
+type ExchangeRate struct {
+	Data struct {
+		Currency string                     `json:"currency"`
+		Rates    map[string]decimal.Decimal `json:"rates"`
+	} `json:"data"`
+}
 
 // Price is used to parse the current spot price for a specified crypto currency.
 type Price struct {
 	Data struct {
-		Base     string `json:"base"`
-		Amount   string `json:"amount"`
-		Currency string `json:"currency"`
+		Base string `json:"base"`
+		money.Money
 	} `json:"data"`
 }
 
+// Granularity is the spacing of the points of a PriceHistory.
+type Granularity string
+
+// These constants are the granularities accepted by GetPriceHistory().
+const (
+	Daily   Granularity = "day"
+	Weekly  Granularity = "week"
+	Monthly Granularity = "month"
+)
+
+// PriceHistory is a series of spot prices of a currency pair, oldest first.
+type PriceHistory struct {
+	CurrencyPair string
+	Points       []PricePoint
+}
+
+// PricePoint is the spot price of a currency pair on a date.
+type PricePoint struct {
+	Time  time.Time
+	Price money.Money
+}
+
 // Transaction is used to parse the transaction history of a specified account.
 type Transaction struct {
-	Data []struct {
-		ID     string `json:"id"`
-		Type   string `json:"type"`
-		Status string `json:"status"`
-		Amount struct {
-			Amount   string `json:"amount"`
-			Currency string `json:"currency"`
-		} `json:"amount"`
-		NativeAmount struct {
-			Amount   string `json:"amount"`
-			Currency string `json:"currency"`
-		} `json:"native_amount"`
-		Description     interface{} `json:"description"`
-		CreatedAt       time.Time   `json:"created_at"`
-		UpdatedAt       time.Time   `json:"updated_at"`
-		Resource        string      `json:"resource"`
-		ResourcePath    string      `json:"resource_path"`
-		InstantExchange bool        `json:"instant_exchange"`
-		Buy             struct {
-			ID           string `json:"id"`
-			Resource     string `json:"resource"`
-			ResourcePath string `json:"resource_path"`
-		} `json:"buy"`
-		Details struct {
-			Title             string `json:"title"`
-			Subtitle          string `json:"subtitle"`
-			Header            string `json:"header"`
-			Health            string `json:"health"`
-			PaymentMethodName string `json:"payment_method_name"`
-		} `json:"details"`
-		HideNativeAmount bool `json:"hide_native_amount"`
-	} `json:"data"`
+	Data       []TransactionData `json:"data"`
 	Pagination struct {
 		EndingBefore         interface{} `json:"ending_before"`
 		StartingAfter        interface{} `json:"starting_after"`
@@ -159,3 +235,238 @@ type Transaction struct {
 		NextURI              interface{} `json:"next_uri"`
 	} `json:"pagination"`
 }
+
+// TransactionData is a single transaction of an account.
+type TransactionData struct {
+	ID              string      `json:"id"`
+	Type            string      `json:"type"`
+	Status          string      `json:"status"`
+	Amount          money.Money `json:"amount"`
+	NativeAmount    money.Money `json:"native_amount"`
+	Description     interface{} `json:"description"`
+	CreatedAt       time.Time   `json:"created_at"`
+	UpdatedAt       time.Time   `json:"updated_at"`
+	Resource        string      `json:"resource"`
+	ResourcePath    string      `json:"resource_path"`
+	InstantExchange bool        `json:"instant_exchange"`
+	Buy             struct {
+		ID           string `json:"id"`
+		Resource     string `json:"resource"`
+		ResourcePath string `json:"resource_path"`
+	} `json:"buy"`
+	Sell struct {
+		ID           string `json:"id"`
+		Resource     string `json:"resource"`
+		ResourcePath string `json:"resource_path"`
+	} `json:"sell"`
+	Details struct {
+		Title             string `json:"title"`
+		Subtitle          string `json:"subtitle"`
+		Header            string `json:"header"`
+		Health            string `json:"health"`
+		PaymentMethodName string `json:"payment_method_name"`
+	} `json:"details"`
+	Network struct {
+		Status         string      `json:"status"`
+		Hash           string      `json:"hash"`
+		TransactionFee money.Money `json:"transaction_fee"`
+	} `json:"network"`
+	To struct {
+		Resource string `json:"resource"`
+		Address  string `json:"address"`
+		Email    string `json:"email"`
+	} `json:"to"`
+	Idem             string `json:"idem"`
+	HideNativeAmount bool   `json:"hide_native_amount"`
+}
+
+// SellRequest contains the parameters used to place a sell order with PlaceSellOrder().
+type SellRequest struct {
+	Amount        string `json:"amount"`
+	Currency      string `json:"currency"`
+	PaymentMethod string `json:"payment_method,omitempty"`
+	Commit        bool   `json:"commit"`
+	Quote         bool   `json:"quote,omitempty"`
+}
+
+// SellOrder is used to parse a sell order returned from the https://api.coinbase.com/v2/accounts/:account_id/sells api endpoint path.
+type SellOrder struct {
+	Data struct {
+		ID            string `json:"id"`
+		Status        string `json:"status"`
+		PaymentMethod struct {
+			ID           string `json:"id"`
+			Resource     string `json:"resource"`
+			ResourcePath string `json:"resource_path"`
+		} `json:"payment_method"`
+		Transaction struct {
+			ID           string `json:"id"`
+			Resource     string `json:"resource"`
+			ResourcePath string `json:"resource_path"`
+		} `json:"transaction"`
+		Amount       money.Money `json:"amount"`
+		Total        money.Money `json:"total"`
+		Subtotal     money.Money `json:"subtotal"`
+		Fee          money.Money `json:"fee"`
+		CreatedAt    time.Time   `json:"created_at"`
+		UpdatedAt    time.Time   `json:"updated_at"`
+		Resource     string      `json:"resource"`
+		ResourcePath string      `json:"resource_path"`
+		Committed    bool        `json:"committed"`
+		Instant      bool        `json:"instant"`
+		PayoutAt     time.Time   `json:"payout_at"`
+	} `json:"data"`
+}
+
+// BuyOrder is used to parse a buy returned from the https://api.coinbase.com/v2/accounts/:account_id/buys api endpoint
+// path. Buys have the same fields as sells.
+type BuyOrder SellOrder
+
+// SendRequest contains the parameters used to send crypto currency with SendMoney().
+type SendRequest struct {
+	To                string `json:"to"`
+	Amount            string `json:"amount"`
+	Currency          string `json:"currency"`
+	Description       string `json:"description,omitempty"`
+	SkipNotifications bool   `json:"skip_notifications,omitempty"`
+	Fee               string `json:"fee,omitempty"`
+	Idem              string `json:"idem,omitempty"`
+	DestinationTag    string `json:"destination_tag,omitempty"`
+	TwoFactorToken    string `json:"-"`
+}
+
+// SendTransaction is used to parse the transaction created by SendMoney().
+type SendTransaction struct {
+	Data TransactionData `json:"data"`
+}
+
+// Pagination describes the page of results returned by list endpoints.
+type Pagination struct {
+	EndingBefore  interface{} `json:"ending_before"`
+	StartingAfter interface{} `json:"starting_after"`
+	Limit         int         `json:"limit"`
+	Order         string      `json:"order"`
+	PreviousURI   interface{} `json:"previous_uri"`
+	NextURI       interface{} `json:"next_uri"`
+}
+
+// Addresses is used to parse the receive addresses of a specified account.
+type Addresses struct {
+	Pagination Pagination    `json:"pagination"`
+	Data       []AddressData `json:"data"`
+}
+
+// Address is used to parse a single receive address, for example one created with CreateAddress().
+type Address struct {
+	Data AddressData `json:"data"`
+}
+
+// AddressData is a single receive address of an account.
+type AddressData struct {
+	ID           string    `json:"id"`
+	Address      string    `json:"address"`
+	Name         string    `json:"name"`
+	Network      string    `json:"network"`
+	URIScheme    string    `json:"uri_scheme"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	Resource     string    `json:"resource"`
+	ResourcePath string    `json:"resource_path"`
+	AddressInfo  struct {
+		Address        string `json:"address"`
+		DestinationTag string `json:"destination_tag"`
+	} `json:"address_info"`
+}
+
+// PaymentMethods is used to parse the payment methods returned from the https://api.coinbase.com/v2/payment-methods api endpoint path.
+type PaymentMethods struct {
+	Pagination Pagination          `json:"pagination"`
+	Data       []PaymentMethodData `json:"data"`
+}
+
+// PaymentMethodData is a single payment method such as a bank account or the fiat wallet.
+type PaymentMethodData struct {
+	ID            string    `json:"id"`
+	Type          string    `json:"type"`
+	Name          string    `json:"name"`
+	Currency      string    `json:"currency"`
+	PrimaryBuy    bool      `json:"primary_buy"`
+	PrimarySell   bool      `json:"primary_sell"`
+	InstantBuy    bool      `json:"instant_buy"`
+	InstantSell   bool      `json:"instant_sell"`
+	AllowBuy      bool      `json:"allow_buy"`
+	AllowSell     bool      `json:"allow_sell"`
+	AllowDeposit  bool      `json:"allow_deposit"`
+	AllowWithdraw bool      `json:"allow_withdraw"`
+	Verified      bool      `json:"verified"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	Resource      string    `json:"resource"`
+	ResourcePath  string    `json:"resource_path"`
+	Limits        struct {
+		Type       string  `json:"type"`
+		Name       string  `json:"name"`
+		Buy        []Limit `json:"buy"`
+		InstantBuy []Limit `json:"instant_buy"`
+		Sell       []Limit `json:"sell"`
+		Deposit    []Limit `json:"deposit"`
+	} `json:"limits"`
+}
+
+// Limit is an allowance on a payment method over a rolling period, for example the weekly buy limit.
+type Limit struct {
+	PeriodInDays int         `json:"period_in_days"`
+	Total        money.Money `json:"total"`
+	Remaining    money.Money `json:"remaining"`
+}
+
+// AuthInfo is used to parse the authentication details returned from the https://api.coinbase.com/v2/user/auth
+// api endpoint path. The send limit is only reported for OAuth authentication.
+type AuthInfo struct {
+	Data struct {
+		Method    string   `json:"method"`
+		Scopes    []string `json:"scopes"`
+		OAuthMeta struct {
+			SendLimitAmount   string `json:"send_limit_amount"`
+			SendLimitCurrency string `json:"send_limit_currency"`
+			SendLimitPeriod   string `json:"send_limit_period"`
+		} `json:"oauth_meta"`
+	} `json:"data"`
+}
+
+// TransferRequest contains the parameters used to deposit or withdraw fiat with Deposit() and Withdraw().
+type TransferRequest struct {
+	Amount        string `json:"amount"`
+	Currency      string `json:"currency"`
+	PaymentMethod string `json:"payment_method"`
+	Commit        bool   `json:"commit"`
+}
+
+// Transfer is used to parse a fiat deposit or withdrawal returned from the
+// https://api.coinbase.com/v2/accounts/:account_id/deposits and /withdrawals api endpoint paths.
+// The amount moved is Subtotal, Fee is what Coinbase charges for the transfer and Amount is the total.
+type Transfer struct {
+	Data struct {
+		ID            string `json:"id"`
+		Status        string `json:"status"`
+		PaymentMethod struct {
+			ID           string `json:"id"`
+			Resource     string `json:"resource"`
+			ResourcePath string `json:"resource_path"`
+		} `json:"payment_method"`
+		Transaction struct {
+			ID           string `json:"id"`
+			Resource     string `json:"resource"`
+			ResourcePath string `json:"resource_path"`
+		} `json:"transaction"`
+		Amount       money.Money `json:"amount"`
+		Subtotal     money.Money `json:"subtotal"`
+		Fee          money.Money `json:"fee"`
+		CreatedAt    time.Time   `json:"created_at"`
+		UpdatedAt    time.Time   `json:"updated_at"`
+		Resource     string      `json:"resource"`
+		ResourcePath string      `json:"resource_path"`
+		Committed    bool        `json:"committed"`
+		PayoutAt     time.Time   `json:"payout_at"`
+	} `json:"data"`
+}
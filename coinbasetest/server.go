@@ -0,0 +1,365 @@
+/*
+Package coinbasetest provides an in-process Coinbase API server for tests. The server answers the v2 endpoints used
+by the coinbase package with canned fixtures, so code built on coinbase.Client can be exercised without real
+credentials or network access.
+
+	srv := coinbasetest.NewServer()
+	defer srv.Close()
+
+	c := srv.Client()
+	accounts, err := c.GetAccount()
+*/
+package coinbasetest
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/KalebHawkins/crypto-client/coinbase"
+	"github.com/shopspring/decimal"
+)
+
+//go:embed fixtures/*.json
+var fixtures embed.FS
+
+// Account IDs of the wallets served by the fixtures.
+const (
+	BTCAccountID = "58542935-67b5-56e1-a3f9-42686e07fa40"
+	ETHAccountID = "2bbf394c-193b-5b2a-9155-3b4732659ede"
+	LTCAccountID = "dd2f9f7d-d0d6-5f36-b1e8-5b93bbd3a0bd"
+	USDAccountID = "91ac7da7-4a5e-5b4e-8b4a-0f0b16e1c9a2"
+)
+
+// Request is a request received by the Server.
+type Request struct {
+	Method string
+	Path   string
+	Header http.Header
+	Body   []byte
+}
+
+// Server is a fake Coinbase API. The fixtures describe a USD user holding BTC, ETH and USD wallets plus an empty
+// LTC wallet. Sells, sends, deposits and withdrawals are accepted and answered with plausible results but do not
+// change any balances.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	prices   map[string]decimal.Decimal
+	handlers map[string]http.HandlerFunc
+	requests []Request
+	nextID   int
+}
+
+// NewServer starts and returns a new Server. The caller should call Close when finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{
+		prices: map[string]decimal.Decimal{
+			"BTC-USD": decimal.NewFromInt(50000),
+			"ETH-USD": decimal.NewFromInt(3000),
+			"LTC-USD": decimal.NewFromInt(100),
+			"USD-USD": decimal.NewFromInt(1),
+		},
+		handlers: map[string]http.HandlerFunc{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// Client returns a coinbase client that sends every request to the server without a rate limit. Any `opts` are
+// applied after the base URL is set.
+func (s *Server) Client(opts ...coinbase.Option) coinbase.CoinbaseClient {
+	opts = append([]coinbase.Option{coinbase.WithBaseURL(s.URL + "/v2/"), coinbase.WithRateLimit(0, 0)}, opts...)
+	return coinbase.APIKeyClient(opts...)
+}
+
+// SetPrice sets the buy, sell and spot price of `currencyPair`, for example "BTC-USD".
+func (s *Server) SetPrice(currencyPair string, price decimal.Decimal) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.prices[strings.ToUpper(currencyPair)] = price
+}
+
+// Handle overrides the response to `method` requests for `path`, for example "/v2/accounts". The query string is
+// not part of the match. This is useful to serve custom data or to simulate errors.
+func (s *Server) Handle(method string, path string, h http.HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[method+" "+path] = h
+}
+
+// Requests returns every request the server has received, oldest first.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// ResetRequests forgets the requests received so far, which keeps the memory of a long running server bounded.
+func (s *Server) ResetRequests() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = nil
+}
+
+// serveHTTP records the request and dispatches it to an override or the default routes.
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.RequestURI(), Header: r.Header.Clone(), Body: body})
+	h, ok := s.handlers[r.Method+" "+r.URL.Path]
+	s.mu.Unlock()
+
+	if ok {
+		h(w, r)
+		return
+	}
+
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/v2"), "/"), "/")
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/v2/user":
+		writeFixture(w, "user.json")
+	case r.Method == http.MethodGet && r.URL.Path == "/v2/user/auth":
+		writeFixture(w, "auth.json")
+	case r.Method == http.MethodGet && r.URL.Path == "/v2/accounts":
+		writeFixture(w, "accounts.json")
+	case r.Method == http.MethodGet && r.URL.Path == "/v2/exchange-rates":
+		s.writeExchangeRates(w, r.URL.Query().Get("currency"))
+	case r.Method == http.MethodGet && r.URL.Path == "/v2/payment-methods":
+		writeFixture(w, "payment_methods.json")
+	case r.Method == http.MethodGet && len(parts) == 3 && parts[0] == "prices":
+		s.writePrice(w, parts[1])
+	case len(parts) == 3 && parts[0] == "accounts":
+		s.serveAccount(w, r, parts[1], parts[2], body)
+	case r.Method == http.MethodGet && len(parts) == 4 && parts[0] == "accounts" && parts[2] == "buys":
+		writeBuy(w, parts[3])
+	case len(parts) == 5 && parts[0] == "accounts" && parts[4] == "commit" && r.Method == http.MethodPost:
+		s.writeOrder(w, parts[2], parts[3], "completed", nil)
+	default:
+		writeError(w, http.StatusNotFound, "not_found", "Not found")
+	}
+}
+
+// serveAccount answers the `resource` endpoints of a single account.
+func (s *Server) serveAccount(w http.ResponseWriter, r *http.Request, accountID string, resource string, body []byte) {
+	switch {
+	case r.Method == http.MethodGet && resource == "transactions":
+		if _, err := fixtures.Open("fixtures/transactions_" + accountID + ".json"); err != nil {
+			writeJSON(w, http.StatusOK, map[string]interface{}{"pagination": map[string]interface{}{}, "data": []interface{}{}})
+			return
+		}
+		writeFixture(w, "transactions_"+accountID+".json")
+	case r.Method == http.MethodGet && resource == "addresses":
+		writeFixture(w, "addresses.json")
+	case r.Method == http.MethodPost && resource == "addresses":
+		var req struct {
+			Name string `json:"name"`
+		}
+		json.Unmarshal(body, &req)
+		writeJSON(w, http.StatusCreated, map[string]interface{}{"data": map[string]interface{}{
+			"id":         s.newID(),
+			"address":    "mswUGcPHp1YnkLCgF1TtoryqSc5E9Q8xFa",
+			"name":       req.Name,
+			"network":    "bitcoin",
+			"created_at": time.Now().UTC(),
+			"updated_at": time.Now().UTC(),
+			"resource":   "address",
+		}})
+	case r.Method == http.MethodPost && resource == "transactions":
+		s.writeSend(w, accountID, body)
+	case r.Method == http.MethodPost && (resource == "sells" || resource == "deposits" || resource == "withdrawals"):
+		s.writeOrder(w, resource, s.newID(), "created", body)
+	default:
+		writeError(w, http.StatusNotFound, "not_found", "Not found")
+	}
+}
+
+// writePrice answers a price lookup for `currencyPair`.
+func (s *Server) writePrice(w http.ResponseWriter, currencyPair string) {
+	s.mu.Lock()
+	price, ok := s.prices[strings.ToUpper(currencyPair)]
+	s.mu.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", fmt.Sprintf("Invalid currency pair %s", currencyPair))
+		return
+	}
+
+	pair := strings.SplitN(strings.ToUpper(currencyPair), "-", 2)
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{
+		"base":     pair[0],
+		"currency": pair[len(pair)-1],
+		"amount":   price.String(),
+	}})
+}
+
+// writeOrder answers a sell, deposit or withdrawal. A sell is valued at the current USD price of its currency.
+func (s *Server) writeOrder(w http.ResponseWriter, resource string, id string, status string, body []byte) {
+	var req struct {
+		Amount   string `json:"amount"`
+		Currency string `json:"currency"`
+	}
+	json.Unmarshal(body, &req)
+
+	amount, err := decimal.NewFromString(req.Amount)
+	if err != nil {
+		amount = decimal.Zero
+	}
+	if req.Currency == "" {
+		req.Currency = "USD"
+	}
+
+	total := amount
+	if resource == "sells" {
+		s.mu.Lock()
+		total = amount.Mul(s.prices[strings.ToUpper(req.Currency)+"-USD"])
+		s.mu.Unlock()
+	}
+
+	fee := decimal.Zero
+	if resource != "deposits" {
+		fee = total.Mul(decimal.RequireFromString("0.0149")).Round(2)
+	}
+
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"data": map[string]interface{}{
+		"id":         id,
+		"status":     status,
+		"amount":     map[string]string{"amount": amount.String(), "currency": strings.ToUpper(req.Currency)},
+		"subtotal":   map[string]string{"amount": total.StringFixed(2), "currency": "USD"},
+		"fee":        map[string]string{"amount": fee.StringFixed(2), "currency": "USD"},
+		"total":      map[string]string{"amount": total.Sub(fee).StringFixed(2), "currency": "USD"},
+		"committed":  status == "completed",
+		"created_at": time.Now().UTC(),
+		"updated_at": time.Now().UTC(),
+		"resource":   strings.TrimSuffix(resource, "s"),
+	}})
+}
+
+// writeBuy answers the lookup of the buy `id` from the buys of the fixture transactions.
+func writeBuy(w http.ResponseWriter, id string) {
+	b, err := fixtures.ReadFile("fixtures/buys.json")
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal_server_error", err.Error())
+		return
+	}
+
+	var buys map[string]json.RawMessage
+	if err := json.Unmarshal(b, &buys); err != nil {
+		writeError(w, http.StatusInternalServerError, "internal_server_error", err.Error())
+		return
+	}
+
+	buy, ok := buys[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "Not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]json.RawMessage{"data": buy})
+}
+
+// writeSend answers a send, echoing back the requested amount as a pending transaction.
+func (s *Server) writeSend(w http.ResponseWriter, accountID string, body []byte) {
+	var req struct {
+		Type     string `json:"type"`
+		To       string `json:"to"`
+		Amount   string `json:"amount"`
+		Currency string `json:"currency"`
+	}
+	json.Unmarshal(body, &req)
+
+	if req.Type != coinbase.Send {
+		writeError(w, http.StatusBadRequest, "validation_error", fmt.Sprintf("Unsupported transaction type %q", req.Type))
+		return
+	}
+
+	id := s.newID()
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"data": map[string]interface{}{
+		"id":            id,
+		"type":          req.Type,
+		"status":        "pending",
+		"amount":        map[string]string{"amount": "-" + req.Amount, "currency": req.Currency},
+		"native_amount": map[string]string{"amount": "0.00", "currency": "USD"},
+		"created_at":    time.Now().UTC(),
+		"updated_at":    time.Now().UTC(),
+		"resource":      "transaction",
+		"resource_path": fmt.Sprintf("/v2/accounts/%s/transactions/%s", accountID, id),
+		"to":            map[string]string{"resource": "bitcoin_address", "address": req.To},
+	}})
+}
+
+// newID returns a unique, UUID shaped resource ID.
+func (s *Server) newID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextID++
+	return fmt.Sprintf("00000000-0000-4000-8000-%012d", s.nextID)
+}
+
+// writeFixture writes the embedded fixture `name` as a successful response.
+// writeExchangeRates writes the fixture rates converted to the base `currency`, USD when it is empty.
+func (s *Server) writeExchangeRates(w http.ResponseWriter, currency string) {
+	b, err := fixtures.ReadFile("fixtures/exchange_rates.json")
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal_server_error", err.Error())
+		return
+	}
+
+	var rates coinbase.ExchangeRate
+	if err := json.Unmarshal(b, &rates); err != nil {
+		writeError(w, http.StatusInternalServerError, "internal_server_error", err.Error())
+		return
+	}
+
+	currency = strings.ToUpper(currency)
+	if currency == "" || currency == rates.Data.Currency {
+		writeJSON(w, http.StatusOK, rates)
+		return
+	}
+
+	base, ok := rates.Data.Rates[currency]
+	if !ok || base.IsZero() {
+		writeError(w, http.StatusBadRequest, "invalid_request", fmt.Sprintf("Invalid currency (%s)", currency))
+		return
+	}
+
+	for k, v := range rates.Data.Rates {
+		rates.Data.Rates[k] = v.DivRound(base, 8)
+	}
+	rates.Data.Currency = currency
+
+	writeJSON(w, http.StatusOK, rates)
+}
+
+func writeFixture(w http.ResponseWriter, name string) {
+	b, err := fixtures.ReadFile("fixtures/" + name)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal_server_error", err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(b)
+}
+
+// writeError writes a Coinbase style error envelope.
+func writeError(w http.ResponseWriter, status int, id string, message string) {
+	writeJSON(w, status, map[string]interface{}{"errors": []map[string]string{{"id": id, "message": message}}})
+}
+
+// writeJSON encodes `v` as the response body.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,45 @@
+package coinmarketcap
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// APIError is returned when the CoinMarketCap API responds with a non 2xx status or a non zero error code in the
+// status of its response.
+type APIError struct {
+	StatusCode int
+	Status     string
+	Code       int
+	Message    string
+	Body       string
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	if e.Code == 0 {
+		return fmt.Sprintf("bad HTTP status return code: %v\n%v", e.Status, e.Body)
+	}
+
+	return fmt.Sprintf("coinmarketcap API error (%v): %d: %s", e.Status, e.Code, e.Message)
+}
+
+// IsAuthError reports whether the error was caused by a missing, invalid or unauthorized API key.
+func (e *APIError) IsAuthError() bool {
+	switch e.Code {
+	case 1001, 1002, 1005, 1006, 1007:
+		return true
+	}
+
+	return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+}
+
+// IsRateLimited reports whether the error was caused by exceeding the rate limit or the credits of the plan.
+func (e *APIError) IsRateLimited() bool {
+	switch e.Code {
+	case 1008, 1009, 1010, 1011:
+		return true
+	}
+
+	return e.StatusCode == http.StatusTooManyRequests
+}
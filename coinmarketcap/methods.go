@@ -0,0 +1,124 @@
+/*
+Package coinmarketcap is used to query the CoinMarketCap API for market metadata such as rankings, circulating
+supply and global market statistics. It holds no user data, only an API key is needed.
+*/
+package coinmarketcap
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// APIKeyClient sets the API key for CoinMarketCap authentication.
+// to use your API Key set your environment variable.
+//
+//	export COINMARKETCAP_KEY="api_key"
+func APIKeyClient() Client {
+	return Client{
+		apiKey:     os.Getenv("COINMARKETCAP_KEY"),
+		baseURL:    apiEndpointBase,
+		httpClient: &http.Client{},
+	}
+}
+
+// ─── COINMARKETCAP METHODS ──────────────────────────────────────────────────────
+
+// GetListings upon a successful API request returns the `limit` highest ranked cryptocurrencies with their market
+// data in `convert`, for example "USD". An error is returned if creating or sending the request failed.
+func (c Client) GetListings(limit int, convert string) ([]Listing, error) {
+	q := url.Values{
+		"start":   {"1"},
+		"limit":   {strconv.Itoa(limit)},
+		"convert": {strings.ToUpper(convert)},
+	}
+
+	var l []Listing
+	err := c.get("/v1/cryptocurrency/listings/latest", q, &l)
+
+	return l, err
+}
+
+// GetQuotes upon a successful API request returns the cryptocurrencies matching `symbols` with their market data
+// in `convert`. Several cryptocurrencies can share a symbol, the highest ranked one is returned. An error is
+// returned if creating or sending the request failed.
+func (c Client) GetQuotes(convert string, symbols ...string) (map[string]Listing, error) {
+	q := url.Values{
+		"symbol":  {strings.ToUpper(strings.Join(symbols, ","))},
+		"convert": {strings.ToUpper(convert)},
+	}
+
+	var raw map[string][]Listing
+	if err := c.get("/v2/cryptocurrency/quotes/latest", q, &raw); err != nil {
+		return nil, err
+	}
+
+	quotes := map[string]Listing{}
+	for sym, listings := range raw {
+		for _, l := range listings {
+			best, ok := quotes[sym]
+			if !ok || (l.Rank > 0 && (best.Rank == 0 || l.Rank < best.Rank)) {
+				quotes[sym] = l
+			}
+		}
+	}
+
+	return quotes, nil
+}
+
+// GetGlobalMetrics upon a successful API request returns the statistics of the whole market in `convert`.
+// An error is returned if creating or sending the request failed.
+func (c Client) GetGlobalMetrics(convert string) (GlobalMetrics, error) {
+	var g GlobalMetrics
+	err := c.get("/v1/global-metrics/quotes/latest", url.Values{"convert": {strings.ToUpper(convert)}}, &g)
+
+	return g, err
+}
+
+//
+// ──────────────────────────────────────────────────── COINMARKETCAP METHODS ─────
+//
+
+// ─── HELPER FUNCTIONS ───────────────────────────────────────────────────────────
+
+// get sends a GET request for `path` with the query `q` and parses the data of the response into `v`. A non 2xx
+// response or a non zero error code is returned as an *APIError.
+func (c Client) get(path string, q url.Values, v interface{}) error {
+	req, err := http.NewRequest("GET", c.baseURL+path+"?"+q.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-CMC_PRO_API_KEY", c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var r struct {
+		Status status          `json:"status"`
+		Data   json.RawMessage `json:"data"`
+	}
+	jsonErr := json.Unmarshal(body, &r)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 || jsonErr != nil || r.Status.ErrorCode != 0 {
+		e := &APIError{StatusCode: resp.StatusCode, Status: resp.Status, Code: r.Status.ErrorCode, Message: r.Status.ErrorMessage}
+		if e.Code == 0 {
+			e.Body = string(body)
+		}
+		return e
+	}
+
+	return json.Unmarshal(r.Data, v)
+}
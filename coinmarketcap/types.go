@@ -0,0 +1,71 @@
+package coinmarketcap
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	apiEndpointBase string = "https://pro-api.coinmarketcap.com"
+)
+
+// Client is used to query the CoinMarketCap API. Create one with APIKeyClient().
+type Client struct {
+	apiKey     string
+	baseURL    string
+	httpClient *http.Client
+}
+
+// status is included in every CoinMarketCap API response. An error code other than 0 is an error.
+type status struct {
+	Timestamp    time.Time `json:"timestamp"`
+	ErrorCode    int       `json:"error_code"`
+	ErrorMessage string    `json:"error_message"`
+	CreditCount  int       `json:"credit_count"`
+}
+
+// Listing is a cryptocurrency along with its rank and supply and its market data in each requested currency.
+type Listing struct {
+	ID                int              `json:"id"`
+	Name              string           `json:"name"`
+	Symbol            string           `json:"symbol"`
+	Slug              string           `json:"slug"`
+	Rank              int              `json:"cmc_rank"`
+	CirculatingSupply decimal.Decimal  `json:"circulating_supply"`
+	TotalSupply       decimal.Decimal  `json:"total_supply"`
+	MaxSupply         *decimal.Decimal `json:"max_supply"`
+	LastUpdated       time.Time        `json:"last_updated"`
+	Quote             map[string]Quote `json:"quote"`
+}
+
+// Quote is the market data of a cryptocurrency in one currency.
+type Quote struct {
+	Price              decimal.Decimal `json:"price"`
+	Volume24h          decimal.Decimal `json:"volume_24h"`
+	PercentChange1h    decimal.Decimal `json:"percent_change_1h"`
+	PercentChange24h   decimal.Decimal `json:"percent_change_24h"`
+	PercentChange7d    decimal.Decimal `json:"percent_change_7d"`
+	MarketCap          decimal.Decimal `json:"market_cap"`
+	MarketCapDominance decimal.Decimal `json:"market_cap_dominance"`
+}
+
+// GlobalMetrics are the statistics of the whole cryptocurrency market.
+type GlobalMetrics struct {
+	ActiveCryptocurrencies int                    `json:"active_cryptocurrencies"`
+	ActiveExchanges        int                    `json:"active_exchanges"`
+	BTCDominance           decimal.Decimal        `json:"btc_dominance"`
+	ETHDominance           decimal.Decimal        `json:"eth_dominance"`
+	LastUpdated            time.Time              `json:"last_updated"`
+	Quote                  map[string]GlobalQuote `json:"quote"`
+}
+
+// GlobalQuote is the size of the whole cryptocurrency market in one currency.
+type GlobalQuote struct {
+	TotalMarketCap      decimal.Decimal `json:"total_market_cap"`
+	TotalVolume24h      decimal.Decimal `json:"total_volume_24h"`
+	AltcoinMarketCap    decimal.Decimal `json:"altcoin_market_cap"`
+	DefiMarketCap       decimal.Decimal `json:"defi_market_cap"`
+	StablecoinMarketCap decimal.Decimal `json:"stablecoin_market_cap"`
+}
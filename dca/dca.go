@@ -0,0 +1,168 @@
+/*
+Package dca schedules dollar-cost-averaging buys. A Rule buys a fixed amount of an asset on a Cadence, and Plan
+decides which rules are due and which of them fit under a spending cap.
+*/
+package dca
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Cadence is how often a rule buys. A cadence of one or more months follows the calendar, any other cadence is a
+// fixed duration.
+type Cadence struct {
+	Months   int
+	Duration time.Duration
+}
+
+// These are the named cadences accepted by ParseCadence.
+var (
+	Daily   = Cadence{Duration: 24 * time.Hour}
+	Weekly  = Cadence{Duration: 7 * 24 * time.Hour}
+	Monthly = Cadence{Months: 1}
+)
+
+// ParseCadence parses daily, weekly, biweekly, monthly or a duration such as 12h. An error is returned for
+// anything else, or for a duration shorter than a minute.
+func ParseCadence(s string) (Cadence, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "daily":
+		return Daily, nil
+	case "weekly":
+		return Weekly, nil
+	case "biweekly":
+		return Cadence{Duration: 14 * 24 * time.Hour}, nil
+	case "monthly":
+		return Monthly, nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return Cadence{}, fmt.Errorf("invalid cadence %q, use daily, weekly, biweekly, monthly or a duration such as 12h", s)
+	}
+	if d < time.Minute {
+		return Cadence{}, fmt.Errorf("invalid cadence %q, buys must be at least a minute apart", s)
+	}
+
+	return Cadence{Duration: d}, nil
+}
+
+// Next returns when a rule with this cadence that last bought at `last` buys again.
+func (c Cadence) Next(last time.Time) time.Time {
+	if c.Months > 0 {
+		return last.AddDate(0, c.Months, 0)
+	}
+
+	return last.Add(c.Duration)
+}
+
+// String returns the cadence as accepted by ParseCadence.
+func (c Cadence) String() string {
+	switch c {
+	case Daily:
+		return "daily"
+	case Weekly:
+		return "weekly"
+	case Cadence{Duration: 14 * 24 * time.Hour}:
+		return "biweekly"
+	case Monthly:
+		return "monthly"
+	}
+
+	if c.Months > 0 {
+		return fmt.Sprintf("every %d months", c.Months)
+	}
+
+	return c.Duration.String()
+}
+
+// Rule buys Amount of the quote currency worth of ProductID, such as BTC-USD, on every Cadence.
+type Rule struct {
+	Name      string
+	ProductID string
+	Amount    decimal.Decimal
+	Cadence   Cadence
+}
+
+// Due reports whether a rule that last bought at `last` buys at `now`. A rule that never bought is due at once.
+func (r Rule) Due(last time.Time, now time.Time) bool {
+	return last.IsZero() || !now.Before(r.Cadence.Next(last))
+}
+
+// ClientOrderID returns the client order ID of the buy a rule that last bought at `last` is due for, a version 4
+// style UUID derived from the name of the rule and when the buy is due. Every attempt at the same buy gets the same
+// ID, so an order that was placed although the attempt failed, for example on a timeout, is returned by the
+// exchange instead of being placed again.
+func (r Rule) ClientOrderID(last time.Time) string {
+	due := "first"
+	if !last.IsZero() {
+		due = r.Cadence.Next(last).UTC().Format(time.RFC3339Nano)
+	}
+
+	b := sha256.Sum256([]byte("dca/" + r.Name + "/" + due))
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// Skip is a due rule that was not planned because buying would exceed the spending cap.
+type Skip struct {
+	Rule   Rule
+	Reason string
+}
+
+// Plan returns the rules in `rules` that are due at `now` given the time each of them last bought, keyed by
+// rule name. With a positive `limit` a due rule is skipped once `spent` plus its amount would exceed the limit, so
+// the planned buys never spend more than the limit. Rules are planned in the order given.
+func Plan(rules []Rule, last map[string]time.Time, spent decimal.Decimal, limit decimal.Decimal, now time.Time) ([]Rule, []Skip) {
+	var due []Rule
+	var skipped []Skip
+	for _, r := range rules {
+		if !r.Due(last[r.Name], now) {
+			continue
+		}
+
+		if limit.IsPositive() && spent.Add(r.Amount).GreaterThan(limit) {
+			skipped = append(skipped, Skip{r, fmt.Sprintf("buying %s would exceed the cap of %s, %s is spent", r.Amount, limit, spent)})
+			continue
+		}
+
+		spent = spent.Add(r.Amount)
+		due = append(due, r)
+	}
+
+	return due, skipped
+}
+
+// Validate returns an error for the first rule without a name, with a duplicate name, without a product or with
+// an amount that is not positive.
+func Validate(rules []Rule) error {
+	names := map[string]bool{}
+	for i, r := range rules {
+		switch {
+		case r.Name == "":
+			return fmt.Errorf("dca rule %d has no name", i+1)
+		case names[r.Name]:
+			return fmt.Errorf("dca rule %q is defined twice", r.Name)
+		case r.ProductID == "":
+			return fmt.Errorf("dca rule %q has no asset", r.Name)
+		case !r.Amount.IsPositive():
+			return fmt.Errorf("dca rule %q must buy a positive amount", r.Name)
+		}
+		names[r.Name] = true
+	}
+
+	return nil
+}
+
+// MonthStart returns the start of the calendar month `t` is in, in the location of `t`. The spending cap applies
+// to the buys since then.
+func MonthStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,149 @@
+package exchange
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/KalebHawkins/crypto-client/money"
+	"github.com/shopspring/decimal"
+)
+
+// KnownRenames are ticker changes made by the asset issuers or the providers, from the old to the current ticker.
+// They are applied unless overridden by the user.
+var KnownRenames = map[string]string{
+	"XBT":    "BTC",
+	"XDG":    "DOGE",
+	"CGLD":   "CELO",
+	"REPV2":  "REP",
+	"BCHABC": "BCH",
+	"BCHSV":  "BSV",
+	"XRB":    "NANO",
+	"MATIC":  "POL",
+}
+
+// AssetMap links the tickers of renamed assets to their current ticker and marks assets that are no longer
+// traded, so histories spanning a rename stay attached to a single asset.
+type AssetMap struct {
+	// Renames maps an old ticker to the ticker it was renamed to. Chains such as A to B to C are followed.
+	Renames map[string]string
+
+	// Delisted lists assets that are no longer traded anywhere and are valued at zero.
+	Delisted map[string]bool
+}
+
+// Canonical returns the current ticker of `symbol`, which is `symbol` itself unless it was renamed.
+func (m AssetMap) Canonical(symbol string) string {
+	symbol = strings.ToUpper(symbol)
+
+	// Following at most len(Renames) steps guards against cycles in user supplied renames.
+	for i := 0; i <= len(m.Renames); i++ {
+		next, ok := m.Renames[symbol]
+		if !ok || next == symbol {
+			break
+		}
+		symbol = next
+	}
+
+	return symbol
+}
+
+// Aliases returns every old ticker of `symbol`, sorted.
+func (m AssetMap) Aliases(symbol string) []string {
+	symbol = m.Canonical(symbol)
+
+	var aliases []string
+	for old := range m.Renames {
+		if old != symbol && m.Canonical(old) == symbol {
+			aliases = append(aliases, old)
+		}
+	}
+	sort.Strings(aliases)
+
+	return aliases
+}
+
+// IsDelisted reports whether `symbol`, or the asset it was renamed to, is delisted.
+func (m AssetMap) IsDelisted(symbol string) bool {
+	return m.Delisted[strings.ToUpper(symbol)] || m.Delisted[m.Canonical(symbol)]
+}
+
+// WithAssetMap returns a Provider reporting the balances and transactions of `p` under their current tickers.
+// Balances of an asset held under several tickers are merged. Prices of delisted assets are zero, prices of
+// renamed assets the provider no longer quotes under the current ticker are looked up under the old ones.
+func WithAssetMap(p Provider, m AssetMap) Provider {
+	return mappedProvider{p, m}
+}
+
+// mappedProvider applies an AssetMap to a Provider.
+type mappedProvider struct {
+	Provider
+	assets AssetMap
+}
+
+// Balances returns the balances of the provider under their current tickers.
+func (p mappedProvider) Balances() ([]Balance, error) {
+	balances, err := p.Provider.Balances()
+	if err != nil {
+		return nil, err
+	}
+
+	var merged []Balance
+	index := map[string]int{}
+	for _, b := range balances {
+		asset := p.assets.Canonical(b.Asset)
+		if i, ok := index[asset]; ok {
+			merged[i].Amount = merged[i].Amount.Add(b.Amount)
+			continue
+		}
+		index[asset] = len(merged)
+		merged = append(merged, Balance{Asset: asset, Amount: b.Amount})
+	}
+
+	return merged, nil
+}
+
+// Transactions returns the transactions of the provider with their amounts under the current tickers.
+func (p mappedProvider) Transactions() ([]Transaction, error) {
+	transactions, err := p.Provider.Transactions()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range transactions {
+		transactions[i].Amount.Currency = p.mapCurrency(transactions[i].Amount.Currency)
+		transactions[i].Value.Currency = p.mapCurrency(transactions[i].Value.Currency)
+		transactions[i].Fee.Currency = p.mapCurrency(transactions[i].Fee.Currency)
+	}
+
+	return transactions, nil
+}
+
+// Price returns the price of `pair`. Delisted assets are worth zero.
+func (p mappedProvider) Price(pair Pair) (money.Money, error) {
+	base := p.assets.Canonical(pair.Base)
+	if p.assets.IsDelisted(base) {
+		return money.New(decimal.Zero, pair.Quote), nil
+	}
+
+	price, err := p.Provider.Price(NewPair(base, pair.Quote))
+	if err == nil {
+		return price, nil
+	}
+
+	for _, alias := range p.assets.Aliases(base) {
+		if aliasPrice, aliasErr := p.Provider.Price(NewPair(alias, pair.Quote)); aliasErr == nil {
+			return aliasPrice, nil
+		}
+	}
+
+	return price, err
+}
+
+// mapCurrency returns the current ticker of `currency`, leaving the zero currency alone.
+func (p mappedProvider) mapCurrency(currency string) string {
+	if currency == "" {
+		return ""
+	}
+
+	return p.assets.Canonical(currency)
+}
@@ -0,0 +1,86 @@
+package exchange
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Capability is a feature beyond balances, transactions and prices that crypto-client supports for some providers.
+type Capability string
+
+// These constants are the capabilities a provider may report from Capabilities().
+const (
+	// Trading is placing buy and sell orders.
+	Trading Capability = "trading"
+
+	// Orders is listing and canceling open orders.
+	Orders Capability = "orders"
+
+	// Transfers is sending, depositing and withdrawing funds.
+	Transfers Capability = "transfers"
+
+	// Staking is staking assets and collecting the rewards.
+	Staking Capability = "staking"
+
+	// Candles is looking up open, high, low and close prices over time.
+	Candles Capability = "candles"
+
+	// PriceHistory is looking up prices on past dates.
+	PriceHistory Capability = "price-history"
+
+	// Webhooks is receiving notifications pushed by the provider.
+	Webhooks Capability = "webhooks"
+
+	// Streaming is receiving live prices over a persistent connection.
+	Streaming Capability = "streaming"
+)
+
+// AllCapabilities lists every capability in the order they are presented to the user.
+var AllCapabilities = []Capability{Trading, Orders, Transfers, Staking, Candles, PriceHistory, Webhooks, Streaming}
+
+// Capabilities is the set of capabilities of a provider.
+type Capabilities []Capability
+
+// Has reports whether `c` is one of the capabilities.
+func (cs Capabilities) Has(c Capability) bool {
+	for _, x := range cs {
+		if x == c {
+			return true
+		}
+	}
+
+	return false
+}
+
+// String is a stringer function for Capabilities. They are comma separated, or "none".
+func (cs Capabilities) String() string {
+	if len(cs) == 0 {
+		return "none"
+	}
+
+	s := make([]string, len(cs))
+	for i, c := range cs {
+		s[i] = string(c)
+	}
+
+	return strings.Join(s, ", ")
+}
+
+// UnsupportedError is returned when a provider is asked for a capability it does not have.
+type UnsupportedError struct {
+	Provider   string
+	Capability Capability
+}
+
+func (e UnsupportedError) Error() string {
+	return fmt.Sprintf("%s does not support %s in crypto-client", e.Provider, e.Capability)
+}
+
+// Require returns an UnsupportedError if `p` lacks the capability `c`.
+func Require(p Provider, c Capability) error {
+	if !p.Capabilities().Has(c) {
+		return UnsupportedError{Provider: p.Name(), Capability: c}
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+/*
+Package exchange defines the provider-agnostic view of a crypto exchange. Every provider package implements Provider
+on top of its own client so commands and portfolio math can be written once against balances, transactions and
+prices instead of once per provider.
+*/
+package exchange
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/KalebHawkins/crypto-client/money"
+	"github.com/shopspring/decimal"
+)
+
+// These constants are the normalized types of a Transaction.
+const (
+	Buy        string = "buy"
+	Sell       string = "sell"
+	Deposit    string = "deposit"
+	Withdrawal string = "withdrawal"
+	Fee        string = "fee"
+	Other      string = "other"
+)
+
+// Provider is a crypto exchange holding the user's assets.
+type Provider interface {
+	// Name returns the name of the provider, for example "Coinbase".
+	Name() string
+
+	// Balances returns the non zero balance of every asset held with the provider.
+	Balances() ([]Balance, error)
+
+	// Transactions returns the history of the user's balances, oldest first.
+	Transactions() ([]Transaction, error)
+
+	// Price returns the current price of one unit of the base asset of `pair` in its quote asset.
+	Price(pair Pair) (money.Money, error)
+
+	// Capabilities returns the features crypto-client supports for the provider in addition to the methods above.
+	// Commands check them to explain up front that a provider lacks a feature instead of failing mid-call.
+	Capabilities() Capabilities
+}
+
+// Balance is the amount of an asset held with a provider.
+type Balance struct {
+	Asset  string
+	Amount decimal.Decimal
+}
+
+// Transaction is a change of a balance. Amount is signed and in the asset that changed. Value is what the
+// transaction was worth in the quote or native currency at the time, it is zero when the provider does not report
+// it.
+type Transaction struct {
+	ID          string
+	Type        string
+	Time        time.Time
+	Amount      money.Money
+	Value       money.Money
+	Fee         money.Money
+	Description string
+}
+
+// Pair is a base asset quoted in another asset, for example BTC quoted in USD.
+type Pair struct {
+	Base  string
+	Quote string
+}
+
+// NewPair returns the pair of `base` quoted in `quote`. Symbols are upper cased.
+func NewPair(base string, quote string) Pair {
+	return Pair{Base: strings.ToUpper(base), Quote: strings.ToUpper(quote)}
+}
+
+// ParsePair parses a pair written as BASE-QUOTE or BASE/QUOTE such as "BTC-USD".
+func ParsePair(s string) (Pair, error) {
+	parts := strings.FieldsFunc(s, func(r rune) bool { return r == '-' || r == '/' })
+	if len(parts) != 2 {
+		return Pair{}, fmt.Errorf("invalid currency pair %q, expected BASE-QUOTE such as BTC-USD", s)
+	}
+
+	return NewPair(parts[0], parts[1]), nil
+}
+
+// String is a stringer function for a Pair. Pairs are written as BASE-QUOTE.
+func (p Pair) String() string {
+	return p.Base + "-" + p.Quote
+}
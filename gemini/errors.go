@@ -0,0 +1,40 @@
+package gemini
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// APIError is returned when the Gemini API responds with a non 2xx status. Reason is a short identifier such as
+// "InvalidSignature" and Message a human readable description.
+type APIError struct {
+	StatusCode int    `json:"-"`
+	Status     string `json:"-"`
+	Reason     string `json:"reason"`
+	Message    string `json:"message"`
+	Body       string `json:"-"`
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("bad HTTP status return code: %v\n%v", e.Status, e.Body)
+	}
+
+	return fmt.Sprintf("gemini API error (%v): %s: %s", e.Status, e.Reason, e.Message)
+}
+
+// IsAuthError reports whether the error was caused by missing or invalid credentials.
+func (e *APIError) IsAuthError() bool {
+	switch e.Reason {
+	case "InvalidSignature", "InvalidNonce", "MissingApikeyHeader", "InvalidApiKey", "InvalidPayload", "MissingRole":
+		return true
+	}
+
+	return e.StatusCode == http.StatusForbidden
+}
+
+// IsRateLimited reports whether the error was caused by exceeding the Gemini rate limit.
+func (e *APIError) IsRateLimited() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.Reason == "RateLimit"
+}
@@ -0,0 +1,153 @@
+/*
+Package gemini is used to query the Gemini API for a user's balances and transfers and for ticker prices.
+*/
+package gemini
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// APIKeyClient sets the API key and API secret for Gemini authentication.
+// to use your API Key and API secret set your environment variables.
+//
+//	export GEMINI_KEY="api_key"
+//	export GEMINI_SECRET="api_secret"
+func APIKeyClient() Client {
+	return Client{
+		apiKey:     os.Getenv("GEMINI_KEY"),
+		apiSecret:  os.Getenv("GEMINI_SECRET"),
+		baseURL:    apiEndpointBase,
+		httpClient: &http.Client{},
+		nonce:      &nonceState{},
+	}
+}
+
+// ─── GEMINI METHODS ─────────────────────────────────────────────────────────────
+
+// GetBalances upon a successful API request returns the balance of every currency held on the exchange. An error
+// is returned if creating or sending the request failed.
+func (c Client) GetBalances() ([]Balance, error) {
+	var b []Balance
+	err := c.private("/v1/balances", nil, &b)
+
+	return b, err
+}
+
+// GetTransfers upon a successful API request returns the most recent deposits and withdrawals made after
+// `since`, newest first. Gemini returns at most 50 transfers per request. A zero `since` returns the latest
+// transfers. An error is returned if creating or sending the request failed.
+func (c Client) GetTransfers(since time.Time) ([]Transfer, error) {
+	params := map[string]interface{}{"limit_transfers": maxTransfers}
+	if !since.IsZero() {
+		params["timestamp"] = since.UnixNano() / int64(time.Millisecond)
+	}
+
+	var t []Transfer
+	err := c.private("/v1/transfers", params, &t)
+
+	return t, err
+}
+
+// GetTicker upon a successful API request returns the current market of `symbol`, for example "btcusd".
+// An error is returned if creating or sending the request failed.
+func (c Client) GetTicker(symbol string) (Ticker, error) {
+	req, err := http.NewRequest("GET", c.baseURL+"/v1/pubticker/"+strings.ToLower(symbol), nil)
+	if err != nil {
+		return Ticker{}, err
+	}
+
+	var t Ticker
+	err = c.do(req, &t)
+
+	return t, err
+}
+
+//
+// ─────────────────────────────────────────────────────────── GEMINI METHODS ─────
+//
+
+// ─── HELPER FUNCTIONS ───────────────────────────────────────────────────────────
+
+// private sends a signed request to `path`. The request and a nonce are added to `params`, which are sent base64
+// encoded in the X-GEMINI-PAYLOAD header rather than in the body.
+func (c Client) private(path string, params map[string]interface{}, v interface{}) error {
+	payload := map[string]interface{}{}
+	for k, p := range params {
+		payload[k] = p
+	}
+	payload["request"] = path
+	payload["nonce"] = c.nonce.next()
+
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	encoded := base64.StdEncoding.EncodeToString(b)
+
+	req, err := http.NewRequest("POST", c.baseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain")
+	req.Header.Set("Cache-Control", "no-cache")
+	req.Header.Set("X-GEMINI-APIKEY", c.apiKey)
+	req.Header.Set("X-GEMINI-PAYLOAD", encoded)
+	req.Header.Set("X-GEMINI-SIGNATURE", c.createSignature(encoded))
+
+	return c.do(req, v)
+}
+
+// createSignature returns the hex encoded HMAC-SHA384 of the encoded payload keyed with the API secret.
+func (c Client) createSignature(payload string) string {
+	h := hmac.New(sha512.New384, []byte(c.apiSecret))
+	h.Write([]byte(payload))
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// do sends a request and parses the response into `v`. A non 2xx response is returned as an *APIError.
+func (c Client) do(req *http.Request, v interface{}) error {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		e := &APIError{StatusCode: resp.StatusCode, Status: resp.Status}
+		if err := json.Unmarshal(body, e); err != nil || e.Reason == "" {
+			e.Body = string(body)
+		}
+		return e
+	}
+
+	return json.Unmarshal(body, v)
+}
+
+// next returns a nonce larger than every nonce returned before.
+func (n *nonceState) next() int64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	now := time.Now().UnixNano() / int64(time.Millisecond)
+	if now <= n.last {
+		now = n.last + 1
+	}
+	n.last = now
+
+	return now
+}
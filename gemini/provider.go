@@ -0,0 +1,84 @@
+package gemini
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/KalebHawkins/crypto-client/exchange"
+	"github.com/KalebHawkins/crypto-client/money"
+)
+
+var _ exchange.Provider = Client{}
+
+// Name implements exchange.Provider.
+func (c Client) Name() string {
+	return "Gemini"
+}
+
+// Capabilities implements exchange.Provider. Only reading balances, transfers and prices is supported.
+func (c Client) Capabilities() exchange.Capabilities {
+	return nil
+}
+
+// Balances implements exchange.Provider.
+func (c Client) Balances() ([]exchange.Balance, error) {
+	b, err := c.GetBalances()
+	if err != nil {
+		return nil, err
+	}
+
+	var balances []exchange.Balance
+	for _, bal := range b {
+		if bal.Amount.IsZero() {
+			continue
+		}
+		balances = append(balances, exchange.Balance{Asset: bal.Currency, Amount: bal.Amount})
+	}
+
+	return balances, nil
+}
+
+// Transactions implements exchange.Provider. Only the latest deposits and withdrawals are returned, see
+// GetTransfers().
+func (c Client) Transactions() ([]exchange.Transaction, error) {
+	transfers, err := c.GetTransfers(time.Time{})
+	if err != nil {
+		return nil, err
+	}
+
+	var transactions []exchange.Transaction
+	for _, t := range transfers {
+		amount := t.Amount
+		typ := exchange.Deposit
+		if strings.EqualFold(t.Type, "Withdrawal") {
+			amount = amount.Neg()
+			typ = exchange.Withdrawal
+		}
+
+		transactions = append(transactions, exchange.Transaction{
+			ID:          strconv.FormatInt(t.EID, 10),
+			Type:        typ,
+			Time:        t.Time(),
+			Amount:      money.New(amount, t.Currency),
+			Description: strings.TrimSpace(t.Type + " " + t.Method),
+		})
+	}
+
+	sort.SliceStable(transactions, func(i, j int) bool {
+		return transactions[i].Time.Before(transactions[j].Time)
+	})
+
+	return transactions, nil
+}
+
+// Price implements exchange.Provider. The price of the last trade is returned.
+func (c Client) Price(pair exchange.Pair) (money.Money, error) {
+	t, err := c.GetTicker(pair.Base + pair.Quote)
+	if err != nil {
+		return money.Money{}, err
+	}
+
+	return money.New(t.Last, pair.Quote), nil
+}
@@ -0,0 +1,66 @@
+package gemini
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	apiEndpointBase string = "https://api.gemini.com"
+	maxTransfers    int    = 50
+)
+
+// Client is used to query the Gemini API. Create one with APIKeyClient().
+type Client struct {
+	apiKey     string
+	apiSecret  string
+	baseURL    string
+	httpClient *http.Client
+
+	// nonce is shared between copies of a Client, Gemini rejects a nonce that is not larger than the last one.
+	nonce *nonceState
+}
+
+// nonceState hands out strictly increasing nonces.
+type nonceState struct {
+	mu   sync.Mutex
+	last int64
+}
+
+// Balance is the amount of a currency held on the exchange.
+type Balance struct {
+	Type                   string          `json:"type"`
+	Currency               string          `json:"currency"`
+	Amount                 decimal.Decimal `json:"amount"`
+	Available              decimal.Decimal `json:"available"`
+	AvailableForWithdrawal decimal.Decimal `json:"availableForWithdrawal"`
+}
+
+// Ticker is the current market of a symbol.
+type Ticker struct {
+	Bid  decimal.Decimal `json:"bid"`
+	Ask  decimal.Decimal `json:"ask"`
+	Last decimal.Decimal `json:"last"`
+}
+
+// Transfer is a deposit or withdrawal of fiat or crypto currency.
+type Transfer struct {
+	Type        string          `json:"type"`
+	Status      string          `json:"status"`
+	TimestampMS int64           `json:"timestampms"`
+	EID         int64           `json:"eid"`
+	Currency    string          `json:"currency"`
+	Amount      decimal.Decimal `json:"amount"`
+	Method      string          `json:"method"`
+	TxHash      string          `json:"txHash"`
+	Destination string          `json:"destination"`
+	Purpose     string          `json:"purpose"`
+}
+
+// Time returns the time the transfer was made.
+func (t Transfer) Time() time.Time {
+	return time.Unix(0, t.TimestampMS*int64(time.Millisecond)).UTC()
+}
@@ -0,0 +1,150 @@
+/*
+Package credentials stores the secrets crypto-client needs across invocations, such as the OAuth token obtained by
+`crypto-client auth login`. Secrets are kept in the OS keyring (Keychain on macOS, the Secret Service on Linux and
+the Credential Manager on Windows), which encrypts them at rest. Every named profile has its own secrets, the empty
+profile is the default one.
+
+Earlier versions kept the token in a file in the crypto-client configuration directory. Such a file is moved into
+the keyring the first time the token is loaded.
+*/
+package credentials
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/KalebHawkins/crypto-client/coinbase"
+	"github.com/KalebHawkins/crypto-client/internal/userdata"
+	"github.com/zalando/go-keyring"
+)
+
+// keyringService is the OS keyring service the secrets are stored under, shared with the API keys stored by the
+// coinbase package.
+const keyringService = "crypto-client"
+
+// tokenFile is the name of the file earlier versions kept the Coinbase OAuth token of the default profile in, inside
+// the configuration directory. Named profiles used coinbase_token_<profile>.json.
+const tokenFile = "coinbase_token.json"
+
+// LoadToken returns the stored Coinbase OAuth token of `profile`. The zero Token is returned if none is stored,
+// including when the keyring is not available, as SaveToken() cannot have stored one then. A token file left by an
+// earlier version is moved into the keyring, or used as it is while the keyring is not available.
+func LoadToken(profile string) (coinbase.Token, error) {
+	secret, err := keyring.Get(keyringService, keyringUser(profile))
+	if err == nil {
+		var t coinbase.Token
+		if err := json.Unmarshal([]byte(secret), &t); err != nil {
+			return coinbase.Token{}, err
+		}
+
+		return t, nil
+	}
+	available := errors.Is(err, keyring.ErrNotFound)
+
+	t, ok, err := loadTokenFile(profile)
+	if err != nil || !ok {
+		return coinbase.Token{}, err
+	}
+
+	if !available {
+		slog.Warn("the OAuth token is kept in a plain file until the OS keyring is available", "profile", profile)
+		return t, nil
+	}
+
+	if err := SaveToken(profile, t); err != nil {
+		return coinbase.Token{}, err
+	}
+
+	return t, removeTokenFile(profile)
+}
+
+// SaveToken stores `t` for `profile` in the OS keyring, replacing any previously stored token. An error is returned
+// if the keyring is not available.
+func SaveToken(profile string, t coinbase.Token) error {
+	b, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+
+	return keyring.Set(keyringService, keyringUser(profile), string(b))
+}
+
+// DeleteToken removes the stored token of `profile`, and the token file of an earlier version. It is not an error if
+// no token is stored.
+func DeleteToken(profile string) error {
+	if err := removeTokenFile(profile); err != nil {
+		return err
+	}
+
+	if err := keyring.Delete(keyringService, keyringUser(profile)); err != nil && !errors.Is(err, keyring.ErrNotFound) {
+		return err
+	}
+
+	return nil
+}
+
+// keyringUser returns the keyring user the token of `profile` is stored under.
+func keyringUser(profile string) string {
+	if profile == "" {
+		return "coinbase-oauth"
+	}
+
+	return "coinbase-oauth/" + profile
+}
+
+// loadTokenFile returns the token of `profile` kept in a file by an earlier version, and whether there is one.
+func loadTokenFile(profile string) (coinbase.Token, bool, error) {
+	path, err := tokenPath(profile)
+	if err != nil {
+		return coinbase.Token{}, false, err
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return coinbase.Token{}, false, nil
+	}
+
+	if err != nil {
+		return coinbase.Token{}, false, err
+	}
+
+	var t coinbase.Token
+	if err := json.Unmarshal(b, &t); err != nil {
+		return coinbase.Token{}, false, err
+	}
+
+	return t, true, nil
+}
+
+// removeTokenFile removes the token file of `profile` kept by an earlier version. It is not an error if there is
+// none.
+func removeTokenFile(profile string) error {
+	path, err := tokenPath(profile)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// tokenPath returns the path of the token file of `profile` kept by an earlier version.
+func tokenPath(profile string) (string, error) {
+	dir, err := userdata.Dir()
+	if err != nil {
+		return "", err
+	}
+
+	if profile != "" {
+		return filepath.Join(dir, "coinbase_token_"+profile+".json"), nil
+	}
+
+	return filepath.Join(dir, tokenFile), nil
+}
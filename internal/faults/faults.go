@@ -0,0 +1,236 @@
+/*
+Package faults provides an http.RoundTripper that randomly injects the failures an exchange API produces in
+practice: slow responses, HTTP 429 rate limiting, 5xx server errors and bodies cut off mid-transfer. It is meant to
+exercise the retry and partial failure handling of crypto-client end to end, deliberately rather than by waiting
+for an outage.
+
+Faults are described by a comma separated list of key=value pairs, for example
+
+	latency=0.2,delay=2s,429=0.1,5xx=0.05,truncate=0.05,seed=42
+
+where latency, 429, 5xx and truncate are the probabilities of each fault, delay is the maximum injected latency
+and seed makes the injected faults reproducible.
+*/
+package faults
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultDelay is the maximum latency injected when the spec does not set a delay.
+const DefaultDelay = 2 * time.Second
+
+// Spec describes the faults a Transport injects. Probabilities are between 0 and 1 and are drawn independently for
+// every request.
+type Spec struct {
+	Latency      float64
+	MaxDelay     time.Duration
+	RateLimited  float64
+	ServerError  float64
+	TruncateBody float64
+	Seed         int64
+}
+
+// Parse parses a spec such as "latency=0.2,delay=2s,429=0.1,5xx=0.05,truncate=0.05,seed=42". Keys that are left
+// out disable the fault. An error is returned for unknown keys and probabilities outside [0, 1].
+func Parse(s string) (Spec, error) {
+	spec := Spec{MaxDelay: DefaultDelay, Seed: time.Now().UnixNano()}
+
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return Spec{}, fmt.Errorf("faults: %q is not a key=value pair", part)
+		}
+		key, value := strings.ToLower(strings.TrimSpace(kv[0])), strings.TrimSpace(kv[1])
+
+		var err error
+		switch key {
+		case "latency":
+			spec.Latency, err = parseProbability(value)
+		case "429":
+			spec.RateLimited, err = parseProbability(value)
+		case "5xx":
+			spec.ServerError, err = parseProbability(value)
+		case "truncate":
+			spec.TruncateBody, err = parseProbability(value)
+		case "delay":
+			spec.MaxDelay, err = time.ParseDuration(value)
+		case "seed":
+			spec.Seed, err = strconv.ParseInt(value, 10, 64)
+		default:
+			return Spec{}, fmt.Errorf("faults: unknown fault %q, use latency, delay, 429, 5xx, truncate or seed", key)
+		}
+
+		if err != nil {
+			return Spec{}, fmt.Errorf("faults: invalid %s: %w", key, err)
+		}
+	}
+
+	return spec, nil
+}
+
+// parseProbability parses a probability between 0 and 1.
+func parseProbability(s string) (float64, error) {
+	p, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	if p < 0 || p > 1 {
+		return 0, fmt.Errorf("%s is not between 0 and 1", s)
+	}
+
+	return p, nil
+}
+
+// String formats the spec in the form accepted by Parse().
+func (s Spec) String() string {
+	return fmt.Sprintf("latency=%g,delay=%s,429=%g,5xx=%g,truncate=%g,seed=%d",
+		s.Latency, s.MaxDelay, s.RateLimited, s.ServerError, s.TruncateBody, s.Seed)
+}
+
+// Transport wraps an http.RoundTripper and injects the faults of its Spec. Injected 429 and 5xx responses are
+// returned without sending the request, so the API never sees requests that fail this way.
+type Transport struct {
+	Base http.RoundTripper
+	Spec Spec
+
+	mu  sync.Mutex
+	rnd *rand.Rand
+}
+
+// NewTransport returns a Transport injecting the faults of `spec` into the requests sent through `base`. A nil
+// `base` uses http.DefaultTransport.
+func NewTransport(base http.RoundTripper, spec Spec) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &Transport{Base: base, Spec: spec, rnd: rand.New(rand.NewSource(spec.Seed))}
+}
+
+// Unwrap returns the RoundTripper the requests are sent through.
+func (t *Transport) Unwrap() http.RoundTripper {
+	return t.Base
+}
+
+// WithBase returns a Transport injecting the faults of the same spec into the requests sent through `base`. Its
+// random faults start over from the seed of the spec.
+func (t *Transport) WithBase(base http.RoundTripper) http.RoundTripper {
+	return NewTransport(base, t.Spec)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.chance(t.Spec.Latency) {
+		select {
+		case <-time.After(t.delay()):
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+
+	if t.chance(t.Spec.RateLimited) {
+		return injectedResponse(req, http.StatusTooManyRequests, http.Header{"Retry-After": []string{"1"}}), nil
+	}
+
+	if t.chance(t.Spec.ServerError) {
+		statuses := []int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout}
+		return injectedResponse(req, statuses[t.intn(len(statuses))], http.Header{}), nil
+	}
+
+	resp, err := t.Base.RoundTrip(req)
+	if err != nil || !t.chance(t.Spec.TruncateBody) {
+		return resp, err
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(&truncatedReader{r: bytes.NewReader(body[:t.intn(len(body)+1)])})
+
+	return resp, nil
+}
+
+// chance reports whether a fault with probability `p` happens.
+func (t *Transport) chance(p float64) bool {
+	if p <= 0 {
+		return false
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.rnd.Float64() < p
+}
+
+// intn returns a random number in [0, n).
+func (t *Transport) intn(n int) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.rnd.Intn(n)
+}
+
+// delay returns a random latency up to the maximum delay.
+func (t *Transport) delay() time.Duration {
+	if t.Spec.MaxDelay <= 0 {
+		return 0
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return time.Duration(t.rnd.Int63n(int64(t.Spec.MaxDelay)) + 1)
+}
+
+// injectedResponse returns a response with `status` as if the server had sent it.
+func injectedResponse(req *http.Request, status int, header http.Header) *http.Response {
+	header.Set("Content-Type", "application/json")
+	header.Set("X-Injected-Fault", "true")
+	body := fmt.Sprintf(`{"errors":[{"id":"injected_fault","message":"fault injected by crypto-client: %d %s"}]}`,
+		status, http.StatusText(status))
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// truncatedReader returns io.ErrUnexpectedEOF after the part of the body that was kept, like a connection dropped
+// mid-transfer.
+type truncatedReader struct {
+	r io.Reader
+}
+
+func (t *truncatedReader) Read(p []byte) (int, error) {
+	n, err := t.r.Read(p)
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+
+	return n, err
+}
@@ -0,0 +1,253 @@
+package fx
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/KalebHawkins/crypto-client/coinbase"
+	"github.com/KalebHawkins/crypto-client/money"
+	"github.com/shopspring/decimal"
+)
+
+// Client is a coinbase.Client showing the amounts in the native currency of the user in Currency instead. The user
+// profile reports Currency as the native currency, prices quoted in Currency are looked up in the native currency
+// and converted at the current rate of the exchange-rates endpoint. The native amounts of transactions are
+// converted at the rate of the day they were made, see Load, so they keep what they were worth in Currency back
+// then. Every other method is passed on to the wrapped client.
+type Client struct {
+	coinbase.Client
+	Currency string
+
+	state *clientState
+}
+
+// clientState holds the rates of a Client, loaded on first use and shared by its copies. Daily holds the rates of
+// past days keyed by currency and date.
+type clientState struct {
+	mu    sync.Mutex
+	rates Rates
+	daily map[string]Rates
+}
+
+// NewClient returns a Client showing the amounts of `c` in `currency`.
+func NewClient(c coinbase.Client, currency string) Client {
+	return Client{Client: c, Currency: strings.ToUpper(currency), state: &clientState{daily: map[string]Rates{}}}
+}
+
+// Rates returns the rates from the native currency of the user to Currency, looked up once. An error is returned if
+// a request failed or Coinbase has no rate to Currency.
+func (c Client) Rates() (Rates, error) {
+	c.state.mu.Lock()
+	defer c.state.mu.Unlock()
+
+	if c.state.rates.Base != "" {
+		return c.state.rates, nil
+	}
+
+	user, err := c.Client.GetUserProfile()
+	if err != nil {
+		return Rates{}, err
+	}
+	native := strings.ToUpper(user.Data.NativeCurrency)
+
+	r := Rates{Base: native, perBase: map[string]decimal.Decimal{native: decimal.NewFromInt(1)}}
+	if native != c.Currency {
+		if r, err = FromExchangeRates(c.Client, native); err != nil {
+			return Rates{}, err
+		}
+		if _, ok := r.Rate(c.Currency); !ok {
+			return Rates{}, fmt.Errorf("fx: Coinbase has no exchange rate from %s to %s", native, c.Currency)
+		}
+	}
+
+	c.state.rates = r
+	return r, nil
+}
+
+// GetUserProfile returns the user profile of the wrapped client with Currency as the native currency. An error is
+// returned if the request or looking up the rates failed.
+func (c Client) GetUserProfile() (coinbase.User, error) {
+	if _, err := c.Rates(); err != nil {
+		return coinbase.User{}, err
+	}
+
+	u, err := c.Client.GetUserProfile()
+	u.Data.NativeCurrency = c.Currency
+
+	return u, err
+}
+
+// GetPrice returns the price of `currencyPair`, converted from the native currency when it is quoted in Currency.
+// An error is returned if the request or looking up the rates failed.
+func (c Client) GetPrice(currencyPair string, priceType string) (coinbase.Price, error) {
+	pair, r, err := c.nativePair(currencyPair)
+	if err != nil {
+		return coinbase.Price{}, err
+	}
+	if pair == currencyPair {
+		return c.Client.GetPrice(currencyPair, priceType)
+	}
+
+	p, err := c.Client.GetPrice(pair, priceType)
+	if err != nil {
+		return coinbase.Price{}, err
+	}
+
+	return p, c.convertPrice(r, &p)
+}
+
+// GetPrices returns the prices of `pairs` keyed by pair, those quoted in Currency converted from the native
+// currency. An error is returned if the request or looking up the rates failed.
+func (c Client) GetPrices(pairs []string, priceType string) (map[string]coinbase.Price, error) {
+	r, err := c.Rates()
+	if err != nil {
+		return nil, err
+	}
+
+	native := make([]string, len(pairs))
+	for i, p := range pairs {
+		native[i], _, _ = c.nativePair(p)
+	}
+
+	prices, err := c.Client.GetPrices(native, priceType)
+	if err != nil {
+		return nil, err
+	}
+
+	converted := make(map[string]coinbase.Price, len(pairs))
+	for i, pair := range pairs {
+		p, ok := prices[native[i]]
+		if !ok {
+			continue
+		}
+		if native[i] != pair {
+			if err := c.convertPrice(r, &p); err != nil {
+				return nil, err
+			}
+		}
+		converted[pair] = p
+	}
+
+	return converted, nil
+}
+
+// GetPriceByDate returns the spot price of `currencyPair` on `date`, converted at the current rate from the native
+// currency when it is quoted in Currency. An error is returned if the request or looking up the rates failed.
+func (c Client) GetPriceByDate(currencyPair string, date time.Time) (coinbase.Price, error) {
+	pair, r, err := c.nativePair(currencyPair)
+	if err != nil {
+		return coinbase.Price{}, err
+	}
+	if pair == currencyPair {
+		return c.Client.GetPriceByDate(currencyPair, date)
+	}
+
+	p, err := c.Client.GetPriceByDate(pair, date)
+	if err != nil {
+		return coinbase.Price{}, err
+	}
+
+	return p, c.convertPrice(r, &p)
+}
+
+// GetPriceHistory returns the price history of `currencyPair`, converted at the current rate from the native
+// currency when it is quoted in Currency. An error is returned if the request or looking up the rates failed.
+func (c Client) GetPriceHistory(currencyPair string, from, to time.Time, granularity coinbase.Granularity) (coinbase.PriceHistory, error) {
+	pair, r, err := c.nativePair(currencyPair)
+	if err != nil {
+		return coinbase.PriceHistory{}, err
+	}
+	if pair == currencyPair {
+		return c.Client.GetPriceHistory(currencyPair, from, to, granularity)
+	}
+
+	h, err := c.Client.GetPriceHistory(pair, from, to, granularity)
+	if err != nil {
+		return coinbase.PriceHistory{}, err
+	}
+
+	h.CurrencyPair = currencyPair
+	for i := range h.Points {
+		if h.Points[i].Price, err = r.Convert(h.Points[i].Price, c.Currency); err != nil {
+			return coinbase.PriceHistory{}, err
+		}
+	}
+
+	return h, nil
+}
+
+// GetTransactionHistory returns the transactions of the account `accountID` with their native amounts converted to
+// Currency at the rate of the day they were made. Native amounts in an earlier native currency of the user are
+// converted as well. An error is returned if a request failed.
+func (c Client) GetTransactionHistory(accountID string, opts ...coinbase.ListOptions) (coinbase.Transaction, error) {
+	t, err := c.Client.GetTransactionHistory(accountID, opts...)
+	if err != nil {
+		return t, err
+	}
+
+	for i := range t.Data {
+		if t.Data[i].NativeAmount, err = c.convertOn(t.Data[i].NativeAmount, t.Data[i].CreatedAt); err != nil {
+			return coinbase.Transaction{}, err
+		}
+	}
+
+	return t, nil
+}
+
+// convertOn returns `m` in Currency at the rate of `date`. The rates of a currency are loaded once per day.
+func (c Client) convertOn(m money.Money, date time.Time) (money.Money, error) {
+	if m.Currency == "" || strings.EqualFold(m.Currency, c.Currency) {
+		return m, nil
+	}
+
+	base := strings.ToUpper(m.Currency)
+	key := base + "/" + date.UTC().Format("2006-01-02")
+
+	c.state.mu.Lock()
+	r, ok := c.state.daily[key]
+	c.state.mu.Unlock()
+
+	if !ok {
+		var err error
+		if r, err = Load(c.Client, base, []string{c.Currency}, date); err != nil {
+			return money.Money{}, err
+		}
+
+		c.state.mu.Lock()
+		c.state.daily[key] = r
+		c.state.mu.Unlock()
+	}
+
+	return r.Convert(m, c.Currency)
+}
+
+// nativePair returns `currencyPair` quoted in the native currency when it is quoted in Currency and the two differ,
+// otherwise `currencyPair` itself, and the rates. An error is returned if looking up the rates failed.
+func (c Client) nativePair(currencyPair string) (string, Rates, error) {
+	r, err := c.Rates()
+	if err != nil {
+		return currencyPair, Rates{}, err
+	}
+
+	base, quote, ok := strings.Cut(currencyPair, "-")
+	if !ok || r.Base == c.Currency || !strings.EqualFold(quote, c.Currency) {
+		return currencyPair, r, nil
+	}
+
+	return base + "-" + r.Base, r, nil
+}
+
+// convertPrice converts the price `p` in the native currency to Currency in place.
+func (c Client) convertPrice(r Rates, p *coinbase.Price) error {
+	m, err := r.Convert(p.Data.Money, c.Currency)
+	if err != nil {
+		return err
+	}
+	p.Data.Money = m
+
+	return nil
+}
+
+var _ coinbase.Client = Client{}
@@ -0,0 +1,41 @@
+package fx
+
+import (
+	"testing"
+
+	"github.com/KalebHawkins/crypto-client/coinbasetest"
+	"github.com/shopspring/decimal"
+)
+
+func TestClientTransactionsAtTheRateOfTheirDay(t *testing.T) {
+	srv := coinbasetest.NewServer()
+	defer srv.Close()
+
+	// The exchange-rates endpoint has 1 USD at 0.92 EUR, the reference prices 1 USD at 0.8 EUR.
+	srv.SetPrice("BTC-EUR", decimal.NewFromInt(40000))
+
+	c := NewClient(srv.Client(), "eur")
+	transactions, err := c.GetTransactionHistory(coinbasetest.BTCAccountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		"57ffb4ae-0c59-5430-bcd3-3f98f797a66c": "4800",
+		"4117f7d6-5694-5b36-bc8f-847509850ea4": "7200",
+	}
+	for _, tr := range transactions.Data {
+		w, ok := want[tr.ID]
+		if !ok {
+			continue
+		}
+		delete(want, tr.ID)
+
+		if tr.NativeAmount.Currency != "EUR" || !tr.NativeAmount.Amount.Equal(decimal.RequireFromString(w)) {
+			t.Errorf("native amount of %s = %s, want %s EUR", tr.ID, tr.NativeAmount, w)
+		}
+	}
+	if len(want) > 0 {
+		t.Errorf("transactions missing: %v", want)
+	}
+}
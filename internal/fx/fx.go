@@ -0,0 +1,124 @@
+/*
+Package fx converts amounts between fiat currencies. Coinbase has no endpoint for historical fiat exchange rates, so
+rates are derived from the price of a reference asset quoted in each currency: if BTC trades at 50000 USD and
+46000 EUR then 1 USD is worth 0.92 EUR. The same derivation works for live prices and for past dates, which keeps
+live and historical reports consistent with each other.
+
+FromExchangeRates loads the current rates of the Coinbase exchange-rates endpoint instead, which Client uses to show
+current amounts in another currency than the native currency of the user. Client converts the amounts of past
+transactions with the derived rates of their day.
+*/
+package fx
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/KalebHawkins/crypto-client/coinbase"
+	"github.com/KalebHawkins/crypto-client/money"
+	"github.com/shopspring/decimal"
+)
+
+// ReferenceAsset is the asset whose price in each currency the exchange rates are derived from.
+const ReferenceAsset = "BTC"
+
+// Rates holds the exchange rates from Base to a set of currencies at a point in time.
+type Rates struct {
+	Base string
+	Date time.Time
+
+	perBase map[string]decimal.Decimal
+}
+
+// Load returns the rates from `base` to every currency in `currencies` on `date`. A zero `date` loads the live
+// rates. An error is returned if the price of the reference asset cannot be looked up in one of the currencies.
+func Load(c coinbase.Client, base string, currencies []string, date time.Time) (Rates, error) {
+	base = strings.ToUpper(base)
+
+	basePrice, err := referencePrice(c, base, date)
+	if err != nil {
+		return Rates{}, err
+	}
+
+	r := Rates{Base: base, Date: date, perBase: map[string]decimal.Decimal{base: decimal.NewFromInt(1)}}
+	for _, cur := range currencies {
+		cur = strings.ToUpper(cur)
+		if _, ok := r.perBase[cur]; ok {
+			continue
+		}
+
+		p, err := referencePrice(c, cur, date)
+		if err != nil {
+			return Rates{}, err
+		}
+		r.perBase[cur] = p.Div(basePrice)
+	}
+
+	return r, nil
+}
+
+// FromExchangeRates returns the current rates from `base` to every currency Coinbase has an exchange rate for. An
+// error is returned if the request failed.
+func FromExchangeRates(c coinbase.Client, base string) (Rates, error) {
+	base = strings.ToUpper(base)
+
+	e, err := c.GetExchangeRate(base)
+	if err != nil {
+		return Rates{}, fmt.Errorf("fx: looking up the exchange rates of %s: %v", base, err)
+	}
+
+	r := Rates{Base: base, perBase: map[string]decimal.Decimal{base: decimal.NewFromInt(1)}}
+	for cur, rate := range e.Data.Rates {
+		if rate.IsPositive() {
+			r.perBase[strings.ToUpper(cur)] = rate
+		}
+	}
+
+	return r, nil
+}
+
+// Rate returns how many units of `currency` one unit of Base is worth.
+func (r Rates) Rate(currency string) (decimal.Decimal, bool) {
+	d, ok := r.perBase[strings.ToUpper(currency)]
+
+	return d, ok
+}
+
+// Convert returns `m`, an amount in Base, expressed in `currency`. An error is returned if `m` is not in Base or no
+// rate was loaded for `currency`.
+func (r Rates) Convert(m money.Money, currency string) (money.Money, error) {
+	if !m.IsZero() && m.Currency != r.Base {
+		return money.Money{}, fmt.Errorf("fx: cannot convert %s, rates are based on %s", m.Currency, r.Base)
+	}
+
+	rate, ok := r.Rate(currency)
+	if !ok {
+		return money.Money{}, fmt.Errorf("fx: no %s-%s rate loaded", r.Base, strings.ToUpper(currency))
+	}
+
+	return money.New(m.Amount.Mul(rate), strings.ToUpper(currency)), nil
+}
+
+// referencePrice returns the spot price of the reference asset in `currency` on `date`, or the live price when
+// `date` is zero.
+func referencePrice(c coinbase.Client, currency string, date time.Time) (decimal.Decimal, error) {
+	pair := ReferenceAsset + "-" + currency
+
+	var p coinbase.Price
+	var err error
+	if date.IsZero() {
+		p, err = c.GetPrice(pair, coinbase.Spot)
+	} else {
+		p, err = c.GetPriceByDate(pair, date)
+	}
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("fx: looking up %s: %v", pair, err)
+	}
+
+	if !p.Data.Amount.IsPositive() {
+		return decimal.Zero, fmt.Errorf("fx: no %s price", pair)
+	}
+
+	return p.Data.Amount, nil
+}
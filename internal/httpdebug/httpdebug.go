@@ -0,0 +1,261 @@
+/*
+Package httpdebug provides an http.RoundTripper that writes every request and response, headers and bodies, to a
+writer to diagnose signature and permission errors. Credentials are redacted: the values of headers, query
+parameters, form fields and JSON fields whose name suggests a key, signature, secret, passphrase, password or
+token are replaced, so a dump can be shared when asking for help.
+*/
+package httpdebug
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Redacted replaces the values of sensitive headers and fields.
+const Redacted = "REDACTED"
+
+// MaxBody is the most bytes of a body that are written, the rest is summarized.
+const MaxBody = 64 << 10
+
+// sensitive are substrings of the lower cased names of headers and fields that hold credentials.
+var sensitive = []string{
+	"authorization", "cookie", "secret", "passphrase", "password", "token", "sign", "otp",
+	"apikey", "api-key", "api_key", "access-key", "access_key",
+}
+
+// Transport wraps an http.RoundTripper and writes every request sent through it and the response to Out. The
+// bodies are read in full and handed on unchanged.
+type Transport struct {
+	Base http.RoundTripper
+	Out  io.Writer
+
+	mu sync.Mutex
+}
+
+// NewTransport returns a Transport writing the requests sent through `base` and their responses to `out`. A nil
+// `base` uses http.DefaultTransport.
+func NewTransport(base http.RoundTripper, out io.Writer) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &Transport{Base: base, Out: out}
+}
+
+// Unwrap returns the RoundTripper the requests are sent through.
+func (t *Transport) Unwrap() http.RoundTripper {
+	return t.Base
+}
+
+// WithBase returns a Transport writing the requests sent through `base` and their responses to the same writer.
+func (t *Transport) WithBase(base http.RoundTripper) http.RoundTripper {
+	return NewTransport(base, t.Out)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		b, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		reqBody = b
+		req.Body = ioutil.NopCloser(bytes.NewReader(b))
+	}
+
+	start := time.Now()
+	resp, err := t.Base.RoundTrip(req)
+	elapsed := time.Since(start).Round(time.Millisecond)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "--> %s %s\n", req.Method, redactURL(req.URL))
+	writeHeader(&buf, req.Header)
+	writeBody(&buf, req.Header.Get("Content-Type"), reqBody)
+
+	if err != nil {
+		fmt.Fprintf(&buf, "<-- %v (%s)\n\n", err, elapsed)
+		t.write(buf.Bytes())
+		return nil, err
+	}
+
+	respBody, readErr := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	fmt.Fprintf(&buf, "<-- %s %s (%s)\n", resp.Proto, resp.Status, elapsed)
+	writeHeader(&buf, resp.Header)
+	writeBody(&buf, resp.Header.Get("Content-Type"), respBody)
+	if readErr != nil {
+		fmt.Fprintf(&buf, "reading the body: %v\n", readErr)
+		resp.Body = ioutil.NopCloser(io.MultiReader(bytes.NewReader(respBody), errReader{readErr}))
+	}
+	buf.WriteString("\n")
+	t.write(buf.Bytes())
+
+	return resp, nil
+}
+
+// write writes one exchange at once, so the exchanges of concurrent requests are not interleaved.
+func (t *Transport) write(b []byte) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.Out.Write(b)
+}
+
+// ─── HELPER FUNCTIONS ───────────────────────────────────────────────────────────
+
+// isSensitive reports whether the header or field `name` holds a credential.
+func isSensitive(name string) bool {
+	name = strings.ToLower(name)
+	for _, s := range sensitive {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// redactURL returns `u` with the values of sensitive query parameters and any user info replaced.
+func redactURL(u *url.URL) string {
+	c := *u
+	if c.User != nil {
+		c.User = url.User(Redacted)
+	}
+	c.RawQuery = redactValues(c.RawQuery)
+
+	return c.String()
+}
+
+// redactValues replaces the values of the sensitive fields of a query string or form body. The input is returned
+// unchanged when it does not parse.
+func redactValues(s string) string {
+	values, err := url.ParseQuery(s)
+	if err != nil || len(values) == 0 {
+		return s
+	}
+
+	changed := false
+	for k, vs := range values {
+		if isSensitive(k) {
+			for i := range vs {
+				vs[i] = Redacted
+			}
+			changed = true
+		}
+	}
+	if !changed {
+		return s
+	}
+
+	return values.Encode()
+}
+
+// writeHeader writes the header fields of `h` sorted by name, with sensitive values replaced.
+func writeHeader(w *bytes.Buffer, h http.Header) {
+	names := make([]string, 0, len(h))
+	for n := range h {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	for _, n := range names {
+		for _, v := range h[n] {
+			if isSensitive(n) {
+				v = Redacted
+			}
+			fmt.Fprintf(w, "%s: %s\n", n, v)
+		}
+	}
+}
+
+// writeBody writes a blank line and `body` with its sensitive fields replaced, nothing when the body is empty.
+// JSON and form bodies are redacted field by field, other bodies are written as they are.
+func writeBody(w *bytes.Buffer, contentType string, body []byte) {
+	if len(body) == 0 {
+		return
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case mediaType == "application/x-www-form-urlencoded":
+		body = []byte(redactValues(string(body)))
+	case json.Valid(body):
+		body = redactJSON(body)
+	}
+
+	body = bytes.TrimRight(body, "\r\n")
+	w.WriteString("\n")
+	if len(body) > MaxBody {
+		fmt.Fprintf(w, "%s\n... %d more bytes\n", body[:MaxBody], len(body)-MaxBody)
+		return
+	}
+	w.Write(body)
+	w.WriteString("\n")
+}
+
+// redactJSON replaces the values of sensitive object fields at any depth. The body is returned unchanged when it
+// has none, to keep its formatting.
+func redactJSON(body []byte) []byte {
+	var v interface{}
+	d := json.NewDecoder(bytes.NewReader(body))
+	d.UseNumber()
+	if err := d.Decode(&v); err != nil {
+		return body
+	}
+
+	if !redactValue(v) {
+		return body
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return body
+	}
+
+	return b
+}
+
+// redactValue replaces the sensitive fields of the decoded JSON value `v` in place and reports whether there were
+// any.
+func redactValue(v interface{}) bool {
+	changed := false
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, f := range v {
+			if isSensitive(k) {
+				v[k] = Redacted
+				changed = true
+				continue
+			}
+			changed = redactValue(f) || changed
+		}
+	case []interface{}:
+		for _, f := range v {
+			changed = redactValue(f) || changed
+		}
+	}
+
+	return changed
+}
+
+// errReader returns its error, to hand a failed read of the response body on to the caller.
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read(p []byte) (int, error) {
+	return 0, r.err
+}
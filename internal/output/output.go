@@ -0,0 +1,215 @@
+/*
+Package output renders tabular command results as an aligned table or in a machine readable format. Every format
+is fed the same Records so a command only has to describe its results once.
+
+JSON and YAML render a list of objects keyed by the snake cased column headers, in column order. CSV renders a
+header row followed by the rows.
+*/
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/rodaine/table"
+	"gopkg.in/yaml.v3"
+)
+
+// These constants are the supported output formats.
+const (
+	Table = "table"
+	JSON  = "json"
+	YAML  = "yaml"
+	CSV   = "csv"
+)
+
+// Formats lists the supported output formats.
+var Formats = []string{Table, JSON, YAML, CSV}
+
+// Records are the rows of a table along with its column headers. Cells are kept as given so JSON and YAML can
+// encode numbers and booleans natively.
+type Records struct {
+	Headers []string
+	Rows    [][]interface{}
+}
+
+// Validate returns an error naming the supported formats if `format` is not one of them.
+func Validate(format string) error {
+	for _, f := range Formats {
+		if format == f {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown output format %q, expected one of %s", format, strings.Join(Formats, ", "))
+}
+
+// Write renders `r` to `w` in `format`.
+func Write(w io.Writer, format string, r Records) error {
+	switch format {
+	case Table:
+		return writeTable(w, r)
+	case JSON:
+		return writeJSON(w, r)
+	case YAML:
+		return writeYAML(w, r)
+	case CSV:
+		return writeCSV(w, r)
+	}
+
+	return Validate(format)
+}
+
+// writeTable renders `r` as aligned columns.
+func writeTable(w io.Writer, r Records) error {
+	headers := make([]interface{}, len(r.Headers))
+	for i, h := range r.Headers {
+		headers[i] = h
+	}
+
+	tbl := table.New(headers...).WithWriter(w)
+	for _, row := range r.Rows {
+		tbl.AddRow(row...)
+	}
+	tbl.Print()
+
+	return nil
+}
+
+// writeJSON renders `r` as an indented JSON array of objects.
+func writeJSON(w io.Writer, r Records) error {
+	keys := r.keys()
+
+	objects := make([]orderedObject, 0, len(r.Rows))
+	for _, row := range r.Rows {
+		objects = append(objects, orderedObject{keys: keys, values: row})
+	}
+
+	b, err := json.MarshalIndent(objects, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(w, string(b))
+
+	return err
+}
+
+// writeYAML renders `r` as a YAML document holding a sequence of mappings.
+func writeYAML(w io.Writer, r Records) error {
+	keys := r.keys()
+
+	seq := &yaml.Node{Kind: yaml.SequenceNode}
+	for _, row := range r.Rows {
+		m := &yaml.Node{Kind: yaml.MappingNode}
+		for i, k := range keys {
+			v := &yaml.Node{}
+			if err := v.Encode(cell(row, i)); err != nil {
+				return err
+			}
+			m.Content = append(m.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: k}, v)
+		}
+		seq.Content = append(seq.Content, m)
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(seq); err != nil {
+		return err
+	}
+
+	return enc.Close()
+}
+
+// writeCSV renders `r` as a header row followed by the rows.
+func writeCSV(w io.Writer, r Records) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(r.Headers); err != nil {
+		return err
+	}
+
+	for _, row := range r.Rows {
+		record := make([]string, len(r.Headers))
+		for i := range record {
+			record[i] = fmt.Sprint(cell(row, i))
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// nonAlphanumeric matches the characters replaced when turning a header into a key.
+var nonAlphanumeric = regexp.MustCompile(`[^a-z0-9]+`)
+
+// keys returns the snake cased headers, for example spot_price_per_unit for "Spot Price Per Unit". Headers
+// without a usable name become column_<n>.
+func (r Records) keys() []string {
+	keys := make([]string, len(r.Headers))
+	for i, h := range r.Headers {
+		k := strings.Trim(nonAlphanumeric.ReplaceAllString(strings.ToLower(h), "_"), "_")
+		if k == "" {
+			k = fmt.Sprintf("column_%d", i+1)
+		}
+		keys[i] = k
+	}
+
+	return keys
+}
+
+// cell returns the value of column `i` of `row` for encoding. Numbers and booleans are kept, anything else is
+// formatted as a string. Missing cells are empty strings.
+func cell(row []interface{}, i int) interface{} {
+	if i >= len(row) {
+		return ""
+	}
+
+	switch v := row[i].(type) {
+	case bool, int, int32, int64, float32, float64:
+		return v
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// orderedObject is a JSON object whose keys are encoded in the given order instead of sorted.
+type orderedObject struct {
+	keys   []string
+	values []interface{}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (o orderedObject) MarshalJSON() ([]byte, error) {
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, k := range o.keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+
+		key, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		val, err := json.Marshal(cell(o.values, i))
+		if err != nil {
+			return nil, err
+		}
+
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(val)
+	}
+	b.WriteByte('}')
+
+	return []byte(b.String()), nil
+}
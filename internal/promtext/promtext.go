@@ -0,0 +1,109 @@
+/*
+Package promtext writes gauges in the Prometheus text exposition format, which is all a scrape target needs and
+avoids depending on the Prometheus client library.
+*/
+package promtext
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ContentType is the content type of the text exposition format.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Gauge is a metric family of gauges sharing a name and help text.
+type Gauge struct {
+	Name    string
+	Help    string
+	Samples []Sample
+}
+
+// Sample is one value of a Gauge, told apart from the others by its labels.
+type Sample struct {
+	Labels map[string]string
+	Value  float64
+}
+
+// Add appends a sample with `value` and the label pairs `labels`, given as name, value, name, value and so on.
+func (g *Gauge) Add(value float64, labels ...string) {
+	s := Sample{Value: value, Labels: map[string]string{}}
+	for i := 0; i+1 < len(labels); i += 2 {
+		s.Labels[labels[i]] = labels[i+1]
+	}
+	g.Samples = append(g.Samples, s)
+}
+
+// Write writes `gauges` to `w`. Labels are written in alphabetical order so the output is stable. Gauges without
+// samples are skipped.
+func Write(w io.Writer, gauges []Gauge) error {
+	bw := bufio.NewWriter(w)
+	for _, g := range gauges {
+		if len(g.Samples) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(bw, "# HELP %s %s\n", g.Name, escapeHelp(g.Help))
+		fmt.Fprintf(bw, "# TYPE %s gauge\n", g.Name)
+		for _, s := range g.Samples {
+			bw.WriteString(g.Name)
+			writeLabels(bw, s.Labels)
+			bw.WriteString(" ")
+			bw.WriteString(formatValue(s.Value))
+			bw.WriteString("\n")
+		}
+	}
+
+	return bw.Flush()
+}
+
+// writeLabels writes `labels` in braces, or nothing when there are none.
+func writeLabels(w *bufio.Writer, labels map[string]string) {
+	if len(labels) == 0 {
+		return
+	}
+
+	names := make([]string, 0, len(labels))
+	for n := range labels {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	w.WriteString("{")
+	for i, n := range names {
+		if i > 0 {
+			w.WriteString(",")
+		}
+		fmt.Fprintf(w, "%s=\"%s\"", n, escapeLabel(labels[n]))
+	}
+	w.WriteString("}")
+}
+
+// formatValue formats `v` as Prometheus expects, including its spelling of the special values.
+func formatValue(v float64) string {
+	switch {
+	case math.IsNaN(v):
+		return "NaN"
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// escapeLabel escapes backslashes, double quotes and line feeds in a label value.
+func escapeLabel(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
+
+// escapeHelp escapes backslashes and line feeds in a help text.
+func escapeHelp(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(s)
+}
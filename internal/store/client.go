@@ -0,0 +1,312 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/KalebHawkins/crypto-client/coinbase"
+)
+
+// ErrNotStored is returned by an offline Client for data that was never synced.
+var ErrNotStored = errors.New("not in the local store, run once online with --cache to store it")
+
+// Client is a coinbase.Client keeping the history in a Store. Accounts, the user profile, current prices and
+// exchange rates are still fetched live and stored for later, transactions are synced incrementally and read from
+// the store, and historical prices are fetched once. Every other method is passed on to the wrapped client.
+type Client struct {
+	coinbase.Client
+	store *Store
+
+	offline bool
+	asOf    *asOf
+}
+
+// asOf tracks the oldest data an offline Client served.
+type asOf struct {
+	mu     sync.Mutex
+	oldest time.Time
+}
+
+// NewClient returns a client syncing the history of `c` into `s`.
+func NewClient(c coinbase.Client, s *Store) Client {
+	return Client{Client: c, store: s}
+}
+
+// Offline returns a copy of the client that only serves what is stored, as of the last sync, and never calls the
+// wrapped client for the stored data. AsOf() reports how old the served data is.
+func (c Client) Offline() Client {
+	c.offline = true
+	c.asOf = &asOf{}
+
+	return c
+}
+
+// AsOf returns when the oldest data served by an offline client was looked up, the zero time if none was served.
+func (c Client) AsOf() time.Time {
+	if c.asOf == nil {
+		return time.Time{}
+	}
+
+	c.asOf.mu.Lock()
+	defer c.asOf.mu.Unlock()
+
+	return c.asOf.oldest
+}
+
+// served records that data looked up at `at` was served offline.
+func (c Client) served(at time.Time) {
+	c.asOf.mu.Lock()
+	defer c.asOf.mu.Unlock()
+
+	if c.asOf.oldest.IsZero() || at.Before(c.asOf.oldest) {
+		c.asOf.oldest = at
+	}
+}
+
+// GetUserProfile upon a successful API request returns the user profile and stores it. Offline the stored profile
+// is returned. An error is returned if the request or storing the profile failed.
+func (c Client) GetUserProfile() (coinbase.User, error) {
+	if c.offline {
+		u, at, ok, err := c.store.User()
+		return u, c.stored("the user profile", at, ok, err)
+	}
+
+	u, err := c.Client.GetUserProfile()
+	if err != nil {
+		return coinbase.User{}, err
+	}
+
+	return u, c.store.PutUser(u, time.Now())
+}
+
+// GetAccount upon a successful API request returns the accounts and stores them. Offline the stored accounts are
+// returned. An error is returned if the request or storing the accounts failed.
+func (c Client) GetAccount() (coinbase.Account, error) {
+	if c.offline {
+		a, at, ok, err := c.store.Accounts()
+		return a, c.stored("the accounts", at, ok, err)
+	}
+
+	a, err := c.Client.GetAccount()
+	if err != nil {
+		return coinbase.Account{}, err
+	}
+
+	return a, c.store.PutAccounts(a, time.Now())
+}
+
+// GetPrice upon a successful API request returns the current `priceType` price of `currencyPair` and stores it.
+// Offline the last looked up price is returned. An error is returned if the request or storing the price failed.
+func (c Client) GetPrice(currencyPair string, priceType string) (coinbase.Price, error) {
+	if c.offline {
+		p, at, ok, err := c.store.LatestPrice(currencyPair, priceType)
+		return p, c.stored(fmt.Sprintf("the %s price of %s", priceType, currencyPair), at, ok, err)
+	}
+
+	p, err := c.Client.GetPrice(currencyPair, priceType)
+	if err != nil {
+		return coinbase.Price{}, err
+	}
+
+	return p, c.store.PutLatestPrice(currencyPair, priceType, p, time.Now())
+}
+
+// GetPrices upon successful API requests returns the current `priceType` price of every pair in `pairs` and stores
+// them. Offline the last looked up prices are returned. An error is returned if a request or storing a price failed.
+func (c Client) GetPrices(pairs []string, priceType string) (map[string]coinbase.Price, error) {
+	if c.offline {
+		prices := map[string]coinbase.Price{}
+		for _, p := range pairs {
+			price, err := c.GetPrice(p, priceType)
+			if err != nil {
+				return nil, err
+			}
+			prices[p] = price
+		}
+
+		return prices, nil
+	}
+
+	prices, err := c.Client.GetPrices(pairs, priceType)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for p, price := range prices {
+		if err := c.store.PutLatestPrice(p, priceType, price, now); err != nil {
+			return nil, err
+		}
+	}
+
+	return prices, nil
+}
+
+// GetExchangeRate upon a successful API request returns the exchange rates from `currency` and stores them.
+// Offline the last looked up rates are returned. An error is returned if the request or storing the rates failed.
+func (c Client) GetExchangeRate(currency string) (coinbase.ExchangeRate, error) {
+	if c.offline {
+		r, at, ok, err := c.store.ExchangeRate(currency)
+		return r, c.stored(fmt.Sprintf("the %s exchange rates", currency), at, ok, err)
+	}
+
+	r, err := c.Client.GetExchangeRate(currency)
+	if err != nil {
+		return coinbase.ExchangeRate{}, err
+	}
+
+	return r, c.store.PutExchangeRate(currency, r, time.Now())
+}
+
+// GetTransactionHistory syncs the transactions of the account `accountID` and returns them from the store, newest
+// first. Offline the transactions of the last sync are returned. `opts` applies as for the wrapped client. An error
+// is returned if the sync failed.
+func (c Client) GetTransactionHistory(accountID string, opts ...coinbase.ListOptions) (coinbase.Transaction, error) {
+	if c.offline {
+		at, err := c.store.Synced(accountID)
+		if err := c.stored("the transactions of account "+accountID, at, !at.IsZero(), err); err != nil {
+			return coinbase.Transaction{}, err
+		}
+	} else if _, err := c.Sync(accountID); err != nil {
+		return coinbase.Transaction{}, err
+	}
+
+	stored, err := c.store.Transactions(accountID)
+	if err != nil {
+		return coinbase.Transaction{}, err
+	}
+
+	var o coinbase.ListOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+
+	var t coinbase.Transaction
+	for _, tr := range stored {
+		if !o.Since.IsZero() && tr.CreatedAt.Before(o.Since) {
+			break
+		}
+		if o.Limit > 0 && len(t.Data) == o.Limit {
+			break
+		}
+		t.Data = append(t.Data, tr)
+	}
+
+	return t, nil
+}
+
+// Sync upon a successful API request stores the transactions of the account `accountID` created since the last
+// sync, refetching those that were still pending, and returns how many were fetched. The first sync fetches the
+// whole history. An error is returned if the request or storing the transactions failed.
+func (c Client) Sync(accountID string) (int, error) {
+	since, err := c.store.SyncPoint(accountID)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	t, err := c.Client.GetTransactionHistory(accountID, coinbase.ListOptions{Since: since})
+	if err != nil {
+		return 0, err
+	}
+
+	if err := c.store.PutTransactions(accountID, t.Data); err != nil {
+		return 0, err
+	}
+
+	return len(t.Data), c.store.SetSynced(accountID, now)
+}
+
+// GetPriceByDate returns the spot price of `currencyPair` on the date of `date` from the store, or upon a successful
+// API request. Prices of past dates are stored, the price of today still changes. An error is returned if the
+// request failed, or offline if the price is not stored.
+func (c Client) GetPriceByDate(currencyPair string, date time.Time) (coinbase.Price, error) {
+	p, ok, err := c.store.Price(currencyPair, date)
+	if err != nil || ok {
+		return p, err
+	}
+
+	if c.offline {
+		return coinbase.Price{}, fmt.Errorf("the price of %s on %s is %w", currencyPair, date.Format("2006-01-02"), ErrNotStored)
+	}
+
+	p, err = c.Client.GetPriceByDate(currencyPair, date)
+	if err != nil {
+		return coinbase.Price{}, err
+	}
+
+	if past(date) {
+		return p, c.store.PutPrice(currencyPair, date, p)
+	}
+
+	return p, nil
+}
+
+// GetPriceHistory returns the price history of `currencyPair` from the store when every point is stored, otherwise
+// it is fetched as by the wrapped client and its past points are stored. An error is returned if the request failed,
+// or offline if a point is not stored.
+func (c Client) GetPriceHistory(currencyPair string, from, to time.Time, granularity coinbase.Granularity) (coinbase.PriceHistory, error) {
+	h := coinbase.PriceHistory{CurrencyPair: currencyPair}
+	for _, d := range granularity.Dates(from, to) {
+		p, ok, err := c.store.Price(currencyPair, d)
+		if err != nil {
+			return coinbase.PriceHistory{}, err
+		}
+		if !ok {
+			h.Points = nil
+			break
+		}
+		h.Points = append(h.Points, coinbase.PricePoint{Time: d, Price: p.Data.Money})
+	}
+
+	if len(h.Points) > 0 {
+		return h, nil
+	}
+
+	if c.offline {
+		return coinbase.PriceHistory{}, fmt.Errorf("the price history of %s is %w", currencyPair, ErrNotStored)
+	}
+
+	h, err := c.Client.GetPriceHistory(currencyPair, from, to, granularity)
+	if err != nil {
+		return coinbase.PriceHistory{}, err
+	}
+
+	for _, pt := range h.Points {
+		if !past(pt.Time) {
+			continue
+		}
+
+		var p coinbase.Price
+		p.Data.Base = strings.SplitN(currencyPair, "-", 2)[0]
+		p.Data.Money = pt.Price
+		if err := c.store.PutPrice(currencyPair, pt.Time, p); err != nil {
+			return coinbase.PriceHistory{}, err
+		}
+	}
+
+	return h, nil
+}
+
+// stored records that `what`, looked up at `at`, was served offline. An error is returned if reading it from the
+// store failed or it is not stored.
+func (c Client) stored(what string, at time.Time, ok bool, err error) error {
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("%s is %w", what, ErrNotStored)
+	}
+
+	c.served(at)
+
+	return nil
+}
+
+// past reports whether the date of `date` is over in UTC, so its price no longer changes.
+func past(date time.Time) bool {
+	return date.UTC().Format("2006-01-02") < time.Now().UTC().Format("2006-01-02")
+}
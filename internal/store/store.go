@@ -0,0 +1,388 @@
+/*
+Package store persists Coinbase accounts, transactions and historical prices in a local bbolt database, so
+repeated runs only download what changed since the last sync and analytics can query the history locally. Every
+credential profile has its own database in the user's configuration directory.
+*/
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/KalebHawkins/crypto-client/coinbase"
+	"github.com/KalebHawkins/crypto-client/internal/userdata"
+	bolt "go.etcd.io/bbolt"
+)
+
+// These are the top level buckets of the database. Transactions holds a nested bucket per account keyed by the
+// creation time and ID of the transaction, so a cursor walks them in chronological order. Latest holds the last
+// looked up value of what changes all the time, such as the user profile, the current prices and exchange rates.
+// Trades holds the trades imported from elsewhere, keyed like the transactions.
+var (
+	accountsBucket     = []byte("accounts")
+	transactionsBucket = []byte("transactions")
+	pricesBucket       = []byte("prices")
+	syncsBucket        = []byte("syncs")
+	latestBucket       = []byte("latest")
+	tradesBucket       = []byte("trades")
+)
+
+// buckets are the top level buckets holding what can be downloaded again, which Clear() empties.
+var buckets = [][]byte{accountsBucket, transactionsBucket, pricesBucket, syncsBucket, latestBucket}
+
+// accountsKey is the key of the Coinbase account list in the accounts bucket.
+var accountsKey = []byte("coinbase")
+
+// stamped is a stored value with the time it was looked up.
+type stamped struct {
+	At    time.Time       `json:"at"`
+	Value json.RawMessage `json:"value"`
+}
+
+// openTimeout is how long Open waits for another process holding the database to release it.
+const openTimeout = time.Second
+
+// Store is a local database of the Coinbase history. It is safe for concurrent use.
+type Store struct {
+	db *bolt.DB
+}
+
+// Path returns the path of the database of `profile`, for example ~/.config/crypto-client/store.db for the
+// default profile and store_<profile>.db for a named one.
+func Path(profile string) (string, error) {
+	dir, err := userdata.Dir()
+	if err != nil {
+		return "", err
+	}
+
+	if profile != "" {
+		return filepath.Join(dir, "store_"+profile+".db"), nil
+	}
+
+	return filepath.Join(dir, "store.db"), nil
+}
+
+// Open opens the database at `path`, creating it and its directory if needed. An error is returned if the
+// database is held by another process for longer than a second or is not a valid database.
+func Open(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: openTimeout})
+	if errors.Is(err, bolt.ErrTimeout) {
+		return nil, fmt.Errorf("the store %s is in use by another crypto-client process", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("opening the store %s: %w", path, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, b := range append([][]byte{tradesBucket}, buckets...) {
+			if _, err := tx.CreateBucketIfNotExists(b); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Close releases the database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Path returns the path of the database file.
+func (s *Store) Path() string {
+	return s.db.Path()
+}
+
+// PutAccounts replaces the stored account list, looked up at `at`.
+func (s *Store) PutAccounts(a coinbase.Account, at time.Time) error {
+	return s.putStamped(accountsBucket, accountsKey, a, at)
+}
+
+// Accounts returns the stored account list, when it was looked up and whether one is stored.
+func (s *Store) Accounts() (coinbase.Account, time.Time, bool, error) {
+	var a coinbase.Account
+	at, ok, err := s.getStamped(accountsBucket, accountsKey, &a)
+
+	return a, at, ok, err
+}
+
+// PutUser replaces the stored user profile, looked up at `at`.
+func (s *Store) PutUser(u coinbase.User, at time.Time) error {
+	return s.putStamped(latestBucket, []byte("user"), u, at)
+}
+
+// User returns the stored user profile, when it was looked up and whether one is stored.
+func (s *Store) User() (coinbase.User, time.Time, bool, error) {
+	var u coinbase.User
+	at, ok, err := s.getStamped(latestBucket, []byte("user"), &u)
+
+	return u, at, ok, err
+}
+
+// PutLatestPrice replaces the stored current `priceType` price of `currencyPair`, looked up at `at`.
+func (s *Store) PutLatestPrice(currencyPair string, priceType string, p coinbase.Price, at time.Time) error {
+	return s.putStamped(latestBucket, []byte("price/"+currencyPair+"/"+priceType), p, at)
+}
+
+// LatestPrice returns the last looked up `priceType` price of `currencyPair`, when it was looked up and whether
+// one is stored.
+func (s *Store) LatestPrice(currencyPair string, priceType string) (coinbase.Price, time.Time, bool, error) {
+	var p coinbase.Price
+	at, ok, err := s.getStamped(latestBucket, []byte("price/"+currencyPair+"/"+priceType), &p)
+
+	return p, at, ok, err
+}
+
+// PutExchangeRate replaces the stored exchange rates from `currency`, looked up at `at`.
+func (s *Store) PutExchangeRate(currency string, r coinbase.ExchangeRate, at time.Time) error {
+	return s.putStamped(latestBucket, []byte("rates/"+currency), r, at)
+}
+
+// ExchangeRate returns the last looked up exchange rates from `currency`, when they were looked up and whether
+// they are stored.
+func (s *Store) ExchangeRate(currency string) (coinbase.ExchangeRate, time.Time, bool, error) {
+	var r coinbase.ExchangeRate
+	at, ok, err := s.getStamped(latestBucket, []byte("rates/"+currency), &r)
+
+	return r, at, ok, err
+}
+
+// PutTransactions stores the transactions of the account `accountID`, replacing stored transactions with the same
+// ID and creation time.
+func (s *Store) PutTransactions(accountID string, transactions []coinbase.TransactionData) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.Bucket(transactionsBucket).CreateBucketIfNotExists([]byte(accountID))
+		if err != nil {
+			return err
+		}
+
+		for _, t := range transactions {
+			v, err := json.Marshal(t)
+			if err != nil {
+				return err
+			}
+			if err := b.Put(transactionKey(t), v); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Transactions returns the stored transactions of the account `accountID`, newest first like the Coinbase API.
+func (s *Store) Transactions(accountID string) ([]coinbase.TransactionData, error) {
+	var transactions []coinbase.TransactionData
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(transactionsBucket).Bucket([]byte(accountID))
+		if b == nil {
+			return nil
+		}
+
+		c := b.Cursor()
+		for k, v := c.Last(); k != nil; k, v = c.Prev() {
+			var t coinbase.TransactionData
+			if err := json.Unmarshal(v, &t); err != nil {
+				return fmt.Errorf("reading stored transaction %s: %w", k, err)
+			}
+			transactions = append(transactions, t)
+		}
+
+		return nil
+	})
+
+	return transactions, err
+}
+
+// SyncPoint returns the time from which the transactions of the account `accountID` must be fetched again to
+// bring the store up to date: the creation time of the oldest stored transaction that was still pending, or of the
+// newest stored transaction. The zero time is returned when the account was never synced.
+func (s *Store) SyncPoint(accountID string) (time.Time, error) {
+	var since time.Time
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(transactionsBucket).Bucket([]byte(accountID))
+		if b == nil {
+			return nil
+		}
+
+		return b.ForEach(func(k, v []byte) error {
+			var t coinbase.TransactionData
+			if err := json.Unmarshal(v, &t); err != nil {
+				return fmt.Errorf("reading stored transaction %s: %w", k, err)
+			}
+
+			// Keys are in chronological order, so the first pending transaction is the oldest one.
+			since = t.CreatedAt
+			if t.Status == "pending" {
+				return errStop
+			}
+
+			return nil
+		})
+	})
+	if errors.Is(err, errStop) {
+		err = nil
+	}
+
+	return since, err
+}
+
+// errStop ends a ForEach early.
+var errStop = errors.New("stop")
+
+// SetSynced records that the transactions of the account `accountID` were synced at `at`.
+func (s *Store) SetSynced(accountID string, at time.Time) error {
+	return s.put(syncsBucket, []byte(accountID), at)
+}
+
+// Synced returns when the transactions of the account `accountID` were last synced, the zero time if never.
+func (s *Store) Synced(accountID string) (time.Time, error) {
+	var at time.Time
+	_, err := s.get(syncsBucket, []byte(accountID), &at)
+
+	return at, err
+}
+
+// PutPrice stores the spot price of `currencyPair` on the date of `date`.
+func (s *Store) PutPrice(currencyPair string, date time.Time, p coinbase.Price) error {
+	return s.put(pricesBucket, priceKey(currencyPair, date), p)
+}
+
+// Price returns the stored spot price of `currencyPair` on the date of `date` and whether one is stored.
+func (s *Store) Price(currencyPair string, date time.Time) (coinbase.Price, bool, error) {
+	var p coinbase.Price
+	ok, err := s.get(pricesBucket, priceKey(currencyPair, date), &p)
+
+	return p, ok, err
+}
+
+// Stats counts what is stored.
+type Stats struct {
+	Accounts     int
+	Transactions int
+	Prices       int
+	Trades       int
+	Size         int64
+}
+
+// Stats returns the number of stored accounts, transactions, prices and imported trades, and the size of the
+// database file.
+func (s *Store) Stats() (Stats, error) {
+	var st Stats
+	err := s.db.View(func(tx *bolt.Tx) error {
+		st.Size = tx.Size()
+		st.Prices = tx.Bucket(pricesBucket).Stats().KeyN
+		st.Trades = tx.Bucket(tradesBucket).Stats().KeyN
+
+		var a coinbase.Account
+		if v := tx.Bucket(accountsBucket).Get(accountsKey); v != nil {
+			var entry stamped
+			if err := json.Unmarshal(v, &entry); err != nil {
+				return err
+			}
+			if err := json.Unmarshal(entry.Value, &a); err != nil {
+				return err
+			}
+		}
+		st.Accounts = len(a.Data)
+
+		return tx.Bucket(transactionsBucket).ForEach(func(k, _ []byte) error {
+			st.Transactions += tx.Bucket(transactionsBucket).Bucket(k).Stats().KeyN
+			return nil
+		})
+	})
+
+	return st, err
+}
+
+// Clear removes everything stored except the imported trades, which cannot be downloaded again. Remove those with
+// RemoveTrades().
+func (s *Store) Clear() error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		for _, b := range buckets {
+			if err := tx.DeleteBucket(b); err != nil {
+				return err
+			}
+			if _, err := tx.CreateBucket(b); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ─── HELPER FUNCTIONS ───────────────────────────────────────────────────────────
+
+// put stores `v` as JSON under `key` in the top level bucket `bucket`.
+func (s *Store) put(bucket []byte, key []byte, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucket).Put(key, b)
+	})
+}
+
+// get parses the JSON stored under `key` in the top level bucket `bucket` into `v` and reports whether it exists.
+func (s *Store) get(bucket []byte, key []byte, v interface{}) (bool, error) {
+	var found bool
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucket).Get(key)
+		if b == nil {
+			return nil
+		}
+		found = true
+
+		return json.Unmarshal(b, v)
+	})
+
+	return found, err
+}
+
+// putStamped stores `v` looked up at `at` under `key` in the top level bucket `bucket`.
+func (s *Store) putStamped(bucket []byte, key []byte, v interface{}, at time.Time) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	return s.put(bucket, key, stamped{At: at, Value: b})
+}
+
+// getStamped parses the value stored with putStamped() under `key` in the top level bucket `bucket` into `v`, and
+// returns when it was looked up and whether it exists.
+func (s *Store) getStamped(bucket []byte, key []byte, v interface{}) (time.Time, bool, error) {
+	var st stamped
+	ok, err := s.get(bucket, key, &st)
+	if err != nil || !ok {
+		return time.Time{}, ok, err
+	}
+
+	return st.At, true, json.Unmarshal(st.Value, v)
+}
+
+// transactionKey orders transactions by creation time, the ID keeps transactions created at the same time apart.
+func transactionKey(t coinbase.TransactionData) []byte {
+	return []byte(t.CreatedAt.UTC().Format("2006-01-02T15:04:05.000000000Z") + "/" + t.ID)
+}
+
+// priceKey is the key of the price of `currencyPair` on the date of `date`.
+func priceKey(currencyPair string, date time.Time) []byte {
+	return []byte(currencyPair + "/" + date.Format("2006-01-02"))
+}
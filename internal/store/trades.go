@@ -0,0 +1,200 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/KalebHawkins/crypto-client/coinbase"
+	"github.com/KalebHawkins/crypto-client/money"
+	"github.com/KalebHawkins/crypto-client/portfolio"
+	"github.com/shopspring/decimal"
+	bolt "go.etcd.io/bbolt"
+)
+
+// Trade is a buy or sell made outside Coinbase, such as on another exchange or a DEX, imported so the cost basis
+// covers the complete history. Value is what was paid for the bought amount or received for the sold amount and
+// Fee what was charged on top, both in the native currency of the user. Trades with the same Source and ID replace
+// each other. File is the name of the file the trade was imported from, if any.
+type Trade struct {
+	ID     string          `json:"id"`
+	Source string          `json:"source"`
+	File   string          `json:"file,omitempty"`
+	Time   time.Time       `json:"time"`
+	Side   string          `json:"side"`
+	Asset  string          `json:"asset"`
+	Amount decimal.Decimal `json:"amount"`
+	Value  money.Money     `json:"value"`
+	Fee    money.Money     `json:"fee"`
+}
+
+// Validate returns an error describing what is wrong with the trade, nil if it is valid.
+func (t Trade) Validate() error {
+	switch {
+	case t.ID == "" || t.Source == "":
+		return fmt.Errorf("the trade has no ID or source")
+	case t.Side != coinbase.Buy && t.Side != coinbase.Sell:
+		return fmt.Errorf("invalid side %q, use buy or sell", t.Side)
+	case t.Asset == "":
+		return fmt.Errorf("the trade has no asset")
+	case !t.Amount.IsPositive():
+		return fmt.Errorf("the amount of %s must be positive", t.Asset)
+	case t.Value.IsNegative() || t.Fee.IsNegative():
+		return fmt.Errorf("the value and fee must not be negative")
+	case t.Value.Currency == "" || !t.Fee.IsZero() && t.Fee.Currency != t.Value.Currency:
+		return fmt.Errorf("the value and fee must be in the same currency")
+	}
+
+	return nil
+}
+
+// Event returns the trade as a cost basis event. A buy opens a lot costing the value plus the fee, a sell
+// realizes the value less the fee.
+func (t Trade) Event() portfolio.Event {
+	e := portfolio.Event{
+		Time:   t.Time,
+		Kind:   portfolio.Acquire,
+		Asset:  t.Asset,
+		Amount: t.Amount,
+		Value:  t.Value.Add(t.Fee),
+	}
+	if t.Side == coinbase.Sell {
+		e.Kind = portfolio.Dispose
+		e.Value = t.Value.Sub(t.Fee)
+	}
+
+	return e
+}
+
+// PutTrades stores `trades`, replacing stored trades with the same source and ID. An error is returned if a trade
+// is invalid, nothing is stored then.
+func (s *Store) PutTrades(trades []Trade) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return putTrades(tx.Bucket(tradesBucket), trades)
+	})
+}
+
+// ReplaceTrades stores `trades` imported from `file` of `source` in place of every trade imported from that file
+// before, so rows removed or edited in the file do not linger. An error is returned if a trade is invalid, nothing
+// is changed then.
+func (s *Store) ReplaceTrades(source, file string, trades []Trade) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(tradesBucket)
+
+		err := deleteTrades(b, func(t Trade) bool { return t.Source == source && t.File == file })
+		if err != nil {
+			return err
+		}
+
+		return putTrades(b, trades)
+	})
+}
+
+// Trades returns the stored trades of `asset`, or of every asset when it is empty, oldest first.
+func (s *Store) Trades(asset string) ([]Trade, error) {
+	var trades []Trade
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(tradesBucket).ForEach(func(k, v []byte) error {
+			var t Trade
+			if err := json.Unmarshal(v, &t); err != nil {
+				return fmt.Errorf("reading stored trade %s: %w", k, err)
+			}
+			if asset == "" || strings.EqualFold(t.Asset, asset) {
+				trades = append(trades, t)
+			}
+
+			return nil
+		})
+	})
+
+	return trades, err
+}
+
+// Events returns the cost basis events of the stored trades of `asset`, or of every asset when it is empty, oldest
+// first.
+func (s *Store) Events(asset string) ([]portfolio.Event, error) {
+	trades, err := s.Trades(asset)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]portfolio.Event, len(trades))
+	for i, t := range trades {
+		events[i] = t.Event()
+	}
+
+	return events, nil
+}
+
+// RemoveTrades removes the stored trades imported from `source` and returns how many there were.
+func (s *Store) RemoveTrades(source string) (int, error) {
+	n := 0
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		return deleteTrades(tx.Bucket(tradesBucket), func(t Trade) bool {
+			if t.Source == source {
+				n++
+				return true
+			}
+			return false
+		})
+	})
+
+	return n, err
+}
+
+// putTrades stores `trades` in `b`, replacing stored trades with the same source and ID.
+func putTrades(b *bolt.Bucket, trades []Trade) error {
+	for _, t := range trades {
+		if err := t.Validate(); err != nil {
+			return fmt.Errorf("trade %s of %s: %w", t.ID, t.Source, err)
+		}
+
+		// Drop the trade stored before, it is keyed by its old time if that changed.
+		if err := deleteTrades(b, func(st Trade) bool { return st.Source == t.Source && st.ID == t.ID }); err != nil {
+			return err
+		}
+
+		v, err := json.Marshal(t)
+		if err != nil {
+			return err
+		}
+		if err := b.Put(tradeKey(t), v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteTrades deletes the trades in `b` matching `match`.
+func deleteTrades(b *bolt.Bucket, match func(t Trade) bool) error {
+	var keys [][]byte
+	err := b.ForEach(func(k, v []byte) error {
+		var t Trade
+		if err := json.Unmarshal(v, &t); err != nil {
+			return fmt.Errorf("reading stored trade %s: %w", k, err)
+		}
+		if match(t) {
+			keys = append(keys, append([]byte(nil), k...))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, k := range keys {
+		if err := b.Delete(k); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// tradeKey orders trades by time, the source and ID keep trades made at the same time apart.
+func tradeKey(t Trade) []byte {
+	return []byte(t.Time.UTC().Format("2006-01-02T15:04:05.000000000Z") + "/" + t.Source + "/" + t.ID)
+}
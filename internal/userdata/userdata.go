@@ -0,0 +1,504 @@
+/*
+Package userdata persists information the user attaches locally, such as notes and tags on assets and
+transactions, the watchlist, portfolio goals, price alerts or recurring buys. Everything is stored as a single JSON document in the user's configuration
+directory.
+*/
+package userdata
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// fileName is the name of the JSON document inside the crypto-client configuration directory.
+const fileName = "userdata.json"
+
+// Data is the locally stored user data.
+type Data struct {
+	Assets         map[string]Annotation `json:"assets,omitempty"`
+	Transactions   map[string]Annotation `json:"transactions,omitempty"`
+	Watchlist      []string              `json:"watchlist,omitempty"`
+	Goals          []Goal                `json:"goals,omitempty"`
+	IgnoredWallets []string              `json:"ignored_wallets,omitempty"`
+	AssetRenames   map[string]string     `json:"asset_renames,omitempty"`
+	DelistedAssets []string              `json:"delisted_assets,omitempty"`
+	Profiles       []string              `json:"profiles,omitempty"`
+	Alerts         []Alert               `json:"alerts,omitempty"`
+	DCAPurchases   []DCAPurchase         `json:"dca_purchases,omitempty"`
+
+	path string
+}
+
+// Annotation holds the free-form notes and tags attached to an asset or a transaction.
+type Annotation struct {
+	Notes []string `json:"notes,omitempty"`
+	Tags  []string `json:"tags,omitempty"`
+}
+
+// Goal is a target the user is working towards. When Asset is set the goal is to hold Target units of that
+// asset, otherwise the goal is a total portfolio value of Target in the native currency.
+type Goal struct {
+	Name   string  `json:"name"`
+	Asset  string  `json:"asset,omitempty"`
+	Target float64 `json:"target"`
+}
+
+// These constants are the conditions of an Alert.
+const (
+	Above = "above"
+	Below = "below"
+)
+
+// Alert fires when the spot price of Pair, such as BTC-USD, crosses Price in the direction of Condition.
+// Triggered is set once it fired and cleared when the price moves back, so an alert fires once per crossing.
+type Alert struct {
+	ID        int     `json:"id"`
+	Pair      string  `json:"pair"`
+	Condition string  `json:"condition"`
+	Price     float64 `json:"price"`
+	Triggered bool    `json:"triggered,omitempty"`
+}
+
+// DCAPurchase records a buy placed by the dollar-cost-averaging rule named Rule, so the rule knows when it buys
+// next and the buys count against the spending cap.
+type DCAPurchase struct {
+	Rule      string          `json:"rule"`
+	Time      time.Time       `json:"time"`
+	ProductID string          `json:"product_id"`
+	Amount    decimal.Decimal `json:"amount"`
+	OrderID   string          `json:"order_id,omitempty"`
+}
+
+// Dir returns the crypto-client configuration directory, for example ~/.config/crypto-client on Linux.
+func Dir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "crypto-client"), nil
+}
+
+// Path returns the location of the user data document.
+func Path() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, fileName), nil
+}
+
+// Load reads the user data from disk. An empty Data is returned if nothing has been stored yet.
+func Load() (*Data, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Data{path: path}
+
+	b, err := ioutil.ReadFile(d.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return d, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(b, d); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// Save writes the user data back to disk, creating the configuration directory if needed.
+func (d *Data) Save() error {
+	if err := os.MkdirAll(filepath.Dir(d.path), 0700); err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(d.path, b, 0600)
+}
+
+// Asset returns the annotation for an asset symbol such as BTC. Symbols are case insensitive.
+func (d *Data) Asset(symbol string) Annotation {
+	return d.Assets[strings.ToUpper(symbol)]
+}
+
+// Transaction returns the annotation for a transaction ID.
+func (d *Data) Transaction(id string) Annotation {
+	return d.Transactions[id]
+}
+
+// AddAssetNote attaches a note to an asset.
+func (d *Data) AddAssetNote(symbol string, note string) {
+	if d.Assets == nil {
+		d.Assets = map[string]Annotation{}
+	}
+	key := strings.ToUpper(symbol)
+	a := d.Assets[key]
+	a.Notes = append(a.Notes, note)
+	d.Assets[key] = a
+}
+
+// AddTransactionNote attaches a note to a transaction.
+func (d *Data) AddTransactionNote(id string, note string) {
+	if d.Transactions == nil {
+		d.Transactions = map[string]Annotation{}
+	}
+	a := d.Transactions[id]
+	a.Notes = append(a.Notes, note)
+	d.Transactions[id] = a
+}
+
+// TagAsset adds tags to an asset. Tags already present are ignored.
+func (d *Data) TagAsset(symbol string, tags ...string) {
+	if d.Assets == nil {
+		d.Assets = map[string]Annotation{}
+	}
+	key := strings.ToUpper(symbol)
+	a := d.Assets[key]
+	a.Tags = addTags(a.Tags, tags)
+	d.Assets[key] = a
+}
+
+// TagTransaction adds tags to a transaction. Tags already present are ignored.
+func (d *Data) TagTransaction(id string, tags ...string) {
+	if d.Transactions == nil {
+		d.Transactions = map[string]Annotation{}
+	}
+	a := d.Transactions[id]
+	a.Tags = addTags(a.Tags, tags)
+	d.Transactions[id] = a
+}
+
+// UntagAsset removes tags from an asset.
+func (d *Data) UntagAsset(symbol string, tags ...string) {
+	key := strings.ToUpper(symbol)
+	a, ok := d.Assets[key]
+	if !ok {
+		return
+	}
+	a.Tags = removeTags(a.Tags, tags)
+	d.Assets[key] = a
+}
+
+// UntagTransaction removes tags from a transaction.
+func (d *Data) UntagTransaction(id string, tags ...string) {
+	a, ok := d.Transactions[id]
+	if !ok {
+		return
+	}
+	a.Tags = removeTags(a.Tags, tags)
+	d.Transactions[id] = a
+}
+
+// Watch adds asset symbols to the watchlist. Symbols already on the watchlist are ignored.
+func (d *Data) Watch(symbols ...string) {
+	for _, s := range symbols {
+		s = strings.ToUpper(strings.TrimSpace(s))
+		if s == "" || d.IsWatched(s) {
+			continue
+		}
+		d.Watchlist = append(d.Watchlist, s)
+	}
+	sort.Strings(d.Watchlist)
+}
+
+// Unwatch removes asset symbols from the watchlist.
+func (d *Data) Unwatch(symbols ...string) {
+	kept := d.Watchlist[:0]
+	for _, w := range d.Watchlist {
+		remove := false
+		for _, s := range symbols {
+			if strings.EqualFold(w, s) {
+				remove = true
+			}
+		}
+		if !remove {
+			kept = append(kept, w)
+		}
+	}
+	d.Watchlist = kept
+}
+
+// IsWatched reports whether an asset symbol is on the watchlist.
+func (d *Data) IsWatched(symbol string) bool {
+	for _, w := range d.Watchlist {
+		if strings.EqualFold(w, symbol) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Ignore adds wallet IDs or names to the ignored wallets, which are left out of overviews, portfolio views and
+// goals. Wallets already ignored are skipped.
+func (d *Data) Ignore(wallets ...string) {
+	for _, w := range wallets {
+		w = strings.TrimSpace(w)
+		if w == "" || d.IsIgnored(w) {
+			continue
+		}
+		d.IgnoredWallets = append(d.IgnoredWallets, w)
+	}
+	sort.Strings(d.IgnoredWallets)
+}
+
+// Unignore removes wallet IDs or names from the ignored wallets.
+func (d *Data) Unignore(wallets ...string) {
+	kept := d.IgnoredWallets[:0]
+	for _, i := range d.IgnoredWallets {
+		remove := false
+		for _, w := range wallets {
+			if strings.EqualFold(i, w) {
+				remove = true
+			}
+		}
+		if !remove {
+			kept = append(kept, i)
+		}
+	}
+	d.IgnoredWallets = kept
+}
+
+// IsIgnored reports whether a wallet is ignored, either by one of `keys`, typically its ID and its name.
+// Names are case insensitive.
+func (d *Data) IsIgnored(keys ...string) bool {
+	for _, i := range d.IgnoredWallets {
+		for _, k := range keys {
+			if strings.EqualFold(i, k) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// RenameAsset records that the asset `old` is now traded as `current`. Renaming an asset to itself overrides a
+// rename crypto-client knows about.
+func (d *Data) RenameAsset(old string, current string) {
+	if d.AssetRenames == nil {
+		d.AssetRenames = map[string]string{}
+	}
+	d.AssetRenames[strings.ToUpper(old)] = strings.ToUpper(current)
+}
+
+// Delist marks assets as no longer traded. Assets already delisted are skipped.
+func (d *Data) Delist(symbols ...string) {
+	for _, s := range symbols {
+		s = strings.ToUpper(strings.TrimSpace(s))
+		if s == "" || d.IsDelisted(s) {
+			continue
+		}
+		d.DelistedAssets = append(d.DelistedAssets, s)
+	}
+	sort.Strings(d.DelistedAssets)
+}
+
+// IsDelisted reports whether an asset was marked as delisted.
+func (d *Data) IsDelisted(symbol string) bool {
+	for _, s := range d.DelistedAssets {
+		if strings.EqualFold(s, symbol) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ForgetAsset removes the rename and the delisting recorded for an asset and reports whether there was any.
+func (d *Data) ForgetAsset(symbol string) bool {
+	symbol = strings.ToUpper(symbol)
+	_, renamed := d.AssetRenames[symbol]
+	delete(d.AssetRenames, symbol)
+
+	delisted := d.IsDelisted(symbol)
+	kept := d.DelistedAssets[:0]
+	for _, s := range d.DelistedAssets {
+		if s != symbol {
+			kept = append(kept, s)
+		}
+	}
+	d.DelistedAssets = kept
+
+	return renamed || delisted
+}
+
+// AddProfile registers a named credential profile. Profiles already registered are ignored.
+func (d *Data) AddProfile(name string) {
+	if d.HasProfile(name) {
+		return
+	}
+	d.Profiles = append(d.Profiles, name)
+	sort.Strings(d.Profiles)
+}
+
+// RemoveProfile unregisters a credential profile and reports whether it was registered.
+func (d *Data) RemoveProfile(name string) bool {
+	for i, p := range d.Profiles {
+		if p == name {
+			d.Profiles = append(d.Profiles[:i], d.Profiles[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasProfile reports whether a credential profile is registered. Profile names are case sensitive.
+func (d *Data) HasProfile(name string) bool {
+	for _, p := range d.Profiles {
+		if p == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SetGoal adds a goal, replacing any existing goal with the same name.
+func (d *Data) SetGoal(g Goal) {
+	g.Asset = strings.ToUpper(g.Asset)
+	for i, existing := range d.Goals {
+		if strings.EqualFold(existing.Name, g.Name) {
+			d.Goals[i] = g
+			return
+		}
+	}
+	d.Goals = append(d.Goals, g)
+}
+
+// RemoveGoal removes the goal with the given name and reports whether it existed.
+func (d *Data) RemoveGoal(name string) bool {
+	for i, g := range d.Goals {
+		if strings.EqualFold(g.Name, name) {
+			d.Goals = append(d.Goals[:i], d.Goals[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// AddAlert adds an alert and returns the ID it was given. IDs are never reused while alerts with higher IDs exist.
+func (d *Data) AddAlert(a Alert) int {
+	a.ID = 1
+	for _, existing := range d.Alerts {
+		if existing.ID >= a.ID {
+			a.ID = existing.ID + 1
+		}
+	}
+	a.Pair = strings.ToUpper(a.Pair)
+	d.Alerts = append(d.Alerts, a)
+
+	return a.ID
+}
+
+// RemoveAlert removes the alert with the given ID and reports whether it existed.
+func (d *Data) RemoveAlert(id int) bool {
+	for i, a := range d.Alerts {
+		if a.ID == id {
+			d.Alerts = append(d.Alerts[:i], d.Alerts[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// SetAlertTriggered records whether the alert with the given ID has fired and reports whether it exists.
+func (d *Data) SetAlertTriggered(id int, triggered bool) bool {
+	for i, a := range d.Alerts {
+		if a.ID == id {
+			d.Alerts[i].Triggered = triggered
+			return true
+		}
+	}
+
+	return false
+}
+
+// AddDCAPurchase records a buy placed by a dollar-cost-averaging rule.
+func (d *Data) AddDCAPurchase(p DCAPurchase) {
+	d.DCAPurchases = append(d.DCAPurchases, p)
+}
+
+// LastDCAPurchases returns the time of the latest buy of every dollar-cost-averaging rule, keyed by rule name.
+func (d *Data) LastDCAPurchases() map[string]time.Time {
+	last := map[string]time.Time{}
+	for _, p := range d.DCAPurchases {
+		if p.Time.After(last[p.Rule]) {
+			last[p.Rule] = p.Time
+		}
+	}
+
+	return last
+}
+
+// DCASpent returns the total amount bought by dollar-cost-averaging rules since `since`.
+func (d *Data) DCASpent(since time.Time) decimal.Decimal {
+	spent := decimal.Zero
+	for _, p := range d.DCAPurchases {
+		if !p.Time.Before(since) {
+			spent = spent.Add(p.Amount)
+		}
+	}
+
+	return spent
+}
+
+// HasTag reports whether the annotation carries the given tag. Tags are case insensitive.
+func (a Annotation) HasTag(tag string) bool {
+	for _, t := range a.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// addTags merges `add` into `tags`, skipping duplicates, and returns the sorted result.
+func addTags(tags []string, add []string) []string {
+	for _, t := range add {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == "" || (Annotation{Tags: tags}).HasTag(t) {
+			continue
+		}
+		tags = append(tags, t)
+	}
+	sort.Strings(tags)
+
+	return tags
+}
+
+// removeTags returns `tags` without any of the tags in `remove`.
+func removeTags(tags []string, remove []string) []string {
+	kept := tags[:0]
+	for _, t := range tags {
+		if !(Annotation{Tags: remove}).HasTag(t) {
+			kept = append(kept, t)
+		}
+	}
+
+	return kept
+}
@@ -0,0 +1,496 @@
+package userdata
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// These constants are the kinds of a Reference.
+const (
+	WalletReference = "wallet"
+	AssetReference  = "asset"
+)
+
+// assetSymbol matches a well formed asset symbol such as BTC or 1INCH.
+var assetSymbol = regexp.MustCompile(`^[A-Z0-9]{1,12}$`)
+
+// ProfileName matches valid credential profile names.
+var ProfileName = regexp.MustCompile(`^[A-Za-z0-9_-]{1,32}$`)
+
+// Issue is a problem found in the user data document, positioned at the line and column it was found on.
+type Issue struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+// String is a stringer function for an Issue.
+func (i Issue) String() string {
+	return fmt.Sprintf("%d:%d: %s", i.Line, i.Column, i.Message)
+}
+
+// Reference is a wallet or an asset named in the user data document. Whether it exists can only be checked
+// against a provider, which is left to the caller.
+type Reference struct {
+	Kind   string
+	Value  string
+	Line   int
+	Column int
+}
+
+// Validate checks the user data document `b` without contacting any provider. It returns the problems found,
+// ordered by position, and the wallets and assets the document refers to. A document that is not valid JSON
+// yields a single issue at the position of the syntax error.
+func Validate(b []byte) ([]Issue, []Reference) {
+	var d Data
+	if err := json.Unmarshal(b, &d); err != nil {
+		return []Issue{jsonIssue(b, err)}, nil
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(b, &root); err != nil || len(root.Content) == 0 {
+		return nil, nil
+	}
+
+	v := &validator{}
+	v.document(root.Content[0])
+
+	sort.SliceStable(v.issues, func(i, j int) bool {
+		if v.issues[i].Line != v.issues[j].Line {
+			return v.issues[i].Line < v.issues[j].Line
+		}
+		return v.issues[i].Column < v.issues[j].Column
+	})
+
+	return v.issues, v.refs
+}
+
+// validator collects the issues and references of a document while walking it.
+type validator struct {
+	issues []Issue
+	refs   []Reference
+}
+
+// add records an issue at the position of `n`.
+func (v *validator) add(n *yaml.Node, format string, args ...interface{}) {
+	v.issues = append(v.issues, Issue{Line: n.Line, Column: n.Column, Message: fmt.Sprintf(format, args...)})
+}
+
+// ref records a reference to a wallet or an asset at the position of `n`.
+func (v *validator) ref(kind string, n *yaml.Node) {
+	v.refs = append(v.refs, Reference{Kind: kind, Value: n.Value, Line: n.Line, Column: n.Column})
+}
+
+// document checks the top level object.
+func (v *validator) document(n *yaml.Node) {
+	if n.Kind != yaml.MappingNode {
+		v.add(n, "the user data must be a JSON object")
+		return
+	}
+
+	known := []string{"assets", "transactions", "watchlist", "goals", "ignored_wallets", "asset_renames", "delisted_assets", "profiles", "alerts", "dca_purchases"}
+	v.fields(n, known, func(key string, k, val *yaml.Node) {
+		switch key {
+		case "assets":
+			v.annotations(val, true)
+		case "transactions":
+			v.annotations(val, false)
+		case "watchlist":
+			v.symbols(val, "watchlist")
+		case "goals":
+			v.goals(val)
+		case "ignored_wallets":
+			v.wallets(val)
+		case "asset_renames":
+			v.renames(val)
+		case "delisted_assets":
+			v.delisted(val)
+		case "profiles":
+			v.profiles(val)
+		case "alerts":
+			v.alerts(val)
+		case "dca_purchases":
+			v.dcaPurchases(val)
+		}
+	})
+}
+
+// fields calls `fn` for every key of the mapping `n` and reports keys that are not in `known`. JSON allows
+// duplicate keys but only the last one takes effect, so duplicates are reported as well.
+func (v *validator) fields(n *yaml.Node, known []string, fn func(key string, k, val *yaml.Node)) {
+	seen := map[string]bool{}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		k, val := n.Content[i], n.Content[i+1]
+
+		if seen[k.Value] {
+			v.add(k, "duplicate key %q, only the last one is used", k.Value)
+		}
+		seen[k.Value] = true
+
+		if !contains(known, k.Value) {
+			v.add(k, "unknown key %q, expected one of %s", k.Value, strings.Join(known, ", "))
+			continue
+		}
+		fn(k.Value, k, val)
+	}
+}
+
+// annotations checks the notes and tags attached to assets or transactions. Asset keys must be upper case
+// symbols because lookups upper case the symbol first.
+func (v *validator) annotations(n *yaml.Node, assets bool) {
+	if n.Kind != yaml.MappingNode {
+		v.add(n, "expected an object")
+		return
+	}
+
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		k, val := n.Content[i], n.Content[i+1]
+
+		if assets {
+			switch {
+			case !assetSymbol.MatchString(strings.ToUpper(k.Value)):
+				v.add(k, "%q is not a valid asset symbol", k.Value)
+			case k.Value != strings.ToUpper(k.Value):
+				v.add(k, "asset %q must be upper case, use %q or it is never matched", k.Value, strings.ToUpper(k.Value))
+			default:
+				v.ref(AssetReference, k)
+			}
+		}
+
+		if val.Kind != yaml.MappingNode {
+			v.add(val, "expected an object with notes and tags")
+			continue
+		}
+
+		v.fields(val, []string{"notes", "tags"}, func(key string, _, list *yaml.Node) {
+			v.stringList(list, key, func(s *yaml.Node) {
+				if key == "tags" && strings.HasPrefix(s.Value, "category:") && strings.TrimPrefix(s.Value, "category:") == "" {
+					v.add(s, "tag %q names no category", s.Value)
+				}
+			})
+		})
+	}
+}
+
+// symbols checks a list of asset symbols.
+func (v *validator) symbols(n *yaml.Node, what string) {
+	seen := map[string]bool{}
+	v.stringList(n, what, func(s *yaml.Node) {
+		sym := strings.ToUpper(s.Value)
+		switch {
+		case !assetSymbol.MatchString(sym):
+			v.add(s, "%q is not a valid asset symbol", s.Value)
+			return
+		case seen[sym]:
+			v.add(s, "%s is listed twice in the %s", sym, what)
+			return
+		}
+		seen[sym] = true
+		v.ref(AssetReference, s)
+	})
+}
+
+// renames checks the asset renames, which map an old symbol to the current one. Old symbols are usually no longer
+// listed so they are not looked up.
+func (v *validator) renames(n *yaml.Node) {
+	if n.Kind != yaml.MappingNode {
+		v.add(n, "asset_renames must be an object mapping old symbols to current ones")
+		return
+	}
+
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		k, val := n.Content[i], n.Content[i+1]
+		switch {
+		case !assetSymbol.MatchString(k.Value):
+			v.add(k, "%q is not a valid upper case asset symbol", k.Value)
+		case val.Kind != yaml.ScalarNode || !assetSymbol.MatchString(val.Value):
+			v.add(val, "%s must be renamed to an upper case asset symbol", k.Value)
+		}
+	}
+}
+
+// delisted checks the delisted assets. They can no longer be traded so they are not looked up.
+func (v *validator) delisted(n *yaml.Node) {
+	v.stringList(n, "delisted_assets", func(s *yaml.Node) {
+		if !assetSymbol.MatchString(s.Value) {
+			v.add(s, "%q is not a valid upper case asset symbol", s.Value)
+		}
+	})
+}
+
+// profiles checks the names of the credential profiles.
+func (v *validator) profiles(n *yaml.Node) {
+	seen := map[string]bool{}
+	v.stringList(n, "profiles", func(s *yaml.Node) {
+		switch {
+		case !ProfileName.MatchString(s.Value):
+			v.add(s, "%q is not a valid profile name, use letters, digits, - and _", s.Value)
+		case seen[s.Value]:
+			v.add(s, "profile %s is listed twice", s.Value)
+		}
+		seen[s.Value] = true
+	})
+}
+
+// alerts checks the price alerts.
+func (v *validator) alerts(n *yaml.Node) {
+	if n.Kind != yaml.SequenceNode {
+		v.add(n, "alerts must be a list")
+		return
+	}
+
+	ids := map[string]bool{}
+	for _, a := range n.Content {
+		if a.Kind != yaml.MappingNode {
+			v.add(a, "an alert must be an object with an id, a pair, a condition and a price")
+			continue
+		}
+
+		var id, pair, condition, price *yaml.Node
+		v.fields(a, []string{"id", "pair", "condition", "price", "triggered"}, func(key string, _, val *yaml.Node) {
+			switch key {
+			case "id":
+				id = val
+			case "pair":
+				pair = val
+			case "condition":
+				condition = val
+			case "price":
+				price = val
+			}
+		})
+
+		switch {
+		case id == nil:
+			v.add(a, "alert has no id")
+		case ids[id.Value]:
+			v.add(id, "alert id %s is used twice", id.Value)
+		default:
+			if n, err := strconv.Atoi(id.Value); err != nil || n <= 0 {
+				v.add(id, "alert id must be a positive whole number, got %s", id.Value)
+			}
+			ids[id.Value] = true
+		}
+
+		if pair == nil {
+			v.add(a, "alert has no pair")
+		} else if parts := strings.Split(pair.Value, "-"); len(parts) != 2 || !assetSymbol.MatchString(parts[0]) || !assetSymbol.MatchString(parts[1]) {
+			v.add(pair, "%q is not an upper case currency pair such as BTC-USD", pair.Value)
+		} else {
+			v.ref(AssetReference, &yaml.Node{Value: parts[0], Line: pair.Line, Column: pair.Column})
+		}
+
+		if condition == nil {
+			v.add(a, "alert has no condition")
+		} else if condition.Value != Above && condition.Value != Below {
+			v.add(condition, "alert condition must be %s or %s, got %s", Above, Below, condition.Value)
+		}
+
+		if price == nil {
+			v.add(a, "alert has no price")
+		} else if f, err := strconv.ParseFloat(price.Value, 64); err != nil || f <= 0 {
+			v.add(price, "alert price must be a positive number, got %s", price.Value)
+		}
+	}
+}
+
+// dcaPurchases checks the buys recorded by the dollar-cost-averaging rules.
+func (v *validator) dcaPurchases(n *yaml.Node) {
+	if n.Kind != yaml.SequenceNode {
+		v.add(n, "dca_purchases must be a list")
+		return
+	}
+
+	for _, p := range n.Content {
+		if p.Kind != yaml.MappingNode {
+			v.add(p, "a dca purchase must be an object with a rule, a time, a product_id and an amount")
+			continue
+		}
+
+		var rule, at, product, amount *yaml.Node
+		v.fields(p, []string{"rule", "time", "product_id", "amount", "order_id"}, func(key string, _, val *yaml.Node) {
+			switch key {
+			case "rule":
+				rule = val
+			case "time":
+				at = val
+			case "product_id":
+				product = val
+			case "amount":
+				amount = val
+			}
+		})
+
+		if rule == nil || strings.TrimSpace(rule.Value) == "" {
+			v.add(p, "dca purchase has no rule")
+		}
+
+		if at == nil {
+			v.add(p, "dca purchase has no time")
+		} else if _, err := time.Parse(time.RFC3339, at.Value); err != nil {
+			v.add(at, "dca purchase time must be an RFC 3339 time, got %s", at.Value)
+		}
+
+		if product == nil {
+			v.add(p, "dca purchase has no product_id")
+		} else if parts := strings.Split(product.Value, "-"); len(parts) != 2 || !assetSymbol.MatchString(parts[0]) || !assetSymbol.MatchString(parts[1]) {
+			v.add(product, "%q is not an upper case currency pair such as BTC-USD", product.Value)
+		} else {
+			v.ref(AssetReference, &yaml.Node{Value: parts[0], Line: product.Line, Column: product.Column})
+		}
+
+		if amount == nil {
+			v.add(p, "dca purchase has no amount")
+		} else if f, err := strconv.ParseFloat(amount.Value, 64); err != nil || f <= 0 {
+			v.add(amount, "dca purchase amount must be a positive number, got %s", amount.Value)
+		}
+	}
+}
+
+// wallets checks the ignored wallets.
+func (v *validator) wallets(n *yaml.Node) {
+	v.stringList(n, "ignored_wallets", func(s *yaml.Node) {
+		if strings.TrimSpace(s.Value) == "" {
+			v.add(s, "empty wallet name")
+			return
+		}
+		v.ref(WalletReference, s)
+	})
+}
+
+// goals checks the portfolio goals.
+func (v *validator) goals(n *yaml.Node) {
+	if n.Kind != yaml.SequenceNode {
+		v.add(n, "goals must be a list")
+		return
+	}
+
+	names := map[string]bool{}
+	for _, g := range n.Content {
+		if g.Kind != yaml.MappingNode {
+			v.add(g, "a goal must be an object with a name and a target")
+			continue
+		}
+
+		var name, target *yaml.Node
+		v.fields(g, []string{"name", "asset", "target"}, func(key string, _, val *yaml.Node) {
+			switch key {
+			case "name":
+				name = val
+			case "target":
+				target = val
+			case "asset":
+				if val.Value == "" {
+					return
+				}
+				if !assetSymbol.MatchString(val.Value) {
+					v.add(val, "%q is not a valid upper case asset symbol", val.Value)
+					return
+				}
+				v.ref(AssetReference, val)
+			}
+		})
+
+		switch {
+		case name == nil || strings.TrimSpace(name.Value) == "":
+			v.add(g, "goal has no name")
+		case names[strings.ToLower(name.Value)]:
+			v.add(name, "goal %q is defined twice, names are case insensitive", name.Value)
+		default:
+			names[strings.ToLower(name.Value)] = true
+		}
+
+		if target == nil {
+			v.add(g, "goal has no target")
+		} else if f, err := strconv.ParseFloat(target.Value, 64); err != nil || f <= 0 {
+			v.add(target, "goal target must be a positive number, got %s", target.Value)
+		}
+	}
+}
+
+// stringList checks that `n` is a list of strings and calls `fn` for each of them.
+func (v *validator) stringList(n *yaml.Node, what string, fn func(s *yaml.Node)) {
+	if n.Kind != yaml.SequenceNode {
+		v.add(n, "%s must be a list", what)
+		return
+	}
+
+	for _, s := range n.Content {
+		if s.Kind != yaml.ScalarNode {
+			v.add(s, "%s must only hold strings", what)
+			continue
+		}
+		fn(s)
+	}
+}
+
+// jsonIssue positions a JSON decoding error at the line and column of its byte offset.
+func jsonIssue(b []byte, err error) Issue {
+	var offset int64 = -1
+
+	var syntax *json.SyntaxError
+	var typ *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntax):
+		offset = syntax.Offset
+	case errors.As(err, &typ):
+		offset = typ.Offset
+		err = fmt.Errorf("%s must be %s, not a JSON %s", typ.Field, jsonKind(typ.Type), typ.Value)
+	}
+
+	if offset < 0 {
+		return Issue{Line: 1, Column: 1, Message: err.Error()}
+	}
+
+	if int(offset) > len(b) {
+		offset = int64(len(b))
+	}
+
+	line, col := 1, 1
+	for _, c := range b[:offset] {
+		if c == '\n' {
+			line++
+			col = 1
+			continue
+		}
+		col++
+	}
+
+	return Issue{Line: line, Column: col, Message: err.Error()}
+}
+
+// jsonKind describes the JSON value expected for a Go type.
+func jsonKind(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Float32, reflect.Float64, reflect.Int, reflect.Int64:
+		return "a number"
+	case reflect.Bool:
+		return "true or false"
+	case reflect.Slice:
+		return "a list"
+	}
+
+	return "an object"
+}
+
+// contains reports whether `list` holds `s`.
+func contains(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+
+	return false
+}
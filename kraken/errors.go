@@ -0,0 +1,41 @@
+package kraken
+
+import (
+	"fmt"
+	"strings"
+)
+
+// APIError is returned when Kraken reports errors in the error array of a response. Kraken errors are strings
+// such as "EAPI:Invalid key" made of a severity, a category and a message.
+type APIError struct {
+	StatusCode int
+	Errors     []string
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	return fmt.Sprintf("kraken API error: %s", strings.Join(e.Errors, "; "))
+}
+
+// IsAuthError reports whether the error was caused by missing or invalid credentials.
+func (e *APIError) IsAuthError() bool {
+	return e.has("EAPI:Invalid key", "EAPI:Invalid signature", "EAPI:Invalid nonce", "EGeneral:Permission denied")
+}
+
+// IsRateLimited reports whether the error was caused by exceeding a Kraken rate limit.
+func (e *APIError) IsRateLimited() bool {
+	return e.has("EAPI:Rate limit exceeded", "EOrder:Rate limit exceeded", "EGeneral:Too many requests")
+}
+
+// has reports whether any of the errors starts with one of `prefixes`.
+func (e *APIError) has(prefixes ...string) bool {
+	for _, err := range e.Errors {
+		for _, p := range prefixes {
+			if strings.HasPrefix(err, p) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,321 @@
+/*
+Package kraken is used to query the Kraken API for a user's balances and trade history and for ticker prices.
+*/
+package kraken
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// APIKeyClient sets the API key and API secret for Kraken authentication.
+// to use your API Key and API secret set your environment variables.
+//
+//	export KRAKEN_KEY="api_key"
+//	export KRAKEN_SECRET="api_secret"
+func APIKeyClient() Client {
+	return Client{
+		apiKey:     os.Getenv("KRAKEN_KEY"),
+		apiSecret:  os.Getenv("KRAKEN_SECRET"),
+		baseURL:    apiEndpointBase,
+		httpClient: &http.Client{},
+		nonce:      &nonceState{},
+	}
+}
+
+// ─── KRAKEN METHODS ─────────────────────────────────────────────────────────────
+
+// GetBalances upon a successful API request returns the balance of every asset held, keyed by normalized asset
+// code such as BTC. Assets with a zero balance are left out. An error is returned if creating or sending the
+// request failed.
+func (c Client) GetBalances() (map[string]decimal.Decimal, error) {
+	var raw map[string]decimal.Decimal
+	if err := c.private("Balance", nil, &raw); err != nil {
+		return nil, err
+	}
+
+	balances := map[string]decimal.Decimal{}
+	for asset, amt := range raw {
+		if amt.IsZero() {
+			continue
+		}
+		a := NormalizeAsset(asset)
+		balances[a] = balances[a].Add(amt)
+	}
+
+	return balances, nil
+}
+
+// GetTradesHistory upon a successful API request returns every trade of the user, newest first. All pages of the
+// history are fetched. An error is returned if creating or sending a request failed.
+func (c Client) GetTradesHistory() ([]Trade, error) {
+	var trades []Trade
+	for {
+		var page struct {
+			Trades map[string]struct {
+				OrderTxID string          `json:"ordertxid"`
+				Pair      string          `json:"pair"`
+				Time      float64         `json:"time"`
+				Type      string          `json:"type"`
+				OrderType string          `json:"ordertype"`
+				Price     decimal.Decimal `json:"price"`
+				Cost      decimal.Decimal `json:"cost"`
+				Fee       decimal.Decimal `json:"fee"`
+				Vol       decimal.Decimal `json:"vol"`
+			} `json:"trades"`
+			Count int `json:"count"`
+		}
+
+		form := url.Values{"ofs": {strconv.Itoa(len(trades))}}
+		if err := c.private("TradesHistory", form, &page); err != nil {
+			return nil, err
+		}
+
+		for id, t := range page.Trades {
+			trades = append(trades, Trade{
+				TxID:      id,
+				OrderID:   t.OrderTxID,
+				Pair:      t.Pair,
+				Time:      time.Unix(0, int64(t.Time*float64(time.Second))).UTC(),
+				Type:      t.Type,
+				OrderType: t.OrderType,
+				Price:     t.Price,
+				Cost:      t.Cost,
+				Fee:       t.Fee,
+				Volume:    t.Vol,
+			})
+		}
+
+		if len(page.Trades) == 0 || len(trades) >= page.Count {
+			break
+		}
+	}
+
+	sortTrades(trades)
+
+	return trades, nil
+}
+
+// GetTicker upon a successful API request returns the current market of `pair`, for example "XBTUSD" or
+// "ETHEUR". An error is returned if creating or sending the request failed.
+func (c Client) GetTicker(pair string) (Ticker, error) {
+	var raw map[string]struct {
+		A []decimal.Decimal `json:"a"`
+		B []decimal.Decimal `json:"b"`
+		C []decimal.Decimal `json:"c"`
+		V []decimal.Decimal `json:"v"`
+		L []decimal.Decimal `json:"l"`
+		H []decimal.Decimal `json:"h"`
+		O decimal.Decimal   `json:"o"`
+	}
+	if err := c.public("Ticker", url.Values{"pair": {pair}}, &raw); err != nil {
+		return Ticker{}, err
+	}
+
+	// Kraken answers with its own name for the pair, such as XXBTZUSD for XBTUSD.
+	for name, t := range raw {
+		if len(t.A) < 1 || len(t.B) < 1 || len(t.C) < 1 || len(t.V) < 2 || len(t.L) < 2 || len(t.H) < 2 {
+			return Ticker{}, fmt.Errorf("kraken: incomplete ticker for %s", name)
+		}
+
+		return Ticker{
+			Pair:      name,
+			Ask:       t.A[0],
+			Bid:       t.B[0],
+			Last:      t.C[0],
+			Open:      t.O,
+			Low24h:    t.L[1],
+			High24h:   t.H[1],
+			Volume24h: t.V[1],
+		}, nil
+	}
+
+	return Ticker{}, fmt.Errorf("kraken: no ticker for %s", pair)
+}
+
+// GetAssetPairs upon a successful API request returns every tradable pair keyed by its Kraken name, for example
+// XXBTZUSD. An error is returned if creating or sending the request failed.
+func (c Client) GetAssetPairs() (map[string]AssetPair, error) {
+	var raw map[string]struct {
+		AltName string `json:"altname"`
+		Base    string `json:"base"`
+		Quote   string `json:"quote"`
+	}
+	if err := c.public("AssetPairs", nil, &raw); err != nil {
+		return nil, err
+	}
+
+	pairs := map[string]AssetPair{}
+	for name, p := range raw {
+		pairs[name] = AssetPair{Name: name, AltName: p.AltName, Base: NormalizeAsset(p.Base), Quote: NormalizeAsset(p.Quote)}
+	}
+
+	return pairs, nil
+}
+
+//
+// ─────────────────────────────────────────────────────────── KRAKEN METHODS ─────
+//
+
+// ─── HELPER FUNCTIONS ───────────────────────────────────────────────────────────
+
+// legacyAssets maps the codes Kraken uses for older assets to their common symbol.
+var legacyAssets = map[string]string{
+	"XXBT": "BTC", "XBT": "BTC", "XBT.M": "BTC",
+	"XXDG": "DOGE", "XDG": "DOGE",
+	"XETH": "ETH", "ETH2": "ETH", "XETC": "ETC", "XLTC": "LTC", "XMLN": "MLN", "XREP": "REP",
+	"XXLM": "XLM", "XXMR": "XMR", "XXRP": "XRP", "XZEC": "ZEC",
+	"ZUSD": "USD", "ZEUR": "EUR", "ZGBP": "GBP", "ZCAD": "CAD", "ZJPY": "JPY", "ZAUD": "AUD",
+}
+
+// NormalizeAsset returns the common symbol of a Kraken asset code, for example BTC for XXBT. Balances held in
+// Kraken's earn program carry a suffix such as ".S" or ".F" which is removed as well.
+func NormalizeAsset(code string) string {
+	if a, ok := legacyAssets[code]; ok {
+		return a
+	}
+
+	if i := strings.IndexByte(code, '.'); i > 0 {
+		return NormalizeAsset(code[:i])
+	}
+
+	return code
+}
+
+// Pair returns the Kraken name of the pair trading `base` for `quote`, for example XBTUSD for BTC and USD.
+func Pair(base string, quote string) string {
+	return krakenAsset(base) + krakenAsset(quote)
+}
+
+// krakenAsset returns the code Kraken uses in pair names for a common asset symbol.
+func krakenAsset(symbol string) string {
+	switch strings.ToUpper(symbol) {
+	case "BTC":
+		return "XBT"
+	case "DOGE":
+		return "XDG"
+	}
+
+	return strings.ToUpper(symbol)
+}
+
+// public sends a GET request to a public endpoint and parses the result into `v`.
+func (c Client) public(method string, q url.Values, v interface{}) error {
+	path := "/0/public/" + method
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+
+	req, err := http.NewRequest("GET", c.baseURL+path, nil)
+	if err != nil {
+		return err
+	}
+
+	return c.do(req, v)
+}
+
+// private sends a signed POST request to a private endpoint and parses the result into `v`.
+func (c Client) private(method string, form url.Values, v interface{}) error {
+	if form == nil {
+		form = url.Values{}
+	}
+	form.Set("nonce", strconv.FormatInt(c.nonce.next(), 10))
+	body := form.Encode()
+
+	path := "/0/private/" + method
+	req, err := http.NewRequest("POST", c.baseURL+path, strings.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	sig, err := c.createSignature(path, form.Get("nonce"), body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("API-Key", c.apiKey)
+	req.Header.Set("API-Sign", sig)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return c.do(req, v)
+}
+
+// createSignature returns the value of the API-Sign header: the HMAC-SHA512 of the URI path followed by the
+// SHA-256 of the nonce and the POST data, keyed with the base64 decoded API secret.
+func (c Client) createSignature(path string, nonce string, body string) (string, error) {
+	secret, err := base64.StdEncoding.DecodeString(c.apiSecret)
+	if err != nil {
+		return "", fmt.Errorf("kraken: invalid API secret: %v", err)
+	}
+
+	sha := sha256.Sum256([]byte(nonce + body))
+
+	h := hmac.New(sha512.New, secret)
+	h.Write([]byte(path))
+	h.Write(sha[:])
+
+	return base64.StdEncoding.EncodeToString(h.Sum(nil)), nil
+}
+
+// do sends a request and parses the result of Kraken's response envelope into `v`.
+func (c Client) do(req *http.Request, v interface{}) error {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var envelope struct {
+		Error  []string        `json:"error"`
+		Result json.RawMessage `json:"result"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return fmt.Errorf("bad HTTP status return code: %v\n%v", resp.Status, string(body))
+	}
+
+	if len(envelope.Error) > 0 {
+		return &APIError{StatusCode: resp.StatusCode, Errors: envelope.Error}
+	}
+
+	return json.Unmarshal(envelope.Result, v)
+}
+
+// next returns a nonce larger than every nonce returned before.
+func (n *nonceState) next() int64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	now := time.Now().UnixNano() / int64(time.Microsecond)
+	if now <= n.last {
+		now = n.last + 1
+	}
+	n.last = now
+
+	return now
+}
+
+// sortTrades sorts trades newest first.
+func sortTrades(trades []Trade) {
+	sort.Slice(trades, func(i, j int) bool {
+		return trades[i].Time.After(trades[j].Time)
+	})
+}
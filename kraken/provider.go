@@ -0,0 +1,93 @@
+package kraken
+
+import (
+	"sort"
+
+	"github.com/KalebHawkins/crypto-client/exchange"
+	"github.com/KalebHawkins/crypto-client/money"
+)
+
+var _ exchange.Provider = Client{}
+
+// Name implements exchange.Provider.
+func (c Client) Name() string {
+	return "Kraken"
+}
+
+// Capabilities implements exchange.Provider. Only reading balances, trades and prices is supported.
+func (c Client) Capabilities() exchange.Capabilities {
+	return nil
+}
+
+// Balances implements exchange.Provider.
+func (c Client) Balances() ([]exchange.Balance, error) {
+	b, err := c.GetBalances()
+	if err != nil {
+		return nil, err
+	}
+
+	var balances []exchange.Balance
+	for asset, amt := range b {
+		if amt.IsZero() {
+			continue
+		}
+		balances = append(balances, exchange.Balance{Asset: asset, Amount: amt})
+	}
+
+	return balances, nil
+}
+
+// Transactions implements exchange.Provider. Only trades are returned, the amount is in the base asset of the
+// pair and the value and fee in its quote asset.
+func (c Client) Transactions() ([]exchange.Transaction, error) {
+	pairs, err := c.GetAssetPairs()
+	if err != nil {
+		return nil, err
+	}
+
+	trades, err := c.GetTradesHistory()
+	if err != nil {
+		return nil, err
+	}
+
+	var transactions []exchange.Transaction
+	for _, t := range trades {
+		p, ok := pairs[t.Pair]
+		if !ok {
+			continue
+		}
+
+		amount := t.Volume
+		typ := exchange.Buy
+		if t.Type == Sell {
+			amount = amount.Neg()
+			typ = exchange.Sell
+		}
+
+		transactions = append(transactions, exchange.Transaction{
+			ID:          t.TxID,
+			Type:        typ,
+			Time:        t.Time,
+			Amount:      money.New(amount, p.Base),
+			Value:       money.New(t.Cost, p.Quote),
+			Fee:         money.New(t.Fee, p.Quote),
+			Description: t.Type + " " + p.AltName,
+		})
+	}
+
+	sort.SliceStable(transactions, func(i, j int) bool {
+		return transactions[i].Time.Before(transactions[j].Time)
+	})
+
+	return transactions, nil
+}
+
+// Price implements exchange.Provider. The price of the last trade is returned.
+func (c Client) Price(pair exchange.Pair) (money.Money, error) {
+	t, err := c.GetTicker(Pair(pair.Base, pair.Quote))
+	if err != nil {
+		return money.Money{}, err
+	}
+
+	return money.New(t.Last, pair.Quote), nil
+}
@@ -0,0 +1,70 @@
+package kraken
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	apiEndpointBase string = "https://api.kraken.com"
+)
+
+// These constants are the types of a trade.
+const (
+	Buy  string = "buy"
+	Sell string = "sell"
+)
+
+// Client is used to query the Kraken API. Create one with APIKeyClient().
+type Client struct {
+	apiKey     string
+	apiSecret  string
+	baseURL    string
+	httpClient *http.Client
+
+	// nonce is shared between copies of a Client, Kraken rejects a nonce that is not larger than the last one.
+	nonce *nonceState
+}
+
+// nonceState hands out strictly increasing nonces.
+type nonceState struct {
+	mu   sync.Mutex
+	last int64
+}
+
+// Ticker is the current market of an asset pair.
+type Ticker struct {
+	Pair      string
+	Ask       decimal.Decimal
+	Bid       decimal.Decimal
+	Last      decimal.Decimal
+	Open      decimal.Decimal
+	Low24h    decimal.Decimal
+	High24h   decimal.Decimal
+	Volume24h decimal.Decimal
+}
+
+// AssetPair describes a tradable pair. Base and Quote are normalized asset codes such as BTC and USD.
+type AssetPair struct {
+	Name    string
+	AltName string
+	Base    string
+	Quote   string
+}
+
+// Trade is a single execution of one of the user's orders.
+type Trade struct {
+	TxID      string
+	OrderID   string
+	Pair      string
+	Time      time.Time
+	Type      string
+	OrderType string
+	Price     decimal.Decimal
+	Cost      decimal.Decimal
+	Fee       decimal.Decimal
+	Volume    decimal.Decimal
+}
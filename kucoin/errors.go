@@ -0,0 +1,40 @@
+package kucoin
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// APIError is returned when the KuCoin API responds with a non 2xx status or a code other than "200000". Code is
+// KuCoin's error code such as "400005" and Msg a human readable description.
+type APIError struct {
+	StatusCode int    `json:"-"`
+	Status     string `json:"-"`
+	Code       string `json:"code"`
+	Msg        string `json:"msg"`
+	Body       string `json:"-"`
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("bad HTTP status return code: %v\n%v", e.Status, e.Body)
+	}
+
+	return fmt.Sprintf("kucoin API error (%v): %s: %s", e.Status, e.Code, e.Msg)
+}
+
+// IsAuthError reports whether the error was caused by missing or invalid credentials.
+func (e *APIError) IsAuthError() bool {
+	switch e.Code {
+	case "400001", "400002", "400003", "400004", "400005", "400006", "400007", "411100":
+		return true
+	}
+
+	return e.StatusCode == http.StatusUnauthorized
+}
+
+// IsRateLimited reports whether the error was caused by exceeding the KuCoin rate limit.
+func (e *APIError) IsRateLimited() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.Code == "429000"
+}
@@ -0,0 +1,184 @@
+/*
+Package kucoin is used to query the KuCoin API for a user's account balances and ledgers and for prices.
+*/
+package kucoin
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// APIKeyClient sets the API key, API secret and passphrase for KuCoin authentication.
+// to use your API Key, API secret and passphrase set your environment variables.
+//
+//	export KUCOIN_KEY="api_key"
+//	export KUCOIN_SECRET="api_secret"
+//	export KUCOIN_PASSPHRASE="passphrase"
+func APIKeyClient() Client {
+	return Client{
+		apiKey:     os.Getenv("KUCOIN_KEY"),
+		apiSecret:  os.Getenv("KUCOIN_SECRET"),
+		passphrase: os.Getenv("KUCOIN_PASSPHRASE"),
+		baseURL:    apiEndpointBase,
+		httpClient: &http.Client{},
+	}
+}
+
+// ─── KUCOIN METHODS ─────────────────────────────────────────────────────────────
+
+// GetAccounts upon a successful API request returns the main, trade and margin accounts of every currency. An
+// error is returned if creating or sending the request failed.
+func (c Client) GetAccounts() ([]Account, error) {
+	var a []Account
+	err := c.get("/api/v1/accounts", nil, true, &a)
+
+	return a, err
+}
+
+// GetBalances upon a successful API request returns the total balance of every currency across all accounts,
+// leaving out empty balances. An error is returned if creating or sending the request failed.
+func (c Client) GetBalances() (map[string]decimal.Decimal, error) {
+	accounts, err := c.GetAccounts()
+	if err != nil {
+		return nil, err
+	}
+
+	balances := map[string]decimal.Decimal{}
+	for _, a := range accounts {
+		if a.Balance.IsZero() {
+			continue
+		}
+		balances[a.Currency] = balances[a.Currency].Add(a.Balance)
+	}
+
+	return balances, nil
+}
+
+// GetLedgers upon a successful API request returns the ledger entries matching `filter`, newest first. Every page
+// is fetched. An error is returned if any request failed.
+func (c Client) GetLedgers(filter LedgerFilter) ([]LedgerEntry, error) {
+	q := url.Values{"pageSize": {strconv.Itoa(maxPageSize)}}
+	if filter.Currency != "" {
+		q.Set("currency", strings.ToUpper(filter.Currency))
+	}
+	if !filter.Start.IsZero() {
+		q.Set("startAt", strconv.FormatInt(filter.Start.UnixNano()/int64(time.Millisecond), 10))
+	}
+	if !filter.End.IsZero() {
+		q.Set("endAt", strconv.FormatInt(filter.End.UnixNano()/int64(time.Millisecond), 10))
+	}
+
+	var entries []LedgerEntry
+	for page := 1; ; page++ {
+		q.Set("currentPage", strconv.Itoa(page))
+
+		var p ledgerPage
+		if err := c.get("/api/v1/accounts/ledgers", q, true, &p); err != nil {
+			return nil, err
+		}
+		entries = append(entries, p.Items...)
+
+		if len(p.Items) == 0 || p.CurrentPage >= p.TotalPage {
+			return entries, nil
+		}
+	}
+}
+
+// GetPrices upon a successful API request returns the price of each of `currencies` in the fiat currency `base`,
+// for example GetPrices("USD", "BTC", "ETH"). Without currencies the price of every currency is returned. An error
+// is returned if creating or sending the request failed.
+func (c Client) GetPrices(base string, currencies ...string) (map[string]decimal.Decimal, error) {
+	q := url.Values{"base": {strings.ToUpper(base)}}
+	if len(currencies) > 0 {
+		q.Set("currencies", strings.ToUpper(strings.Join(currencies, ",")))
+	}
+
+	var p map[string]decimal.Decimal
+	err := c.get("/api/v1/prices", q, false, &p)
+
+	return p, err
+}
+
+// GetTicker upon a successful API request returns the best bid and ask of `symbol`, for example "BTC-USDT".
+// An error is returned if creating or sending the request failed.
+func (c Client) GetTicker(symbol string) (Ticker, error) {
+	var t Ticker
+	err := c.get("/api/v1/market/orderbook/level1", url.Values{"symbol": {strings.ToUpper(symbol)}}, false, &t)
+
+	return t, err
+}
+
+//
+// ─────────────────────────────────────────────────────────── KUCOIN METHODS ─────
+//
+
+// ─── HELPER FUNCTIONS ───────────────────────────────────────────────────────────
+
+// get sends a GET request for `path` with the query `q`, signed when `private` is set, and parses the data of the
+// response into `v`. A non 2xx response or an error code is returned as an *APIError.
+func (c Client) get(path string, q url.Values, private bool, v interface{}) error {
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+
+	req, err := http.NewRequest("GET", c.baseURL+path, nil)
+	if err != nil {
+		return err
+	}
+
+	if private {
+		timestamp := strconv.FormatInt(time.Now().UnixNano()/int64(time.Millisecond), 10)
+		req.Header.Set("KC-API-KEY", c.apiKey)
+		req.Header.Set("KC-API-SIGN", c.sign(timestamp+"GET"+path))
+		req.Header.Set("KC-API-TIMESTAMP", timestamp)
+		req.Header.Set("KC-API-PASSPHRASE", c.sign(c.passphrase))
+		req.Header.Set("KC-API-KEY-VERSION", "2")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var r struct {
+		response
+		Data json.RawMessage `json:"data"`
+	}
+	jsonErr := json.Unmarshal(body, &r)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 || jsonErr != nil || r.Code != "200000" {
+		e := &APIError{StatusCode: resp.StatusCode, Status: resp.Status, Code: r.Code, Msg: r.Msg}
+		if e.Code == "" {
+			e.Body = string(body)
+		}
+		return e
+	}
+
+	return json.Unmarshal(r.Data, v)
+}
+
+// sign returns the base64 encoded HMAC-SHA256 of `s` keyed with the API secret. Version 2 API keys sign the
+// passphrase the same way as the request.
+func (c Client) sign(s string) string {
+	h := hmac.New(sha256.New, []byte(c.apiSecret))
+	h.Write([]byte(s))
+
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
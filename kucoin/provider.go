@@ -0,0 +1,89 @@
+package kucoin
+
+import (
+	"sort"
+
+	"github.com/KalebHawkins/crypto-client/exchange"
+	"github.com/KalebHawkins/crypto-client/money"
+)
+
+var _ exchange.Provider = Client{}
+
+// Name implements exchange.Provider.
+func (c Client) Name() string {
+	return "KuCoin"
+}
+
+// Capabilities implements exchange.Provider. Only reading balances, the ledger and prices is supported.
+func (c Client) Capabilities() exchange.Capabilities {
+	return nil
+}
+
+// Balances implements exchange.Provider. The main, trade and margin accounts are added up.
+func (c Client) Balances() ([]exchange.Balance, error) {
+	b, err := c.GetBalances()
+	if err != nil {
+		return nil, err
+	}
+
+	var balances []exchange.Balance
+	for asset, amt := range b {
+		balances = append(balances, exchange.Balance{Asset: asset, Amount: amt})
+	}
+
+	return balances, nil
+}
+
+// Transactions implements exchange.Provider. Every ledger entry is returned, a trade shows up as one entry for
+// each of the two assets it exchanged.
+func (c Client) Transactions() ([]exchange.Transaction, error) {
+	entries, err := c.GetLedgers(LedgerFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	var transactions []exchange.Transaction
+	for _, e := range entries {
+		transactions = append(transactions, exchange.Transaction{
+			ID:          e.ID,
+			Type:        ledgerType(e),
+			Time:        e.Time(),
+			Amount:      money.New(e.SignedAmount(), e.Currency),
+			Fee:         money.New(e.Fee, e.Currency),
+			Description: e.BizType,
+		})
+	}
+
+	sort.SliceStable(transactions, func(i, j int) bool {
+		return transactions[i].Time.Before(transactions[j].Time)
+	})
+
+	return transactions, nil
+}
+
+// Price implements exchange.Provider. The price of the last trade is returned.
+func (c Client) Price(pair exchange.Pair) (money.Money, error) {
+	t, err := c.GetTicker(pair.String())
+	if err != nil {
+		return money.Money{}, err
+	}
+
+	return money.New(t.Price, pair.Quote), nil
+}
+
+// ledgerType maps the business type of a ledger entry to an exchange transaction type.
+func ledgerType(e LedgerEntry) string {
+	switch e.BizType {
+	case "Deposit":
+		return exchange.Deposit
+	case "Withdrawal":
+		return exchange.Withdrawal
+	case "Exchange", "Trade", "Trade_Exchange":
+		if e.Direction == In {
+			return exchange.Buy
+		}
+		return exchange.Sell
+	}
+
+	return exchange.Other
+}
@@ -0,0 +1,94 @@
+package kucoin
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	apiEndpointBase string = "https://api.kucoin.com"
+	maxPageSize     int    = 500
+)
+
+// These constants are the directions of a ledger entry.
+const (
+	In  string = "in"
+	Out string = "out"
+)
+
+// Client is used to query the KuCoin API. Create one with APIKeyClient().
+type Client struct {
+	apiKey     string
+	apiSecret  string
+	passphrase string
+	baseURL    string
+	httpClient *http.Client
+}
+
+// response is the envelope of every KuCoin API response. A code other than "200000" is an error.
+type response struct {
+	Code string `json:"code"`
+	Msg  string `json:"msg"`
+}
+
+// Account is the balance of a currency in one of the main, trade or margin accounts.
+type Account struct {
+	ID        string          `json:"id"`
+	Currency  string          `json:"currency"`
+	Type      string          `json:"type"`
+	Balance   decimal.Decimal `json:"balance"`
+	Available decimal.Decimal `json:"available"`
+	Holds     decimal.Decimal `json:"holds"`
+}
+
+// LedgerEntry is a single change of an account balance, such as a deposit, trade or fee.
+type LedgerEntry struct {
+	ID          string          `json:"id"`
+	Currency    string          `json:"currency"`
+	Amount      decimal.Decimal `json:"amount"`
+	Fee         decimal.Decimal `json:"fee"`
+	Balance     decimal.Decimal `json:"balance"`
+	AccountType string          `json:"accountType"`
+	BizType     string          `json:"bizType"`
+	Direction   string          `json:"direction"`
+	CreatedAt   int64           `json:"createdAt"`
+	Context     string          `json:"context"`
+}
+
+// Time returns the time the entry was made.
+func (e LedgerEntry) Time() time.Time {
+	return time.Unix(0, e.CreatedAt*int64(time.Millisecond)).UTC()
+}
+
+// SignedAmount returns the amount of the entry, negative when it left the account.
+func (e LedgerEntry) SignedAmount() decimal.Decimal {
+	if e.Direction == Out {
+		return e.Amount.Neg()
+	}
+
+	return e.Amount
+}
+
+// LedgerFilter narrows down the entries returned by GetLedgers(). Zero values are not sent.
+type LedgerFilter struct {
+	Currency string
+	Start    time.Time
+	End      time.Time
+}
+
+// ledgerPage is a single page of ledger entries.
+type ledgerPage struct {
+	CurrentPage int           `json:"currentPage"`
+	TotalPage   int           `json:"totalPage"`
+	Items       []LedgerEntry `json:"items"`
+}
+
+// Ticker is the best bid and ask and the last trade price of a symbol.
+type Ticker struct {
+	Price   decimal.Decimal `json:"price"`
+	BestBid decimal.Decimal `json:"bestBid"`
+	BestAsk decimal.Decimal `json:"bestAsk"`
+	Time    int64           `json:"time"`
+}
@@ -0,0 +1,124 @@
+/*
+Package money provides a decimal-safe amount of a currency. Amounts are backed by arbitrary-precision decimals
+so balances, prices and portfolio totals can be added and multiplied without accumulating floating point error.
+*/
+package money
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// Money is an amount of a fiat or crypto currency. It is parsed from and encoded to the
+// {"amount": "1.23", "currency": "USD"} objects used by the provider APIs. The zero value is zero of no particular
+// currency and takes on the currency of whatever it is combined with, which makes it usable as an accumulator.
+type Money struct {
+	Amount   decimal.Decimal `json:"amount"`
+	Currency string          `json:"currency"`
+}
+
+// New returns an amount of a currency.
+func New(amount decimal.Decimal, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// Parse returns the amount of a currency given as a decimal string such as "0.00012345".
+func Parse(amount string, currency string) (Money, error) {
+	d, err := decimal.NewFromString(amount)
+	if err != nil {
+		return Money{}, fmt.Errorf("invalid %s amount %q: %v", currency, amount, err)
+	}
+
+	return New(d, currency), nil
+}
+
+// Zero returns zero of a currency.
+func Zero(currency string) Money {
+	return New(decimal.Zero, currency)
+}
+
+// Add returns m + o. It panics if both amounts carry a currency and the currencies differ.
+func (m Money) Add(o Money) Money {
+	return New(m.Amount.Add(o.Amount), m.combine(o))
+}
+
+// Sub returns m - o. It panics if both amounts carry a currency and the currencies differ.
+func (m Money) Sub(o Money) Money {
+	return New(m.Amount.Sub(o.Amount), m.combine(o))
+}
+
+// Mul returns m multiplied by `factor`, for example a unit price multiplied by a balance.
+func (m Money) Mul(factor decimal.Decimal) Money {
+	return New(m.Amount.Mul(factor), m.Currency)
+}
+
+// Div returns m divided by `divisor`. Division by zero panics.
+func (m Money) Div(divisor decimal.Decimal) Money {
+	return New(m.Amount.Div(divisor), m.Currency)
+}
+
+// Neg returns -m.
+func (m Money) Neg() Money {
+	return New(m.Amount.Neg(), m.Currency)
+}
+
+// Cmp compares the amounts of m and o and returns -1, 0 or +1. It panics if both amounts carry a currency and the
+// currencies differ.
+func (m Money) Cmp(o Money) int {
+	m.combine(o)
+	return m.Amount.Cmp(o.Amount)
+}
+
+// IsZero reports whether the amount is zero.
+func (m Money) IsZero() bool {
+	return m.Amount.IsZero()
+}
+
+// IsPositive reports whether the amount is greater than zero.
+func (m Money) IsPositive() bool {
+	return m.Amount.IsPositive()
+}
+
+// IsNegative reports whether the amount is less than zero.
+func (m Money) IsNegative() bool {
+	return m.Amount.IsNegative()
+}
+
+// Float64 returns the nearest float64 to the amount. It is meant for display purposes such as percentages and
+// charts, never for further arithmetic.
+func (m Money) Float64() float64 {
+	f, _ := m.Amount.Float64()
+	return f
+}
+
+// StringFixed formats the amount rounded to `places` decimal places followed by the currency, for example
+// "67234.51 USD".
+func (m Money) StringFixed(places int32) string {
+	if m.Currency == "" {
+		return m.Amount.StringFixed(places)
+	}
+
+	return fmt.Sprintf("%s %s", m.Amount.StringFixed(places), m.Currency)
+}
+
+// String formats the exact amount followed by the currency.
+func (m Money) String() string {
+	if m.Currency == "" {
+		return m.Amount.String()
+	}
+
+	return fmt.Sprintf("%s %s", m.Amount.String(), m.Currency)
+}
+
+// combine returns the currency of the result of combining m and o.
+func (m Money) combine(o Money) string {
+	switch {
+	case m.Currency == "":
+		return o.Currency
+	case o.Currency == "" || m.Currency == o.Currency:
+		return m.Currency
+	}
+
+	panic(fmt.Sprintf("money: mismatched currencies %s and %s", m.Currency, o.Currency))
+}
@@ -0,0 +1,55 @@
+package money
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestAddSub(t *testing.T) {
+	usd := func(s string) Money { return New(decimal.RequireFromString(s), "USD") }
+
+	tests := []struct {
+		name string
+		got  Money
+		want Money
+	}{
+		{name: "add", got: usd("1.10").Add(usd("2.20")), want: usd("3.30")},
+		{name: "sub", got: usd("1.10").Sub(usd("2.20")), want: usd("-1.10")},
+		{name: "zero value takes the currency", got: Money{}.Add(usd("5")), want: usd("5")},
+		{name: "no currency keeps the currency", got: usd("5").Sub(Money{Amount: decimal.NewFromInt(1)}), want: usd("4")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got.Currency != tt.want.Currency || !tt.got.Amount.Equal(tt.want.Amount) {
+				t.Errorf("got %s, want %s", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMismatchedCurrenciesPanic(t *testing.T) {
+	usd := New(decimal.NewFromInt(1), "USD")
+	eur := New(decimal.NewFromInt(1), "EUR")
+
+	tests := []struct {
+		name string
+		f    func()
+	}{
+		{name: "add", f: func() { usd.Add(eur) }},
+		{name: "sub", f: func() { usd.Sub(eur) }},
+		{name: "cmp", f: func() { usd.Cmp(eur) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("combining USD and EUR did not panic")
+				}
+			}()
+			tt.f()
+		})
+	}
+}
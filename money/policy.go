@@ -0,0 +1,156 @@
+package money
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// Rounding is the way amounts are rounded to the number of decimal places they are formatted with.
+type Rounding string
+
+// The supported rounding modes.
+const (
+	HalfUp   Rounding = "half-up"   // halves are rounded away from zero, 0.125 becomes 0.13
+	HalfEven Rounding = "half-even" // halves are rounded to the even neighbour, 0.125 becomes 0.12
+	Down     Rounding = "down"      // towards zero, 0.129 becomes 0.12
+	Up       Rounding = "up"        // away from zero, 0.121 becomes 0.13
+)
+
+// Roundings lists every supported rounding mode.
+var Roundings = []Rounding{HalfUp, HalfEven, Down, Up}
+
+// maxPlaces caps the decimal places of adaptively formatted amounts.
+const maxPlaces = 18
+
+// fiatCurrencies are the currencies formatted with the fiat places of a Policy.
+var fiatCurrencies = map[string]bool{
+	"USD": true, "EUR": true, "GBP": true, "JPY": true, "CAD": true, "AUD": true, "CHF": true, "NZD": true,
+	"SEK": true, "NOK": true, "DKK": true, "PLN": true, "CZK": true, "HUF": true, "SGD": true, "HKD": true,
+	"KRW": true, "CNY": true, "INR": true, "BRL": true, "MXN": true, "ZAR": true, "TRY": true, "AED": true,
+}
+
+// Policy decides how many decimal places amounts are formatted with and how they are rounded. Fiat currencies use
+// FiatPlaces, assets listed in Places use their own number of places and every other asset is rounded to
+// SignificantFigures, so both 0.000046 and 1.234567 keep their meaningful digits. Fiat amounts that would round to
+// zero, such as the price of a token worth a fraction of a cent, are also rounded to SignificantFigures.
+type Policy struct {
+	FiatPlaces         int32
+	SignificantFigures int32
+	Places             map[string]int32
+	Rounding           Rounding
+}
+
+// DefaultPolicy formats fiat with 2 places, BTC with 8 and every other asset with 6 significant figures, rounding
+// halves away from zero.
+var DefaultPolicy = Policy{
+	FiatPlaces:         2,
+	SignificantFigures: 6,
+	Places:             map[string]int32{"BTC": 8},
+	Rounding:           HalfUp,
+}
+
+// IsFiat reports whether `currency` is a fiat currency such as USD.
+func IsFiat(currency string) bool {
+	return fiatCurrencies[strings.ToUpper(currency)]
+}
+
+// Validate returns an error if the policy cannot be used to format amounts.
+func (p Policy) Validate() error {
+	if p.FiatPlaces < 0 || p.FiatPlaces > maxPlaces {
+		return fmt.Errorf("fiat places must be between 0 and %d, got %d", maxPlaces, p.FiatPlaces)
+	}
+
+	if p.SignificantFigures < 1 || p.SignificantFigures > maxPlaces {
+		return fmt.Errorf("significant figures must be between 1 and %d, got %d", maxPlaces, p.SignificantFigures)
+	}
+
+	for asset, places := range p.Places {
+		if places < 0 || places > maxPlaces {
+			return fmt.Errorf("places of %s must be between 0 and %d, got %d", asset, maxPlaces, places)
+		}
+	}
+
+	for _, r := range Roundings {
+		if p.Rounding == r {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown rounding %q, use one of %s", p.Rounding, joinRoundings())
+}
+
+// FormatAmount formats `amount` of `currency` without the currency, for example "0.00004600" for BTC or
+// "0.000046" for SHIB.
+func (p Policy) FormatAmount(amount decimal.Decimal, currency string) string {
+	places, fixed := p.places(amount, currency)
+	if !fixed {
+		return p.round(amount, places).String()
+	}
+
+	return p.round(amount, places).StringFixed(places)
+}
+
+// Format formats `m` followed by its currency, for example "67234.51 USD".
+func (p Policy) Format(m Money) string {
+	if m.Currency == "" {
+		return p.FormatAmount(m.Amount, m.Currency)
+	}
+
+	return fmt.Sprintf("%s %s", p.FormatAmount(m.Amount, m.Currency), m.Currency)
+}
+
+// places returns the number of decimal places `amount` of `currency` is rounded to and whether they are fixed.
+// Amounts rounded to significant figures are not padded with trailing zeros.
+func (p Policy) places(amount decimal.Decimal, currency string) (int32, bool) {
+	if places, ok := p.Places[strings.ToUpper(currency)]; ok {
+		return places, true
+	}
+
+	if currency == "" || IsFiat(currency) {
+		if amount.IsZero() || !p.round(amount, p.FiatPlaces).IsZero() {
+			return p.FiatPlaces, true
+		}
+	}
+
+	if amount.IsZero() {
+		return 0, false
+	}
+
+	// The most significant digit of the coefficient sits NumDigits - 1 places above the exponent.
+	msd := amount.Exponent() + int32(amount.NumDigits()) - 1
+	places := p.SignificantFigures - 1 - msd
+	switch {
+	case places < 0:
+		return 0, false
+	case places > maxPlaces:
+		return maxPlaces, false
+	}
+
+	return places, false
+}
+
+// round rounds `amount` to `places` decimal places using the rounding mode of the policy.
+func (p Policy) round(amount decimal.Decimal, places int32) decimal.Decimal {
+	switch p.Rounding {
+	case HalfEven:
+		return amount.RoundBank(places)
+	case Down:
+		return amount.RoundDown(places)
+	case Up:
+		return amount.RoundUp(places)
+	}
+
+	return amount.Round(places)
+}
+
+// joinRoundings returns the supported rounding modes as a comma separated list.
+func joinRoundings() string {
+	var names []string
+	for _, r := range Roundings {
+		names = append(names, string(r))
+	}
+
+	return strings.Join(names, ", ")
+}
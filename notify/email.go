@@ -0,0 +1,67 @@
+package notify
+
+import (
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Email sends messages through an SMTP server. The connection is upgraded with STARTTLS when the server supports
+// it, which is required to authenticate anywhere but on localhost.
+type Email struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	To       []string
+}
+
+// Name implements Notifier.
+func (e Email) Name() string {
+	return "Email"
+}
+
+// Notify implements Notifier. The title is the subject of the email.
+func (e Email) Notify(m Message) error {
+	if len(e.To) == 0 {
+		return fmt.Errorf("no recipients")
+	}
+
+	port := e.Port
+	if port == 0 {
+		port = 587
+	}
+	addr := net.JoinHostPort(e.Host, strconv.Itoa(port))
+
+	var auth smtp.Auth
+	if e.Username != "" {
+		auth = smtp.PlainAuth("", e.Username, e.Password, e.Host)
+	}
+
+	return smtp.SendMail(addr, auth, e.From, e.To, e.message(m, time.Now()))
+}
+
+// message returns `m` formatted as a plain text email sent at `date`.
+func (e Email) message(m Message, date time.Time) []byte {
+	subject := m.Title
+	if subject == "" {
+		subject = "crypto-client notification"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", e.From)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(e.To, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", strings.NewReplacer("\r", " ", "\n", " ").Replace(subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(m.Text, "\n", "\r\n"))
+	b.WriteString("\r\n")
+
+	return []byte(b.String())
+}
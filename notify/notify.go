@@ -0,0 +1,115 @@
+/*
+Package notify delivers short messages, such as fired price alerts, to chat services and email. Every channel
+implements Notifier, and Multi combines several channels into one.
+
+	n := notify.Multi(notify.NewSlack(webhookURL), notify.NewTelegram(token, chatID))
+	err := n.Notify(notify.Message{Title: "BTC-USD above 80000", Text: "BTC-USD is at 80125.10 USD"})
+*/
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// defaultTimeout is the time limit of a single delivery.
+const defaultTimeout = 10 * time.Second
+
+// Message is a notification. Channels without a separate title show it as the first line of the text.
+type Message struct {
+	Title string
+	Text  string
+}
+
+// Notifier delivers messages to a channel.
+type Notifier interface {
+	// Name returns the name of the channel, for example "Slack".
+	Name() string
+
+	// Notify delivers `m`. An error is returned if the channel did not accept it.
+	Notify(m Message) error
+}
+
+// Multi returns a Notifier delivering every message to each of `notifiers`. A failing channel does not keep the
+// message from the others, the error lists every channel that failed.
+func Multi(notifiers ...Notifier) Notifier {
+	return multi(notifiers)
+}
+
+// multi delivers every message to each of its notifiers.
+type multi []Notifier
+
+// Name implements Notifier.
+func (m multi) Name() string {
+	names := make([]string, len(m))
+	for i, n := range m {
+		names[i] = n.Name()
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// Notify implements Notifier.
+func (m multi) Notify(msg Message) error {
+	var failed []string
+	for _, n := range m {
+		if err := n.Notify(msg); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", n.Name(), err))
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("notify: %s", strings.Join(failed, "; "))
+	}
+
+	return nil
+}
+
+// postJSON posts `payload` encoded as JSON to `endpoint` and returns an error unless the response is a 2xx. The
+// endpoint is left out of the error, the URLs of webhooks and bots hold their secrets.
+func postJSON(hc *http.Client, endpoint string, payload interface{}) error {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	resp, err := hc.Post(endpoint, "application/json", bytes.NewReader(b))
+	if err != nil {
+		var ue *url.Error
+		if errors.As(err, &ue) {
+			return fmt.Errorf("%s request failed: %w", ue.Op, ue.Err)
+		}
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	return nil
+}
+
+// withTitle returns the text of `m` preceded by its title formatted with `format`, for example "*%s*" for bold
+// text in Slack.
+func withTitle(m Message, format string) string {
+	if m.Title == "" {
+		return m.Text
+	}
+
+	title := fmt.Sprintf(format, m.Title)
+	if m.Text == "" {
+		return title
+	}
+
+	return title + "\n" + m.Text
+}
@@ -0,0 +1,44 @@
+package notify
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNotifyErrorsLeaveOutSecrets(t *testing.T) {
+	const secret = "123456:SECRET-token"
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad request", http.StatusBadRequest)
+	}))
+	defer failing.Close()
+
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	hc := &http.Client{Timeout: time.Second}
+	tests := []struct {
+		name     string
+		notifier Notifier
+	}{
+		{name: "telegram unreachable", notifier: Telegram{Token: secret, ChatID: "1", BaseURL: closed.URL, HTTPClient: hc}},
+		{name: "telegram rejected", notifier: Telegram{Token: secret, ChatID: "1", BaseURL: failing.URL, HTTPClient: hc}},
+		{name: "slack unreachable", notifier: Slack{WebhookURL: closed.URL + "/services/" + secret, HTTPClient: hc}},
+		{name: "discord unreachable", notifier: Discord{WebhookURL: closed.URL + "/api/webhooks/" + secret, HTTPClient: hc}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.notifier.Notify(Message{Title: "title", Text: "text"})
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if strings.Contains(err.Error(), secret) {
+				t.Errorf("error %q contains the secret", err)
+			}
+		})
+	}
+}
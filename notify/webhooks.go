@@ -0,0 +1,76 @@
+package notify
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Slack posts messages to a Slack incoming webhook.
+type Slack struct {
+	WebhookURL string
+	HTTPClient *http.Client
+}
+
+// NewSlack returns a Notifier posting to the Slack incoming webhook `webhookURL`.
+func NewSlack(webhookURL string) Slack {
+	return Slack{WebhookURL: webhookURL, HTTPClient: &http.Client{Timeout: defaultTimeout}}
+}
+
+// Name implements Notifier.
+func (s Slack) Name() string {
+	return "Slack"
+}
+
+// Notify implements Notifier.
+func (s Slack) Notify(m Message) error {
+	return postJSON(s.HTTPClient, s.WebhookURL, map[string]string{"text": withTitle(m, "*%s*")})
+}
+
+// Discord posts messages to a Discord webhook.
+type Discord struct {
+	WebhookURL string
+	HTTPClient *http.Client
+}
+
+// NewDiscord returns a Notifier posting to the Discord webhook `webhookURL`.
+func NewDiscord(webhookURL string) Discord {
+	return Discord{WebhookURL: webhookURL, HTTPClient: &http.Client{Timeout: defaultTimeout}}
+}
+
+// Name implements Notifier.
+func (d Discord) Name() string {
+	return "Discord"
+}
+
+// Notify implements Notifier.
+func (d Discord) Notify(m Message) error {
+	return postJSON(d.HTTPClient, d.WebhookURL, map[string]string{"content": withTitle(m, "**%s**")})
+}
+
+// telegramEndpoint is the base URL of the Telegram Bot API.
+const telegramEndpoint = "https://api.telegram.org"
+
+// Telegram sends messages to a chat through a Telegram bot.
+type Telegram struct {
+	Token      string
+	ChatID     string
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewTelegram returns a Notifier sending messages to `chatID` through the bot authenticated by `token`. The bot has
+// to be a member of the chat.
+func NewTelegram(token string, chatID string) Telegram {
+	return Telegram{Token: token, ChatID: chatID, BaseURL: telegramEndpoint, HTTPClient: &http.Client{Timeout: defaultTimeout}}
+}
+
+// Name implements Notifier.
+func (t Telegram) Name() string {
+	return "Telegram"
+}
+
+// Notify implements Notifier.
+func (t Telegram) Notify(m Message) error {
+	url := fmt.Sprintf("%s/bot%s/sendMessage", t.BaseURL, t.Token)
+	return postJSON(t.HTTPClient, url, map[string]string{"chat_id": t.ChatID, "text": withTitle(m, "%s")})
+}
@@ -0,0 +1,103 @@
+package portfolio
+
+import (
+	"strings"
+	"time"
+
+	"github.com/KalebHawkins/crypto-client/coinbase"
+	"github.com/KalebHawkins/crypto-client/exchange"
+	"github.com/KalebHawkins/crypto-client/money"
+)
+
+// disposalTypes are the Coinbase transaction types that dispose of an asset in exchange for something else, as
+// opposed to moving it elsewhere.
+var disposalTypes = map[string]bool{
+	coinbase.Sell:         true,
+	"trade":               true,
+	"advanced_trade_fill": true,
+}
+
+// failedStatuses are the statuses of Coinbase transactions that did not move any funds.
+var failedStatuses = map[string]bool{
+	"canceled": true,
+	"failed":   true,
+	"expired":  true,
+}
+
+// FromCoinbase returns the events of Coinbase transactions, valued at their native amount. Incoming amounts are
+// acquisitions, outgoing sells and trades disposals and every other outgoing amount, such as a send, a transfer
+// out. Transactions of fiat currencies and transactions that failed are left out.
+//
+// Assets are named by their current ticker in `assets`, so the lots of an asset stay together across a rename. When
+// Coinbase carries out a rename itself the old ticker leaves one wallet and the same amount of the new one arrives
+// in another, those two transactions are left out so the lots keep their cost. Give the transactions of every
+// wallet at once for them to be matched.
+func FromCoinbase(transactions []coinbase.TransactionData, assets exchange.AssetMap) []Event {
+	var events []Event
+	var renamedOut, renamedIn []int
+	for _, t := range transactions {
+		if failedStatuses[t.Status] || money.IsFiat(t.Amount.Currency) || t.Amount.IsZero() {
+			continue
+		}
+
+		ticker := strings.ToUpper(t.Amount.Currency)
+		e := Event{
+			Time:   t.CreatedAt,
+			Kind:   Acquire,
+			Asset:  assets.Canonical(ticker),
+			Amount: t.Amount.Amount.Abs(),
+			Value:  t.NativeAmount,
+		}
+
+		if t.Amount.IsNegative() {
+			e.Kind = TransferOut
+			if disposalTypes[t.Type] {
+				e.Kind = Dispose
+			}
+		}
+
+		switch {
+		case e.Asset != ticker && e.Kind != Acquire:
+			renamedOut = append(renamedOut, len(events))
+		case e.Asset == ticker && e.Kind == Acquire && len(assets.Aliases(ticker)) > 0:
+			renamedIn = append(renamedIn, len(events))
+		}
+
+		events = append(events, e)
+	}
+
+	return withoutRenames(events, renamedOut, renamedIn)
+}
+
+// withoutRenames returns `events` without the pairs of an amount leaving under an old ticker, at one of the indexes
+// `out`, and the same amount arriving under the current ticker within a day, at one of the indexes `in`.
+func withoutRenames(events []Event, out []int, in []int) []Event {
+	if len(out) == 0 || len(in) == 0 {
+		return events
+	}
+
+	dropped := map[int]bool{}
+	for _, o := range out {
+		for _, i := range in {
+			a, b := events[o], events[i]
+			if dropped[i] || a.Asset != b.Asset || !a.Amount.Equal(b.Amount) {
+				continue
+			}
+			if d := b.Time.Sub(a.Time); d < -24*time.Hour || d > 24*time.Hour {
+				continue
+			}
+
+			dropped[o], dropped[i] = true, true
+			break
+		}
+	}
+
+	kept := events[:0]
+	for i, e := range events {
+		if !dropped[i] {
+			kept = append(kept, e)
+		}
+	}
+
+	return kept
+}
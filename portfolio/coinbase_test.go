@@ -0,0 +1,79 @@
+package portfolio
+
+import (
+	"testing"
+	"time"
+
+	"github.com/KalebHawkins/crypto-client/coinbase"
+	"github.com/KalebHawkins/crypto-client/exchange"
+	"github.com/KalebHawkins/crypto-client/money"
+	"github.com/shopspring/decimal"
+)
+
+func transaction(typ string, created time.Time, amount, currency, value string) coinbase.TransactionData {
+	return coinbase.TransactionData{
+		Type:         typ,
+		Status:       "completed",
+		Amount:       money.New(decimal.RequireFromString(amount), currency),
+		NativeAmount: money.New(decimal.RequireFromString(value), "USD"),
+		CreatedAt:    created,
+	}
+}
+
+func TestFromCoinbaseRename(t *testing.T) {
+	assets := exchange.AssetMap{Renames: map[string]string{"MATIC": "POL"}}
+	migrated := day(10)
+
+	// MATIC bought twice, migrated to POL by Coinbase and partly sold afterwards. The migration must neither
+	// dispose of the MATIC lots nor open a POL lot at the price of the migration day.
+	events := FromCoinbase([]coinbase.TransactionData{
+		transaction("buy", day(1), "100", "MATIC", "50"),
+		transaction("buy", day(2), "100", "MATIC", "150"),
+		transaction("send", migrated, "-200", "MATIC", "-80"),
+		transaction("send", migrated.Add(time.Hour), "200", "POL", "80"),
+		transaction("sell", day(20), "-50", "POL", "-30"),
+	}, assets)
+
+	if len(events) != 3 {
+		t.Fatalf("got %d events, want 3: %v", len(events), events)
+	}
+	for _, e := range events {
+		if e.Asset != "POL" {
+			t.Errorf("event asset = %s, want POL", e.Asset)
+		}
+	}
+
+	b, err := Build(FIFO, "USD", events)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := b.Position("POL")
+	if !p.Amount().Equal(decimal.NewFromInt(150)) {
+		t.Errorf("amount = %s, want 150", p.Amount())
+	}
+	if p.Realized.Cmp(usd("5")) != 0 {
+		t.Errorf("realized = %s, want 5", p.Realized)
+	}
+	if p.Cost().Cmp(usd("175")) != 0 {
+		t.Errorf("open cost = %s, want 175", p.Cost())
+	}
+}
+
+func TestFromCoinbaseUnmatchedRename(t *testing.T) {
+	assets := exchange.AssetMap{Renames: map[string]string{"MATIC": "POL"}}
+
+	// A MATIC send without a POL deposit of the same amount is a transfer out.
+	events := FromCoinbase([]coinbase.TransactionData{
+		transaction("buy", day(1), "100", "MATIC", "50"),
+		transaction("send", day(10), "-40", "MATIC", "-20"),
+		transaction("send", day(10), "60", "POL", "30"),
+	}, assets)
+
+	if len(events) != 3 {
+		t.Fatalf("got %d events, want 3", len(events))
+	}
+	if events[1].Kind != TransferOut || events[1].Asset != "POL" {
+		t.Errorf("send = %s of %s, want %s of POL", events[1].Kind, events[1].Asset, TransferOut)
+	}
+}
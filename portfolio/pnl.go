@@ -0,0 +1,42 @@
+package portfolio
+
+import (
+	"github.com/KalebHawkins/crypto-client/money"
+)
+
+// PnL is a profit and loss split into the gains realized by disposals and the gains of the amounts still held.
+type PnL struct {
+	Realized   money.Money
+	Unrealized money.Money
+}
+
+// Total returns the realized plus the unrealized gain.
+func (p PnL) Total() money.Money {
+	return p.Realized.Add(p.Unrealized)
+}
+
+// Add returns the sum of both profits and losses.
+func (p PnL) Add(o PnL) PnL {
+	return PnL{Realized: p.Realized.Add(o.Realized), Unrealized: p.Unrealized.Add(o.Unrealized)}
+}
+
+// PnL returns the profit and loss of the position with the open lots valued at `price` per unit.
+func (p Position) PnL(price money.Money) PnL {
+	return PnL{Realized: p.Realized, Unrealized: p.Unrealized(price)}
+}
+
+// PnL returns the profit and loss of every position, with the open lots valued at `prices` per unit keyed by
+// asset. Open lots of assets without a price are valued at their cost, so they add nothing to the unrealized
+// gain.
+func (b *Book) PnL(prices map[string]money.Money) PnL {
+	total := PnL{Realized: money.Zero(b.Currency), Unrealized: money.Zero(b.Currency)}
+	for _, p := range b.Positions() {
+		price, ok := prices[p.Asset]
+		if !ok {
+			price = p.AverageCost()
+		}
+		total = total.Add(p.PnL(price))
+	}
+
+	return total
+}
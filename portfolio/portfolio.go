@@ -0,0 +1,293 @@
+/*
+Package portfolio computes the cost basis of assets from their transaction history. Every acquisition opens a lot
+holding the amount acquired and what it cost, every disposal closes lots in the order of the selected Method and
+realizes the difference between the proceeds and the cost of the closed lots.
+
+	book, err := portfolio.Build(portfolio.FIFO, "USD", portfolio.FromCoinbase(transactions.Data, exchange.AssetMap{}))
+	p := book.Position("BTC")
+	fmt.Println(p.AverageCost(), p.Realized, p.Unrealized(spot))
+*/
+package portfolio
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/KalebHawkins/crypto-client/money"
+	"github.com/shopspring/decimal"
+)
+
+// Method is the order in which lots are closed by a disposal.
+type Method string
+
+// The supported cost basis methods.
+const (
+	FIFO Method = "fifo" // the oldest lot first
+	LIFO Method = "lifo" // the newest lot first
+	HIFO Method = "hifo" // the lot with the highest unit cost first
+)
+
+// Methods lists every supported cost basis method.
+var Methods = []Method{FIFO, LIFO, HIFO}
+
+// ParseMethod returns the method named `s`, ignoring case.
+func ParseMethod(s string) (Method, error) {
+	for _, m := range Methods {
+		if strings.EqualFold(s, string(m)) {
+			return m, nil
+		}
+	}
+
+	return "", fmt.Errorf("unknown cost basis method %q, use fifo, lifo or hifo", s)
+}
+
+// These constants are the kinds of an Event.
+const (
+	// Acquire opens a lot, for example a buy, a reward or a receive. Value is the cost of the lot.
+	Acquire = "acquire"
+
+	// Dispose closes lots and realizes a gain or loss, for example a sell or a trade. Value is the proceeds.
+	Dispose = "dispose"
+
+	// TransferOut closes lots without realizing anything, for example a send to a wallet of your own.
+	TransferOut = "transfer-out"
+)
+
+// Event changes the lots of an asset. Amount is always positive, Value is in the book's currency.
+type Event struct {
+	Time   time.Time
+	Kind   string
+	Asset  string
+	Amount decimal.Decimal
+	Value  money.Money
+}
+
+// Lot is an amount of an asset acquired at one time for one cost. Amount and Cost shrink as the lot is closed.
+type Lot struct {
+	Acquired time.Time
+	Amount   decimal.Decimal
+	Cost     money.Money
+}
+
+// UnitCost returns the cost of one unit of the lot.
+func (l Lot) UnitCost() money.Money {
+	if l.Amount.IsZero() {
+		return money.Zero(l.Cost.Currency)
+	}
+
+	return l.Cost.Div(l.Amount)
+}
+
+// Disposal is a disposal and the lots it closed.
+type Disposal struct {
+	Time     time.Time
+	Amount   decimal.Decimal
+	Proceeds money.Money
+	Cost     money.Money
+
+	// Uncovered is the part of Amount no lot was left for. It is disposed of at zero cost.
+	Uncovered decimal.Decimal
+}
+
+// Gain returns the realized gain of the disposal, negative for a loss.
+func (d Disposal) Gain() money.Money {
+	return d.Proceeds.Sub(d.Cost)
+}
+
+// Position is the open lots and the disposals of a single asset.
+type Position struct {
+	Asset     string
+	Lots      []Lot
+	Disposals []Disposal
+
+	// Realized is the sum of the gains of every disposal.
+	Realized money.Money
+}
+
+// Amount returns the amount held in the open lots.
+func (p Position) Amount() decimal.Decimal {
+	amount := decimal.Zero
+	for _, l := range p.Lots {
+		amount = amount.Add(l.Amount)
+	}
+
+	return amount
+}
+
+// Cost returns the cost of the open lots.
+func (p Position) Cost() money.Money {
+	cost := money.Zero(p.Realized.Currency)
+	for _, l := range p.Lots {
+		cost = cost.Add(l.Cost)
+	}
+
+	return cost
+}
+
+// AverageCost returns the cost of one unit held, averaged over the open lots. It is zero when nothing is held.
+func (p Position) AverageCost() money.Money {
+	amount := p.Amount()
+	if amount.IsZero() {
+		return p.Cost()
+	}
+
+	return p.Cost().Div(amount)
+}
+
+// Unrealized returns the gain of the open lots if they were disposed of at `price` per unit.
+func (p Position) Unrealized(price money.Money) money.Money {
+	return price.Mul(p.Amount()).Sub(p.Cost())
+}
+
+// Book tracks the positions of every asset in a single currency using one cost basis method.
+type Book struct {
+	Method   Method
+	Currency string
+	// Skipped counts the events Build left out because they are valued in another currency.
+	Skipped int
+
+	positions map[string]*Position
+}
+
+// NewBook returns an empty book valuing lots in `currency`.
+func NewBook(method Method, currency string) *Book {
+	return &Book{Method: method, Currency: strings.ToUpper(currency), positions: map[string]*Position{}}
+}
+
+// Build returns a book holding `events`, which are applied oldest first. Events valued in another currency than
+// `currency` are left out and counted in Book.Skipped. An error is returned if the method is unknown or an event is
+// invalid.
+func Build(method Method, currency string, events []Event) (*Book, error) {
+	if _, err := ParseMethod(string(method)); err != nil {
+		return nil, err
+	}
+
+	sorted := append([]Event(nil), events...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Time.Before(sorted[j].Time) })
+
+	b := NewBook(method, currency)
+	for _, e := range sorted {
+		if !b.valuedIn(e) {
+			b.Skipped++
+			continue
+		}
+		if err := b.Apply(e); err != nil {
+			return nil, err
+		}
+	}
+
+	return b, nil
+}
+
+// Apply adds `e` to the book. Events have to be applied in the order they happened. An error is returned if the
+// amount is not positive, the value is not in the book's currency or the kind is unknown.
+func (b *Book) Apply(e Event) error {
+	if !e.Amount.IsPositive() {
+		return fmt.Errorf("portfolio: %s of %s on %s has no positive amount", e.Kind, e.Asset, e.Time.Format("2006-01-02"))
+	}
+
+	if !b.valuedIn(e) {
+		return fmt.Errorf("portfolio: %s of %s on %s is valued in %s rather than %s", e.Kind, e.Asset,
+			e.Time.Format("2006-01-02"), e.Value.Currency, b.Currency)
+	}
+	value := money.New(e.Value.Amount.Abs(), b.Currency)
+
+	p := b.position(e.Asset)
+	switch e.Kind {
+	case Acquire:
+		p.Lots = append(p.Lots, Lot{Acquired: e.Time, Amount: e.Amount, Cost: value})
+	case Dispose:
+		cost, uncovered := b.close(p, e.Amount)
+		d := Disposal{Time: e.Time, Amount: e.Amount, Proceeds: value, Cost: cost, Uncovered: uncovered}
+		p.Disposals = append(p.Disposals, d)
+		p.Realized = p.Realized.Add(d.Gain())
+	case TransferOut:
+		b.close(p, e.Amount)
+	default:
+		return fmt.Errorf("portfolio: unknown event kind %q", e.Kind)
+	}
+
+	return nil
+}
+
+// valuedIn reports whether `e` is valued in the book's currency. Events without a currency are.
+func (b *Book) valuedIn(e Event) bool {
+	return e.Value.Currency == "" || strings.EqualFold(e.Value.Currency, b.Currency)
+}
+
+// Position returns the position of `asset`. It is empty if the asset was never held.
+func (b *Book) Position(asset string) Position {
+	if p, ok := b.positions[strings.ToUpper(asset)]; ok {
+		return *p
+	}
+
+	return Position{Asset: strings.ToUpper(asset), Realized: money.Zero(b.Currency)}
+}
+
+// Positions returns the position of every asset sorted by asset.
+func (b *Book) Positions() []Position {
+	var positions []Position
+	for _, p := range b.positions {
+		positions = append(positions, *p)
+	}
+	sort.Slice(positions, func(i, j int) bool { return positions[i].Asset < positions[j].Asset })
+
+	return positions
+}
+
+// position returns the position of `asset`, creating it if needed.
+func (b *Book) position(asset string) *Position {
+	asset = strings.ToUpper(asset)
+	p, ok := b.positions[asset]
+	if !ok {
+		p = &Position{Asset: asset, Realized: money.Zero(b.Currency)}
+		b.positions[asset] = p
+	}
+
+	return p
+}
+
+// close closes `amount` of the lots of `p` in the order of the book's method and returns their cost along with
+// the amount no lot was left for.
+func (b *Book) close(p *Position, amount decimal.Decimal) (money.Money, decimal.Decimal) {
+	cost := money.Zero(b.Currency)
+
+	for amount.IsPositive() && len(p.Lots) > 0 {
+		i := b.next(p.Lots)
+		lot := &p.Lots[i]
+
+		if lot.Amount.GreaterThan(amount) {
+			part := lot.Cost.Mul(amount).Div(lot.Amount)
+			cost = cost.Add(part)
+			lot.Cost = lot.Cost.Sub(part)
+			lot.Amount = lot.Amount.Sub(amount)
+			return cost, decimal.Zero
+		}
+
+		cost = cost.Add(lot.Cost)
+		amount = amount.Sub(lot.Amount)
+		p.Lots = append(p.Lots[:i], p.Lots[i+1:]...)
+	}
+
+	return cost, amount
+}
+
+// next returns the index of the lot closed next. Lots are kept in the order they were acquired.
+func (b *Book) next(lots []Lot) int {
+	switch b.Method {
+	case LIFO:
+		return len(lots) - 1
+	case HIFO:
+		best := 0
+		for i, l := range lots {
+			if l.UnitCost().Amount.GreaterThan(lots[best].UnitCost().Amount) {
+				best = i
+			}
+		}
+		return best
+	}
+
+	return 0
+}
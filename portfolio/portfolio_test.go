@@ -0,0 +1,180 @@
+package portfolio
+
+import (
+	"testing"
+	"time"
+
+	"github.com/KalebHawkins/crypto-client/money"
+	"github.com/shopspring/decimal"
+)
+
+func day(d int) time.Time {
+	return time.Date(2021, time.January, d, 0, 0, 0, 0, time.UTC)
+}
+
+func event(kind string, d int, amount, value string) Event {
+	return Event{
+		Time:   day(d),
+		Kind:   kind,
+		Asset:  "BTC",
+		Amount: decimal.RequireFromString(amount),
+		Value:  money.New(decimal.RequireFromString(value), "USD"),
+	}
+}
+
+func usd(s string) money.Money {
+	return money.New(decimal.RequireFromString(s), "USD")
+}
+
+func TestBuildPartialLotClose(t *testing.T) {
+	// Three lots with unit costs of 100, 300 and 200, then a disposal of 1.5 that closes part of a lot under
+	// every method.
+	events := []Event{
+		event(Dispose, 4, "1.5", "600"),
+		event(Acquire, 1, "1", "100"),
+		event(Acquire, 2, "1", "300"),
+		event(Acquire, 3, "2", "400"),
+	}
+
+	tests := []struct {
+		method   Method
+		cost     string
+		realized string
+		lots     []string
+		lotCost  string
+	}{
+		{method: FIFO, cost: "250", realized: "350", lots: []string{"0.5", "2"}, lotCost: "550"},
+		{method: LIFO, cost: "300", realized: "300", lots: []string{"1", "1", "0.5"}, lotCost: "500"},
+		{method: HIFO, cost: "400", realized: "200", lots: []string{"1", "1.5"}, lotCost: "400"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.method), func(t *testing.T) {
+			b, err := Build(tt.method, "usd", events)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			p := b.Position("btc")
+			if len(p.Disposals) != 1 {
+				t.Fatalf("got %d disposals, want 1", len(p.Disposals))
+			}
+
+			d := p.Disposals[0]
+			if d.Cost.Cmp(usd(tt.cost)) != 0 {
+				t.Errorf("disposal cost = %s, want %s", d.Cost, tt.cost)
+			}
+			if !d.Uncovered.IsZero() {
+				t.Errorf("disposal uncovered = %s, want 0", d.Uncovered)
+			}
+			if p.Realized.Cmp(usd(tt.realized)) != 0 {
+				t.Errorf("realized = %s, want %s", p.Realized, tt.realized)
+			}
+
+			if len(p.Lots) != len(tt.lots) {
+				t.Fatalf("got %d open lots, want %d", len(p.Lots), len(tt.lots))
+			}
+			for i, want := range tt.lots {
+				if !p.Lots[i].Amount.Equal(decimal.RequireFromString(want)) {
+					t.Errorf("lot %d amount = %s, want %s", i, p.Lots[i].Amount, want)
+				}
+			}
+
+			if !p.Amount().Equal(decimal.RequireFromString("2.5")) {
+				t.Errorf("amount = %s, want 2.5", p.Amount())
+			}
+			if p.Cost().Cmp(usd(tt.lotCost)) != 0 {
+				t.Errorf("open cost = %s, want %s", p.Cost(), tt.lotCost)
+			}
+		})
+	}
+}
+
+func TestBuildUncoveredDisposal(t *testing.T) {
+	b, err := Build(FIFO, "USD", []Event{
+		event(Acquire, 1, "1", "100"),
+		event(Dispose, 2, "1.5", "300"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := b.Position("BTC")
+	d := p.Disposals[0]
+	if !d.Uncovered.Equal(decimal.RequireFromString("0.5")) {
+		t.Errorf("uncovered = %s, want 0.5", d.Uncovered)
+	}
+	if p.Realized.Cmp(usd("200")) != 0 {
+		t.Errorf("realized = %s, want 200", p.Realized)
+	}
+	if len(p.Lots) != 0 {
+		t.Errorf("got %d open lots, want 0", len(p.Lots))
+	}
+}
+
+func TestBuildTransferOutRealizesNothing(t *testing.T) {
+	b, err := Build(FIFO, "USD", []Event{
+		event(Acquire, 1, "2", "200"),
+		event(TransferOut, 2, "0.5", "0"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := b.Position("BTC")
+	if len(p.Disposals) != 0 {
+		t.Errorf("got %d disposals, want 0", len(p.Disposals))
+	}
+	if !p.Realized.IsZero() {
+		t.Errorf("realized = %s, want 0", p.Realized)
+	}
+	if p.Cost().Cmp(usd("150")) != 0 {
+		t.Errorf("open cost = %s, want 150", p.Cost())
+	}
+}
+
+func TestBuildSkipsOtherCurrency(t *testing.T) {
+	eur := event(Acquire, 2, "1", "100")
+	eur.Value = money.New(decimal.NewFromInt(100), "EUR")
+
+	b, err := Build(FIFO, "USD", []Event{event(Acquire, 1, "2", "200"), eur, event(Dispose, 3, "1", "150")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.Skipped != 1 {
+		t.Errorf("skipped = %d, want 1", b.Skipped)
+	}
+	p := b.Position("BTC")
+	if !p.Amount().Equal(decimal.NewFromInt(1)) {
+		t.Errorf("amount = %s, want 1", p.Amount())
+	}
+	if p.Realized.Cmp(usd("50")) != 0 {
+		t.Errorf("realized = %s, want 50", p.Realized)
+	}
+
+	if err := NewBook(FIFO, "USD").Apply(eur); err == nil {
+		t.Error("Apply: expected an error")
+	}
+}
+
+func TestBuildErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		method Method
+		event  Event
+	}{
+		{name: "unknown method", method: "avg", event: event(Acquire, 1, "1", "100")},
+		{name: "zero amount", method: FIFO, event: event(Acquire, 1, "0", "100")},
+		{name: "negative amount", method: FIFO, event: event(Dispose, 1, "-1", "100")},
+		{name: "unknown kind", method: FIFO, event: event("stake", 1, "1", "100")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Build(tt.method, "USD", []Event{tt.event}); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
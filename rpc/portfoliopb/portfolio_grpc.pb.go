@@ -0,0 +1,249 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: portfoliopb/portfolio.proto
+
+package portfoliopb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// PortfolioServiceClient is the client API for PortfolioService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PortfolioServiceClient interface {
+	// GetPortfolio returns every tracked wallet with a balance, valued at the spot price in the native currency.
+	GetPortfolio(ctx context.Context, in *GetPortfolioRequest, opts ...grpc.CallOption) (*Portfolio, error)
+	// GetPrice returns the current price of a product.
+	GetPrice(ctx context.Context, in *GetPriceRequest, opts ...grpc.CallOption) (*Price, error)
+	// ListTransactions returns the transactions of the tracked wallets, newest first.
+	ListTransactions(ctx context.Context, in *ListTransactionsRequest, opts ...grpc.CallOption) (*ListTransactionsResponse, error)
+	// WatchPrices streams a price every time one of the products trades, until the call is canceled.
+	WatchPrices(ctx context.Context, in *WatchPricesRequest, opts ...grpc.CallOption) (PortfolioService_WatchPricesClient, error)
+}
+
+type portfolioServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPortfolioServiceClient(cc grpc.ClientConnInterface) PortfolioServiceClient {
+	return &portfolioServiceClient{cc}
+}
+
+func (c *portfolioServiceClient) GetPortfolio(ctx context.Context, in *GetPortfolioRequest, opts ...grpc.CallOption) (*Portfolio, error) {
+	out := new(Portfolio)
+	err := c.cc.Invoke(ctx, "/cryptoclient.v1.PortfolioService/GetPortfolio", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *portfolioServiceClient) GetPrice(ctx context.Context, in *GetPriceRequest, opts ...grpc.CallOption) (*Price, error) {
+	out := new(Price)
+	err := c.cc.Invoke(ctx, "/cryptoclient.v1.PortfolioService/GetPrice", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *portfolioServiceClient) ListTransactions(ctx context.Context, in *ListTransactionsRequest, opts ...grpc.CallOption) (*ListTransactionsResponse, error) {
+	out := new(ListTransactionsResponse)
+	err := c.cc.Invoke(ctx, "/cryptoclient.v1.PortfolioService/ListTransactions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *portfolioServiceClient) WatchPrices(ctx context.Context, in *WatchPricesRequest, opts ...grpc.CallOption) (PortfolioService_WatchPricesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &PortfolioService_ServiceDesc.Streams[0], "/cryptoclient.v1.PortfolioService/WatchPrices", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &portfolioServiceWatchPricesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PortfolioService_WatchPricesClient interface {
+	Recv() (*Price, error)
+	grpc.ClientStream
+}
+
+type portfolioServiceWatchPricesClient struct {
+	grpc.ClientStream
+}
+
+func (x *portfolioServiceWatchPricesClient) Recv() (*Price, error) {
+	m := new(Price)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// PortfolioServiceServer is the server API for PortfolioService service.
+// All implementations must embed UnimplementedPortfolioServiceServer
+// for forward compatibility
+type PortfolioServiceServer interface {
+	// GetPortfolio returns every tracked wallet with a balance, valued at the spot price in the native currency.
+	GetPortfolio(context.Context, *GetPortfolioRequest) (*Portfolio, error)
+	// GetPrice returns the current price of a product.
+	GetPrice(context.Context, *GetPriceRequest) (*Price, error)
+	// ListTransactions returns the transactions of the tracked wallets, newest first.
+	ListTransactions(context.Context, *ListTransactionsRequest) (*ListTransactionsResponse, error)
+	// WatchPrices streams a price every time one of the products trades, until the call is canceled.
+	WatchPrices(*WatchPricesRequest, PortfolioService_WatchPricesServer) error
+	mustEmbedUnimplementedPortfolioServiceServer()
+}
+
+// UnimplementedPortfolioServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedPortfolioServiceServer struct {
+}
+
+func (UnimplementedPortfolioServiceServer) GetPortfolio(context.Context, *GetPortfolioRequest) (*Portfolio, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPortfolio not implemented")
+}
+func (UnimplementedPortfolioServiceServer) GetPrice(context.Context, *GetPriceRequest) (*Price, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPrice not implemented")
+}
+func (UnimplementedPortfolioServiceServer) ListTransactions(context.Context, *ListTransactionsRequest) (*ListTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTransactions not implemented")
+}
+func (UnimplementedPortfolioServiceServer) WatchPrices(*WatchPricesRequest, PortfolioService_WatchPricesServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchPrices not implemented")
+}
+func (UnimplementedPortfolioServiceServer) mustEmbedUnimplementedPortfolioServiceServer() {}
+
+// UnsafePortfolioServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PortfolioServiceServer will
+// result in compilation errors.
+type UnsafePortfolioServiceServer interface {
+	mustEmbedUnimplementedPortfolioServiceServer()
+}
+
+func RegisterPortfolioServiceServer(s grpc.ServiceRegistrar, srv PortfolioServiceServer) {
+	s.RegisterService(&PortfolioService_ServiceDesc, srv)
+}
+
+func _PortfolioService_GetPortfolio_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPortfolioRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PortfolioServiceServer).GetPortfolio(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cryptoclient.v1.PortfolioService/GetPortfolio",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PortfolioServiceServer).GetPortfolio(ctx, req.(*GetPortfolioRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PortfolioService_GetPrice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPriceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PortfolioServiceServer).GetPrice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cryptoclient.v1.PortfolioService/GetPrice",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PortfolioServiceServer).GetPrice(ctx, req.(*GetPriceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PortfolioService_ListTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PortfolioServiceServer).ListTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cryptoclient.v1.PortfolioService/ListTransactions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PortfolioServiceServer).ListTransactions(ctx, req.(*ListTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PortfolioService_WatchPrices_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchPricesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PortfolioServiceServer).WatchPrices(m, &portfolioServiceWatchPricesServer{stream})
+}
+
+type PortfolioService_WatchPricesServer interface {
+	Send(*Price) error
+	grpc.ServerStream
+}
+
+type portfolioServiceWatchPricesServer struct {
+	grpc.ServerStream
+}
+
+func (x *portfolioServiceWatchPricesServer) Send(m *Price) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// PortfolioService_ServiceDesc is the grpc.ServiceDesc for PortfolioService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PortfolioService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "cryptoclient.v1.PortfolioService",
+	HandlerType: (*PortfolioServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPortfolio",
+			Handler:    _PortfolioService_GetPortfolio_Handler,
+		},
+		{
+			MethodName: "GetPrice",
+			Handler:    _PortfolioService_GetPrice_Handler,
+		},
+		{
+			MethodName: "ListTransactions",
+			Handler:    _PortfolioService_ListTransactions_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchPrices",
+			Handler:       _PortfolioService_WatchPrices_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "portfoliopb/portfolio.proto",
+}
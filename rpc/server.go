@@ -0,0 +1,361 @@
+/*
+Package rpc serves the Coinbase portfolio over gRPC for services embedding crypto-client. The service is defined in
+portfoliopb/portfolio.proto, which also holds the generated client: dial the server and call
+portfoliopb.NewPortfolioServiceClient() on the connection.
+*/
+package rpc
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative portfoliopb/portfolio.proto
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/KalebHawkins/crypto-client/coinbase"
+	"github.com/KalebHawkins/crypto-client/coinbase/stream"
+	"github.com/KalebHawkins/crypto-client/exchange"
+	"github.com/KalebHawkins/crypto-client/money"
+	"github.com/KalebHawkins/crypto-client/portfolio"
+	"github.com/KalebHawkins/crypto-client/rpc/portfoliopb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Server implements portfoliopb.PortfolioServiceServer on top of a Coinbase client. Register it with
+// portfoliopb.RegisterPortfolioServiceServer().
+type Server struct {
+	portfoliopb.UnimplementedPortfolioServiceServer
+
+	// Client looks up the account.
+	Client coinbase.Client
+	// Stream delivers the live prices of WatchPrices.
+	Stream *stream.Client
+	// Tracked, when set, reports whether a wallet is served, for example to leave out ignored wallets.
+	Tracked func(a Wallet) bool
+	// Imported, when set, returns cost basis events of an asset from outside Coinbase, for example trades imported
+	// from other exchanges.
+	Imported func(asset string) ([]portfolio.Event, error)
+	// Assets names renamed assets by their current ticker, so the cost basis of an asset covers its wallets under
+	// every ticker. The zero value renames nothing.
+	Assets exchange.AssetMap
+}
+
+// Wallet identifies an account for Server.Tracked.
+type Wallet struct {
+	ID   string
+	Name string
+}
+
+// NewServer returns a server for `c` serving every wallet and streaming prices from the public Coinbase feed.
+func NewServer(c coinbase.Client) *Server {
+	return &Server{Client: c, Stream: stream.New()}
+}
+
+// SkippedEventsTrailer is the trailer of GetPortfolio counting the transactions left out of the gains because they
+// are valued in another currency than the native one. It is only sent when there are any.
+const SkippedEventsTrailer = "skipped-events"
+
+// GetPortfolio returns every tracked wallet with a balance, valued at the spot price in the native currency.
+func (s *Server) GetPortfolio(ctx context.Context, req *portfoliopb.GetPortfolioRequest) (*portfoliopb.Portfolio, error) {
+	method := portfolio.FIFO
+	if req.CostBasis != "" {
+		m, err := portfolio.ParseMethod(req.CostBasis)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		method = m
+	}
+
+	user, err := s.Client.GetUserProfile()
+	if err != nil {
+		return nil, apiStatus(err)
+	}
+	native := user.Data.NativeCurrency
+
+	accounts, err := s.Client.GetAccount()
+	if err != nil {
+		return nil, apiStatus(err)
+	}
+
+	p := &portfoliopb.Portfolio{Currency: native, AsOf: timestamppb.Now()}
+	totalValue := money.Zero(native)
+	total := portfolio.PnL{Realized: money.Zero(native), Unrealized: money.Zero(native)}
+	books := map[string]*portfolio.Book{}
+	skipped := 0
+	for _, a := range accounts.Data {
+		if !a.Balance.Amount.IsPositive() || !s.tracked(a.ID, a.Name) {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, status.FromContextError(err).Err()
+		}
+		asset := a.Balance.Currency
+
+		spot, err := s.Client.GetPrice(asset+"-"+native, coinbase.Spot)
+		if err != nil {
+			return nil, apiStatus(err)
+		}
+
+		canonical := s.Assets.Canonical(asset)
+		book, counted := books[canonical]
+		if !counted {
+			book, err = s.book(method, native, canonical, accounts)
+			if err != nil {
+				return nil, err
+			}
+			books[canonical] = book
+			skipped += book.Skipped
+		}
+		position := book.Position(canonical)
+		pnl := position.PnL(spot.Data.Money)
+		value := spot.Data.Mul(a.Balance.Amount)
+
+		p.Holdings = append(p.Holdings, &portfoliopb.Holding{
+			WalletId:       a.ID,
+			WalletName:     a.Name,
+			Asset:          asset,
+			Balance:        toMoney(a.Balance),
+			SpotPrice:      toMoney(spot.Data.Money),
+			Value:          toMoney(value),
+			AverageCost:    toMoney(position.AverageCost()),
+			RealizedGain:   toMoney(pnl.Realized),
+			UnrealizedGain: toMoney(pnl.Unrealized),
+			TotalReturn:    toMoney(pnl.Total()),
+		})
+
+		totalValue = totalValue.Add(value)
+		if !counted {
+			total = total.Add(pnl)
+		}
+	}
+
+	p.TotalValue = toMoney(totalValue)
+	p.RealizedGain = toMoney(total.Realized)
+	p.UnrealizedGain = toMoney(total.Unrealized)
+	p.TotalReturn = toMoney(total.Total())
+
+	if skipped > 0 {
+		grpc.SetTrailer(ctx, metadata.Pairs(SkippedEventsTrailer, strconv.Itoa(skipped)))
+	}
+
+	return p, nil
+}
+
+// book returns the book of `asset`, under its current ticker, over the transactions of every tracked wallet in
+// `accounts` holding it under any of its tickers and the events of Imported.
+func (s *Server) book(method portfolio.Method, native, asset string, accounts coinbase.Account) (*portfolio.Book, error) {
+	var transactions []coinbase.TransactionData
+	for _, a := range accounts.Data {
+		if s.Assets.Canonical(a.Balance.Currency) != asset || !s.tracked(a.ID, a.Name) {
+			continue
+		}
+
+		t, err := s.Client.GetTransactionHistory(a.ID)
+		if err != nil {
+			return nil, apiStatus(err)
+		}
+		transactions = append(transactions, t.Data...)
+	}
+
+	events := portfolio.FromCoinbase(transactions, s.Assets)
+	if s.Imported != nil {
+		for _, ticker := range append([]string{asset}, s.Assets.Aliases(asset)...) {
+			imported, err := s.Imported(ticker)
+			if err != nil {
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+			for _, e := range imported {
+				e.Asset = asset
+				events = append(events, e)
+			}
+		}
+	}
+
+	book, err := portfolio.Build(method, native, events)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return book, nil
+}
+
+// GetPrice returns the current buy, sell or spot price of a product.
+func (s *Server) GetPrice(ctx context.Context, req *portfoliopb.GetPriceRequest) (*portfoliopb.Price, error) {
+	if req.ProductId == "" {
+		return nil, status.Error(codes.InvalidArgument, "product_id is required")
+	}
+
+	priceType := strings.ToLower(req.Type)
+	switch priceType {
+	case "":
+		priceType = coinbase.Spot
+	case coinbase.Buy, coinbase.Sell, coinbase.Spot:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "invalid price type %q, use buy, sell or spot", req.Type)
+	}
+
+	product := productID(req.ProductId)
+	p, err := s.Client.GetPrice(product, priceType)
+	if err != nil {
+		return nil, apiStatus(err)
+	}
+
+	return &portfoliopb.Price{ProductId: product, Type: priceType, Price: toMoney(p.Data.Money), Time: timestamppb.Now()}, nil
+}
+
+// ListTransactions returns the transactions of one or every tracked wallet matching the request, newest first.
+func (s *Server) ListTransactions(ctx context.Context, req *portfoliopb.ListTransactionsRequest) (*portfoliopb.ListTransactionsResponse, error) {
+	if req.Limit < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit must not be negative")
+	}
+
+	accounts, err := s.Client.GetAccount()
+	if err != nil {
+		return nil, apiStatus(err)
+	}
+
+	filter := coinbase.TransactionFilter{Types: req.Types}
+	for _, a := range req.Assets {
+		filter.Assets = append(filter.Assets, strings.ToUpper(a))
+	}
+	if req.Since != nil {
+		filter.Since = req.Since.AsTime()
+	}
+	if req.Until != nil {
+		filter.Until = req.Until.AsTime()
+	}
+
+	var selected []*portfoliopb.Transaction
+	found := false
+	for _, a := range accounts.Data {
+		if req.WalletId != "" && a.ID != req.WalletId || !s.tracked(a.ID, a.Name) {
+			continue
+		}
+		found = true
+
+		if err := ctx.Err(); err != nil {
+			return nil, status.FromContextError(err).Err()
+		}
+
+		transactions, err := s.Client.GetTransactionHistory(a.ID)
+		if err != nil {
+			return nil, apiStatus(err)
+		}
+
+		for _, t := range filter.Apply(transactions.Data) {
+			selected = append(selected, &portfoliopb.Transaction{
+				Id:           t.ID,
+				WalletId:     a.ID,
+				Type:         t.Type,
+				Status:       t.Status,
+				Amount:       toMoney(t.Amount),
+				NativeAmount: toMoney(t.NativeAmount),
+				CreatedAt:    timestamppb.New(t.CreatedAt),
+				Title:        t.Details.Title,
+			})
+		}
+	}
+
+	if req.WalletId != "" && !found {
+		return nil, status.Errorf(codes.NotFound, "no tracked wallet %s", req.WalletId)
+	}
+
+	sortNewestFirst(selected)
+	if req.Limit > 0 && len(selected) > int(req.Limit) {
+		selected = selected[:req.Limit]
+	}
+
+	return &portfoliopb.ListTransactionsResponse{Transactions: selected}, nil
+}
+
+// WatchPrices streams the spot price of the requested products every time one of them trades, at most once per
+// min_interval and product, until the call is canceled.
+func (s *Server) WatchPrices(req *portfoliopb.WatchPricesRequest, srv portfoliopb.PortfolioService_WatchPricesServer) error {
+	if len(req.ProductIds) == 0 {
+		return status.Error(codes.InvalidArgument, "product_ids is required")
+	}
+
+	var minInterval time.Duration
+	if req.MinInterval != nil {
+		minInterval = req.MinInterval.AsDuration()
+	}
+
+	var products []string
+	for _, p := range req.ProductIds {
+		products = append(products, productID(p))
+	}
+
+	sent := map[string]time.Time{}
+	for t := range s.Stream.Ticker(srv.Context(), products...) {
+		if last, ok := sent[t.ProductID]; ok && t.Time.Sub(last) < minInterval {
+			continue
+		}
+		sent[t.ProductID] = t.Time
+
+		quote := t.ProductID[strings.LastIndex(t.ProductID, "-")+1:]
+		err := srv.Send(&portfoliopb.Price{
+			ProductId: t.ProductID,
+			Type:      coinbase.Spot,
+			Price:     &portfoliopb.Money{Amount: t.Price.String(), Currency: quote},
+			Time:      timestamppb.New(t.Time),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return status.FromContextError(srv.Context().Err()).Err()
+}
+
+// ─── HELPER FUNCTIONS ───────────────────────────────────────────────────────────
+
+// tracked reports whether the wallet is served.
+func (s *Server) tracked(id string, name string) bool {
+	return s.Tracked == nil || s.Tracked(Wallet{ID: id, Name: name})
+}
+
+// apiStatus maps a Coinbase API error to the matching gRPC status.
+func apiStatus(err error) error {
+	code := codes.Unavailable
+	switch {
+	case errors.Is(err, coinbase.ErrUnauthorized):
+		code = codes.Unauthenticated
+	case errors.Is(err, coinbase.ErrForbidden):
+		code = codes.PermissionDenied
+	case errors.Is(err, coinbase.ErrNotFound):
+		code = codes.NotFound
+	case errors.Is(err, coinbase.ErrRateLimited):
+		code = codes.ResourceExhausted
+	}
+
+	return status.Error(code, err.Error())
+}
+
+// toMoney converts `m` to its message, keeping every digit of the amount.
+func toMoney(m money.Money) *portfoliopb.Money {
+	return &portfoliopb.Money{Amount: m.Amount.String(), Currency: m.Currency}
+}
+
+// productID upper cases a currency pair such as btc-eur and quotes a bare asset such as ETH in USD.
+func productID(s string) string {
+	p := strings.ToUpper(s)
+	if !strings.Contains(p, "-") {
+		p += "-USD"
+	}
+
+	return p
+}
+
+// sortNewestFirst sorts transactions of several wallets by creation time, newest first.
+func sortNewestFirst(transactions []*portfoliopb.Transaction) {
+	sort.SliceStable(transactions, func(i, j int) bool {
+		return transactions[i].CreatedAt.AsTime().After(transactions[j].CreatedAt.AsTime())
+	})
+}